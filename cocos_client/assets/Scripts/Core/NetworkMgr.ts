@@ -1,4 +1,5 @@
 import { EventMgr } from '../Events/EventMgr';
+import { loadTileRegistry } from './TileConfig';
 
 export class NetworkMgr {
     private static _instance: NetworkMgr = null!;
@@ -69,6 +70,8 @@ export class NetworkMgr {
         try {
             if (action === "join_room") {
                 innerData = window.mahjong_pb.encodeJoinRoomReq(data) as unknown as Uint8Array;
+            } else if (action === "tenpai_hints") {
+                innerData = window.mahjong_pb.encodeTenpaiHintsReq(data) as unknown as Uint8Array;
             } else if (action === "discard_tile" || action === "player_action") {
                 // 若兩者共用 pb.PlayerActionData
                 innerData = window.mahjong_pb.encodePlayerActionData(data) as unknown as Uint8Array;
@@ -117,6 +120,15 @@ export class NetworkMgr {
                     innerData = window.mahjong_pb.decodeSyncStateData(msg.data);
                 } else if (msg.action === "deal_tiles") {
                     innerData = window.mahjong_pb.decodeDealTilesData(msg.data);
+                } else if (msg.action === "tile_registry") {
+                    innerData = window.mahjong_pb.decodeTileRegistryData(msg.data);
+                    loadTileRegistry(innerData.tiles || []);
+                } else if (msg.action === "tenpai_hints") {
+                    innerData = window.mahjong_pb.decodeTenpaiHintsData(msg.data);
+                } else if (msg.action === "round_result") {
+                    innerData = window.mahjong_pb.decodeRoundResultData(msg.data);
+                } else if (msg.action === "game_over") {
+                    innerData = window.mahjong_pb.decodeGameOverData(msg.data);
                 } else if (msg.action.endsWith("_res")) {
                     if (msg.action === "join_room_res") {
                         innerData = window.mahjong_pb.decodeJoinRoomRes(msg.data);
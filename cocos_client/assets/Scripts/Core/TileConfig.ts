@@ -31,31 +31,34 @@ const WanNumbers: Record<number, string> = {
     6: "六", 7: "七", 8: "八", 9: "九"
 };
 
+/** 伺服器下發的牌 ID 登錄表中的一張牌 (對應 pb.TileDefData) */
+export interface TileDef {
+    id?: number;
+    type?: number;
+    value?: number;
+}
+
+// 牌 ID → 類型與數值，由 tile_registry 載入
+const tileRegistry = new Map<number, TileInfo>();
+
 /**
- * 根據牌 ID (1-144) 解析出 TileType 和 Value
- * 對應 Go 端 GenerateAllTiles() 的 ID 分配:
- *   1-36:  萬 (1-9萬，各4張)
- *   37-72: 筒 (1-9筒，各4張)
- *   73-108: 條 (1-9條，各4張)
- *   109-124: 風 (東南西北，各4張)
- *   125-136: 元 (中發白，各4張)
- *   137-144: 花 (梅蘭竹菊春夏秋冬，各1張)
+ * 載入伺服器下發的牌 ID 登錄表 (連線時的 tile_registry 訊息)
+ * 客戶端不自行推算 ID 分配，一律以登錄表為準
  */
-export function parseTileId(id: number): TileInfo {
-    if (id >= 1 && id <= 36) {
-        return { type: TileType.Wan, value: Math.ceil(id / 4) };
-    } else if (id >= 37 && id <= 72) {
-        return { type: TileType.Tong, value: Math.ceil((id - 36) / 4) };
-    } else if (id >= 73 && id <= 108) {
-        return { type: TileType.Tiao, value: Math.ceil((id - 72) / 4) };
-    } else if (id >= 109 && id <= 124) {
-        return { type: TileType.Wind, value: Math.ceil((id - 108) / 4) };
-    } else if (id >= 125 && id <= 136) {
-        return { type: TileType.Dragon, value: Math.ceil((id - 124) / 4) };
-    } else if (id >= 137 && id <= 144) {
-        return { type: TileType.Flower, value: id - 136 };
+export function loadTileRegistry(tiles: TileDef[]) {
+    tileRegistry.clear();
+    for (const def of tiles) {
+        // proto3 省略零值欄位，萬子 (type 0) 的 type 會是 undefined
+        tileRegistry.set(def.id || 0, { type: (def.type || 0) as TileType, value: def.value || 0 });
     }
-    return { type: TileType.Wan, value: 1 }; // fallback
+}
+
+/**
+ * 根據牌 ID (1-144) 查出 TileType 和 Value
+ * 登錄表尚未載入或 ID 不存在時回傳 null
+ */
+export function parseTileId(id: number): TileInfo | null {
+    return tileRegistry.get(id) || null;
 }
 
 /**
@@ -64,9 +64,13 @@ export interface PlayerInfo {
   name?: string;
   seat?: number;
   score?: number;
-  hand_tiles?: number[];
-  discarded_tiles?: number[];
+  hand_count?: number;
+  connection_status?: string;
   melds?: MeldData[];
+  flowers?: number[];
+  total_tai?: number;
+  patterns?: { [key: string]: number };
+  is_dead_hand?: boolean;
 }
 
 export function encodePlayerInfo(message: PlayerInfo): Uint8Array {
@@ -104,30 +108,18 @@ function _encodePlayerInfo(message: PlayerInfo, bb: ByteBuffer): void {
     writeVarint64(bb, intToLong($score));
   }
 
-  // repeated int32 hand_tiles = 5;
-  let array$hand_tiles = message.hand_tiles;
-  if (array$hand_tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$hand_tiles) {
-      writeVarint64(packed, intToLong(value));
-    }
-    writeVarint32(bb, 42);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
+  // optional int32 hand_count = 5;
+  let $hand_count = message.hand_count;
+  if ($hand_count !== undefined) {
+    writeVarint32(bb, 40);
+    writeVarint64(bb, intToLong($hand_count));
   }
 
-  // repeated int32 discarded_tiles = 6;
-  let array$discarded_tiles = message.discarded_tiles;
-  if (array$discarded_tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$discarded_tiles) {
-      writeVarint64(packed, intToLong(value));
-    }
+  // optional string connection_status = 6;
+  let $connection_status = message.connection_status;
+  if ($connection_status !== undefined) {
     writeVarint32(bb, 50);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
+    writeString(bb, $connection_status);
   }
 
   // repeated MeldData melds = 7;
@@ -142,6 +134,50 @@ function _encodePlayerInfo(message: PlayerInfo, bb: ByteBuffer): void {
       pushByteBuffer(nested);
     }
   }
+
+  // repeated int32 flowers = 8;
+  let array$flowers = message.flowers;
+  if (array$flowers !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$flowers) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 66);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // optional int32 total_tai = 9;
+  let $total_tai = message.total_tai;
+  if ($total_tai !== undefined) {
+    writeVarint32(bb, 72);
+    writeVarint64(bb, intToLong($total_tai));
+  }
+
+  // optional map<string, int32> patterns = 10;
+  let map$patterns = message.patterns;
+  if (map$patterns !== undefined) {
+    for (let key in map$patterns) {
+      let nested = popByteBuffer();
+      let value = map$patterns[key];
+      writeVarint32(nested, 10);
+      writeString(nested, key);
+      writeVarint32(nested, 16);
+      writeVarint64(nested, intToLong(value));
+      writeVarint32(bb, 82);
+      writeVarint32(bb, nested.offset);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional bool is_dead_hand = 11;
+  let $is_dead_hand = message.is_dead_hand;
+  if ($is_dead_hand !== undefined) {
+    writeVarint32(bb, 88);
+    writeByte(bb, $is_dead_hand ? 1 : 0);
+  }
 }
 
 export function decodePlayerInfo(binary: Uint8Array): PlayerInfo {
@@ -182,9 +218,30 @@ function _decodePlayerInfo(bb: ByteBuffer): PlayerInfo {
         break;
       }
 
-      // repeated int32 hand_tiles = 5;
+      // optional int32 hand_count = 5;
       case 5: {
-        let values = message.hand_tiles || (message.hand_tiles = []);
+        message.hand_count = readVarint32(bb);
+        break;
+      }
+
+      // optional string connection_status = 6;
+      case 6: {
+        message.connection_status = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // repeated MeldData melds = 7;
+      case 7: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated int32 flowers = 8;
+      case 8: {
+        let values = message.flowers || (message.flowers = []);
         if ((tag & 7) === 2) {
           let outerLimit = pushTemporaryLength(bb);
           while (!isAtEnd(bb)) {
@@ -197,27 +254,43 @@ function _decodePlayerInfo(bb: ByteBuffer): PlayerInfo {
         break;
       }
 
-      // repeated int32 discarded_tiles = 6;
-      case 6: {
-        let values = message.discarded_tiles || (message.discarded_tiles = []);
-        if ((tag & 7) === 2) {
-          let outerLimit = pushTemporaryLength(bb);
-          while (!isAtEnd(bb)) {
-            values.push(readVarint32(bb));
+      // optional int32 total_tai = 9;
+      case 9: {
+        message.total_tai = readVarint32(bb);
+        break;
+      }
+
+      // optional map<string, int32> patterns = 10;
+      case 10: {
+        let values = message.patterns || (message.patterns = {});
+        let outerLimit = pushTemporaryLength(bb);
+        let key: string = "";
+        let value: number = 0;
+        end_of_entry: while (!isAtEnd(bb)) {
+          let tag = readVarint32(bb);
+          switch (tag >>> 3) {
+            case 0:
+              break end_of_entry;
+            case 1: {
+              key = readString(bb, readVarint32(bb));
+              break;
+            }
+            case 2: {
+              value = readVarint32(bb);
+              break;
+            }
+            default:
+              skipUnknownField(bb, tag & 7);
           }
-          bb.limit = outerLimit;
-        } else {
-          values.push(readVarint32(bb));
         }
+        values[key] = value;
+        bb.limit = outerLimit;
         break;
       }
 
-      // repeated MeldData melds = 7;
-      case 7: {
-        let limit = pushTemporaryLength(bb);
-        let values = message.melds || (message.melds = []);
-        values.push(_decodeMeldData(bb));
-        bb.limit = limit;
+      // optional bool is_dead_hand = 11;
+      case 11: {
+        message.is_dead_hand = !!readByte(bb);
         break;
       }
 
@@ -305,6 +378,179 @@ function _decodeMeldData(bb: ByteBuffer): MeldData {
   return message;
 }
 
+export interface TileDefData {
+  id?: number;
+  type?: number;
+  value?: number;
+  copy?: number;
+  notation?: string;
+  name?: string;
+}
+
+export function encodeTileDefData(message: TileDefData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeTileDefData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTileDefData(message: TileDefData, bb: ByteBuffer): void {
+  // optional int32 id = 1;
+  let $id = message.id;
+  if ($id !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($id));
+  }
+
+  // optional int32 type = 2;
+  let $type = message.type;
+  if ($type !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($type));
+  }
+
+  // optional int32 value = 3;
+  let $value = message.value;
+  if ($value !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($value));
+  }
+
+  // optional int32 copy = 4;
+  let $copy = message.copy;
+  if ($copy !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($copy));
+  }
+
+  // optional string notation = 5;
+  let $notation = message.notation;
+  if ($notation !== undefined) {
+    writeVarint32(bb, 42);
+    writeString(bb, $notation);
+  }
+
+  // optional string name = 6;
+  let $name = message.name;
+  if ($name !== undefined) {
+    writeVarint32(bb, 50);
+    writeString(bb, $name);
+  }
+}
+
+export function decodeTileDefData(binary: Uint8Array): TileDefData {
+  return _decodeTileDefData(wrapByteBuffer(binary));
+}
+
+function _decodeTileDefData(bb: ByteBuffer): TileDefData {
+  let message: TileDefData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 id = 1;
+      case 1: {
+        message.id = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 type = 2;
+      case 2: {
+        message.type = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 value = 3;
+      case 3: {
+        message.value = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 copy = 4;
+      case 4: {
+        message.copy = readVarint32(bb);
+        break;
+      }
+
+      // optional string notation = 5;
+      case 5: {
+        message.notation = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string name = 6;
+      case 6: {
+        message.name = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface TileRegistryData {
+  tiles?: TileDefData[];
+}
+
+export function encodeTileRegistryData(message: TileRegistryData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeTileRegistryData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTileRegistryData(message: TileRegistryData, bb: ByteBuffer): void {
+  // repeated TileDefData tiles = 1;
+  let array$tiles = message.tiles;
+  if (array$tiles !== undefined) {
+    for (let value of array$tiles) {
+      writeVarint32(bb, 10);
+      let nested = popByteBuffer();
+      _encodeTileDefData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+export function decodeTileRegistryData(binary: Uint8Array): TileRegistryData {
+  return _decodeTileRegistryData(wrapByteBuffer(binary));
+}
+
+function _decodeTileRegistryData(bb: ByteBuffer): TileRegistryData {
+  let message: TileRegistryData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // repeated TileDefData tiles = 1;
+      case 1: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.tiles || (message.tiles = []);
+        values.push(_decodeTileDefData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
 export interface SyncStateData {
   room_id?: string;
   current_wind?: number;
@@ -312,6 +558,7 @@ export interface SyncStateData {
   current_turn_player_id?: string;
   game_state?: string;
   players?: PlayerInfo[];
+  winner_ids?: string[];
 }
 
 export function encodeSyncStateData(message: SyncStateData): Uint8Array {
@@ -368,6 +615,15 @@ function _encodeSyncStateData(message: SyncStateData, bb: ByteBuffer): void {
       pushByteBuffer(nested);
     }
   }
+
+  // repeated string winner_ids = 7;
+  let array$winner_ids = message.winner_ids;
+  if (array$winner_ids !== undefined) {
+    for (let value of array$winner_ids) {
+      writeVarint32(bb, 58);
+      writeString(bb, value);
+    }
+  }
 }
 
 export function decodeSyncStateData(binary: Uint8Array): SyncStateData {
@@ -423,6 +679,13 @@ function _decodeSyncStateData(bb: ByteBuffer): SyncStateData {
         break;
       }
 
+      // repeated string winner_ids = 7;
+      case 7: {
+        let values = message.winner_ids || (message.winner_ids = []);
+        values.push(readString(bb, readVarint32(bb)));
+        break;
+      }
+
       default:
         skipUnknownField(bb, tag & 7);
     }
@@ -431,37 +694,39 @@ function _decodeSyncStateData(bb: ByteBuffer): SyncStateData {
   return message;
 }
 
-export interface DealTilesData {
-  tiles?: number[];
+export interface PatternData {
+  name?: string;
+  tai?: number;
 }
 
-export function encodeDealTilesData(message: DealTilesData): Uint8Array {
+export function encodePatternData(message: PatternData): Uint8Array {
   let bb = popByteBuffer();
-  _encodeDealTilesData(message, bb);
+  _encodePatternData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeDealTilesData(message: DealTilesData, bb: ByteBuffer): void {
-  // repeated int32 tiles = 1;
-  let array$tiles = message.tiles;
-  if (array$tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$tiles) {
-      writeVarint64(packed, intToLong(value));
-    }
+function _encodePatternData(message: PatternData, bb: ByteBuffer): void {
+  // optional string name = 1;
+  let $name = message.name;
+  if ($name !== undefined) {
     writeVarint32(bb, 10);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
+    writeString(bb, $name);
+  }
+
+  // optional int32 tai = 2;
+  let $tai = message.tai;
+  if ($tai !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($tai));
   }
 }
 
-export function decodeDealTilesData(binary: Uint8Array): DealTilesData {
-  return _decodeDealTilesData(wrapByteBuffer(binary));
+export function decodePatternData(binary: Uint8Array): PatternData {
+  return _decodePatternData(wrapByteBuffer(binary));
 }
 
-function _decodeDealTilesData(bb: ByteBuffer): DealTilesData {
-  let message: DealTilesData = {} as any;
+function _decodePatternData(bb: ByteBuffer): PatternData {
+  let message: PatternData = {} as any;
 
   end_of_message: while (!isAtEnd(bb)) {
     let tag = readVarint32(bb);
@@ -470,18 +735,15 @@ function _decodeDealTilesData(bb: ByteBuffer): DealTilesData {
       case 0:
         break end_of_message;
 
-      // repeated int32 tiles = 1;
+      // optional string name = 1;
       case 1: {
-        let values = message.tiles || (message.tiles = []);
-        if ((tag & 7) === 2) {
-          let outerLimit = pushTemporaryLength(bb);
-          while (!isAtEnd(bb)) {
-            values.push(readVarint32(bb));
-          }
-          bb.limit = outerLimit;
-        } else {
-          values.push(readVarint32(bb));
-        }
+        message.name = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 tai = 2;
+      case 2: {
+        message.tai = readVarint32(bb);
         break;
       }
 
@@ -493,61 +755,53 @@ function _decodeDealTilesData(bb: ByteBuffer): DealTilesData {
   return message;
 }
 
-export interface ActionBroadcastData {
-  player_id?: string;
-  action_type?: number;
-  tile_id?: number;
-  related_tiles?: number[];
+export interface WinnerScoreData {
+  seat?: number;
+  total_tai?: number;
+  patterns?: PatternData[];
 }
 
-export function encodeActionBroadcastData(message: ActionBroadcastData): Uint8Array {
+export function encodeWinnerScoreData(message: WinnerScoreData): Uint8Array {
   let bb = popByteBuffer();
-  _encodeActionBroadcastData(message, bb);
+  _encodeWinnerScoreData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeActionBroadcastData(message: ActionBroadcastData, bb: ByteBuffer): void {
-  // optional string player_id = 1;
-  let $player_id = message.player_id;
-  if ($player_id !== undefined) {
-    writeVarint32(bb, 10);
-    writeString(bb, $player_id);
+function _encodeWinnerScoreData(message: WinnerScoreData, bb: ByteBuffer): void {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seat));
   }
 
-  // optional int32 action_type = 2;
-  let $action_type = message.action_type;
-  if ($action_type !== undefined) {
+  // optional int32 total_tai = 2;
+  let $total_tai = message.total_tai;
+  if ($total_tai !== undefined) {
     writeVarint32(bb, 16);
-    writeVarint64(bb, intToLong($action_type));
-  }
-
-  // optional int32 tile_id = 3;
-  let $tile_id = message.tile_id;
-  if ($tile_id !== undefined) {
-    writeVarint32(bb, 24);
-    writeVarint64(bb, intToLong($tile_id));
+    writeVarint64(bb, intToLong($total_tai));
   }
 
-  // repeated int32 related_tiles = 4;
-  let array$related_tiles = message.related_tiles;
-  if (array$related_tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$related_tiles) {
-      writeVarint64(packed, intToLong(value));
+  // repeated PatternData patterns = 3;
+  let array$patterns = message.patterns;
+  if (array$patterns !== undefined) {
+    for (let value of array$patterns) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodePatternData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
     }
-    writeVarint32(bb, 34);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
   }
 }
 
-export function decodeActionBroadcastData(binary: Uint8Array): ActionBroadcastData {
-  return _decodeActionBroadcastData(wrapByteBuffer(binary));
+export function decodeWinnerScoreData(binary: Uint8Array): WinnerScoreData {
+  return _decodeWinnerScoreData(wrapByteBuffer(binary));
 }
 
-function _decodeActionBroadcastData(bb: ByteBuffer): ActionBroadcastData {
-  let message: ActionBroadcastData = {} as any;
+function _decodeWinnerScoreData(bb: ByteBuffer): WinnerScoreData {
+  let message: WinnerScoreData = {} as any;
 
   end_of_message: while (!isAtEnd(bb)) {
     let tag = readVarint32(bb);
@@ -556,36 +810,24 @@ function _decodeActionBroadcastData(bb: ByteBuffer): ActionBroadcastData {
       case 0:
         break end_of_message;
 
-      // optional string player_id = 1;
+      // optional int32 seat = 1;
       case 1: {
-        message.player_id = readString(bb, readVarint32(bb));
+        message.seat = readVarint32(bb);
         break;
       }
 
-      // optional int32 action_type = 2;
+      // optional int32 total_tai = 2;
       case 2: {
-        message.action_type = readVarint32(bb);
+        message.total_tai = readVarint32(bb);
         break;
       }
 
-      // optional int32 tile_id = 3;
+      // repeated PatternData patterns = 3;
       case 3: {
-        message.tile_id = readVarint32(bb);
-        break;
-      }
-
-      // repeated int32 related_tiles = 4;
-      case 4: {
-        let values = message.related_tiles || (message.related_tiles = []);
-        if ((tag & 7) === 2) {
-          let outerLimit = pushTemporaryLength(bb);
-          while (!isAtEnd(bb)) {
-            values.push(readVarint32(bb));
-          }
-          bb.limit = outerLimit;
-        } else {
-          values.push(readVarint32(bb));
-        }
+        let limit = pushTemporaryLength(bb);
+        let values = message.patterns || (message.patterns = []);
+        values.push(_decodePatternData(bb));
+        bb.limit = limit;
         break;
       }
 
@@ -597,39 +839,55 @@ function _decodeActionBroadcastData(bb: ByteBuffer): ActionBroadcastData {
   return message;
 }
 
-export interface JoinRoomReq {
-  room_id?: string;
-  player_id?: string;
+export interface PointTransferData {
+  from?: number;
+  to?: number;
+  tai?: number;
+  points?: number;
 }
 
-export function encodeJoinRoomReq(message: JoinRoomReq): Uint8Array {
+export function encodePointTransferData(message: PointTransferData): Uint8Array {
   let bb = popByteBuffer();
-  _encodeJoinRoomReq(message, bb);
+  _encodePointTransferData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeJoinRoomReq(message: JoinRoomReq, bb: ByteBuffer): void {
-  // optional string room_id = 1;
-  let $room_id = message.room_id;
-  if ($room_id !== undefined) {
-    writeVarint32(bb, 10);
-    writeString(bb, $room_id);
+function _encodePointTransferData(message: PointTransferData, bb: ByteBuffer): void {
+  // optional int32 from = 1;
+  let $from = message.from;
+  if ($from !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($from));
   }
 
-  // optional string player_id = 2;
-  let $player_id = message.player_id;
-  if ($player_id !== undefined) {
-    writeVarint32(bb, 18);
-    writeString(bb, $player_id);
+  // optional int32 to = 2;
+  let $to = message.to;
+  if ($to !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($to));
+  }
+
+  // optional int32 tai = 3;
+  let $tai = message.tai;
+  if ($tai !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($tai));
+  }
+
+  // optional int32 points = 4;
+  let $points = message.points;
+  if ($points !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($points));
   }
 }
 
-export function decodeJoinRoomReq(binary: Uint8Array): JoinRoomReq {
-  return _decodeJoinRoomReq(wrapByteBuffer(binary));
+export function decodePointTransferData(binary: Uint8Array): PointTransferData {
+  return _decodePointTransferData(wrapByteBuffer(binary));
 }
 
-function _decodeJoinRoomReq(bb: ByteBuffer): JoinRoomReq {
-  let message: JoinRoomReq = {} as any;
+function _decodePointTransferData(bb: ByteBuffer): PointTransferData {
+  let message: PointTransferData = {} as any;
 
   end_of_message: while (!isAtEnd(bb)) {
     let tag = readVarint32(bb);
@@ -638,15 +896,27 @@ function _decodeJoinRoomReq(bb: ByteBuffer): JoinRoomReq {
       case 0:
         break end_of_message;
 
-      // optional string room_id = 1;
+      // optional int32 from = 1;
       case 1: {
-        message.room_id = readString(bb, readVarint32(bb));
+        message.from = readVarint32(bb);
         break;
       }
 
-      // optional string player_id = 2;
+      // optional int32 to = 2;
       case 2: {
-        message.player_id = readString(bb, readVarint32(bb));
+        message.to = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tai = 3;
+      case 3: {
+        message.tai = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 points = 4;
+      case 4: {
+        message.points = readVarint32(bb);
         break;
       }
 
@@ -658,100 +928,81 @@ function _decodeJoinRoomReq(bb: ByteBuffer): JoinRoomReq {
   return message;
 }
 
-export interface JoinRoomRes {
-  success?: boolean;
-  message?: string;
+export interface RevealedHandData {
+  seat?: number;
+  tiles?: number[];
+  melds?: MeldData[];
+  flowers?: number[];
+  notation?: string;
 }
 
-export function encodeJoinRoomRes(message: JoinRoomRes): Uint8Array {
+export function encodeRevealedHandData(message: RevealedHandData): Uint8Array {
   let bb = popByteBuffer();
-  _encodeJoinRoomRes(message, bb);
+  _encodeRevealedHandData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeJoinRoomRes(message: JoinRoomRes, bb: ByteBuffer): void {
-  // optional bool success = 1;
-  let $success = message.success;
-  if ($success !== undefined) {
+function _encodeRevealedHandData(message: RevealedHandData, bb: ByteBuffer): void {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
     writeVarint32(bb, 8);
-    writeByte(bb, $success ? 1 : 0);
+    writeVarint64(bb, intToLong($seat));
   }
 
-  // optional string message = 2;
-  let $message = message.message;
-  if ($message !== undefined) {
+  // repeated int32 tiles = 2;
+  let array$tiles = message.tiles;
+  if (array$tiles !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tiles) {
+      writeVarint64(packed, intToLong(value));
+    }
     writeVarint32(bb, 18);
-    writeString(bb, $message);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
   }
-}
-
-export function decodeJoinRoomRes(binary: Uint8Array): JoinRoomRes {
-  return _decodeJoinRoomRes(wrapByteBuffer(binary));
-}
-
-function _decodeJoinRoomRes(bb: ByteBuffer): JoinRoomRes {
-  let message: JoinRoomRes = {} as any;
-
-  end_of_message: while (!isAtEnd(bb)) {
-    let tag = readVarint32(bb);
-
-    switch (tag >>> 3) {
-      case 0:
-        break end_of_message;
-
-      // optional bool success = 1;
-      case 1: {
-        message.success = !!readByte(bb);
-        break;
-      }
-
-      // optional string message = 2;
-      case 2: {
-        message.message = readString(bb, readVarint32(bb));
-        break;
-      }
 
-      default:
-        skipUnknownField(bb, tag & 7);
+  // repeated MeldData melds = 3;
+  let array$melds = message.melds;
+  if (array$melds !== undefined) {
+    for (let value of array$melds) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeMeldData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
     }
   }
 
-  return message;
-}
-
-export interface PlayerActionData {
-  action_type?: number;
-  tile_id?: number;
-}
-
-export function encodePlayerActionData(message: PlayerActionData): Uint8Array {
-  let bb = popByteBuffer();
-  _encodePlayerActionData(message, bb);
-  return toUint8Array(bb);
-}
-
-function _encodePlayerActionData(message: PlayerActionData, bb: ByteBuffer): void {
-  // optional int32 action_type = 1;
-  let $action_type = message.action_type;
-  if ($action_type !== undefined) {
-    writeVarint32(bb, 8);
-    writeVarint64(bb, intToLong($action_type));
+  // repeated int32 flowers = 4;
+  let array$flowers = message.flowers;
+  if (array$flowers !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$flowers) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 34);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
   }
 
-  // optional int32 tile_id = 2;
-  let $tile_id = message.tile_id;
-  if ($tile_id !== undefined) {
-    writeVarint32(bb, 16);
-    writeVarint64(bb, intToLong($tile_id));
+  // optional string notation = 5;
+  let $notation = message.notation;
+  if ($notation !== undefined) {
+    writeVarint32(bb, 42);
+    writeString(bb, $notation);
   }
 }
 
-export function decodePlayerActionData(binary: Uint8Array): PlayerActionData {
-  return _decodePlayerActionData(wrapByteBuffer(binary));
+export function decodeRevealedHandData(binary: Uint8Array): RevealedHandData {
+  return _decodeRevealedHandData(wrapByteBuffer(binary));
 }
 
-function _decodePlayerActionData(bb: ByteBuffer): PlayerActionData {
-  let message: PlayerActionData = {} as any;
+function _decodeRevealedHandData(bb: ByteBuffer): RevealedHandData {
+  let message: RevealedHandData = {} as any;
 
   end_of_message: while (!isAtEnd(bb)) {
     let tag = readVarint32(bb);
@@ -760,15 +1011,54 @@ function _decodePlayerActionData(bb: ByteBuffer): PlayerActionData {
       case 0:
         break end_of_message;
 
-      // optional int32 action_type = 1;
+      // optional int32 seat = 1;
       case 1: {
-        message.action_type = readVarint32(bb);
+        message.seat = readVarint32(bb);
         break;
       }
 
-      // optional int32 tile_id = 2;
+      // repeated int32 tiles = 2;
       case 2: {
-        message.tile_id = readVarint32(bb);
+        let values = message.tiles || (message.tiles = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // repeated MeldData melds = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated int32 flowers = 4;
+      case 4: {
+        let values = message.flowers || (message.flowers = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // optional string notation = 5;
+      case 5: {
+        message.notation = readString(bb, readVarint32(bb));
         break;
       }
 
@@ -780,39 +1070,1737 @@ function _decodePlayerActionData(bb: ByteBuffer): PlayerActionData {
   return message;
 }
 
-export interface PlayerActionRes {
-  success?: boolean;
-  message?: string;
-}
-
-export function encodePlayerActionRes(message: PlayerActionRes): Uint8Array {
+export interface RoundResultData {
+  room_id?: string;
+  winner_seats?: number[];
+  discarder_seat?: number;
+  winning_tile?: number;
+  is_draw?: boolean;
+  hands?: RevealedHandData[];
+  scores?: WinnerScoreData[];
+  transfers?: PointTransferData[];
+  next_dealer_seat?: number;
+  total_scores?: { [key: number]: number };
+  draw_reason?: string;
+  dealer_retained?: boolean;
+}
+
+export function encodeRoundResultData(message: RoundResultData): Uint8Array {
   let bb = popByteBuffer();
-  _encodePlayerActionRes(message, bb);
+  _encodeRoundResultData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodePlayerActionRes(message: PlayerActionRes, bb: ByteBuffer): void {
-  // optional bool success = 1;
-  let $success = message.success;
-  if ($success !== undefined) {
-    writeVarint32(bb, 8);
-    writeByte(bb, $success ? 1 : 0);
+function _encodeRoundResultData(message: RoundResultData, bb: ByteBuffer): void {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // repeated int32 winner_seats = 2;
+  let array$winner_seats = message.winner_seats;
+  if (array$winner_seats !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$winner_seats) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 18);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // optional int32 discarder_seat = 3;
+  let $discarder_seat = message.discarder_seat;
+  if ($discarder_seat !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($discarder_seat));
+  }
+
+  // optional int32 winning_tile = 4;
+  let $winning_tile = message.winning_tile;
+  if ($winning_tile !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($winning_tile));
+  }
+
+  // optional bool is_draw = 5;
+  let $is_draw = message.is_draw;
+  if ($is_draw !== undefined) {
+    writeVarint32(bb, 40);
+    writeByte(bb, $is_draw ? 1 : 0);
+  }
+
+  // repeated RevealedHandData hands = 6;
+  let array$hands = message.hands;
+  if (array$hands !== undefined) {
+    for (let value of array$hands) {
+      writeVarint32(bb, 50);
+      let nested = popByteBuffer();
+      _encodeRevealedHandData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // repeated WinnerScoreData scores = 7;
+  let array$scores = message.scores;
+  if (array$scores !== undefined) {
+    for (let value of array$scores) {
+      writeVarint32(bb, 58);
+      let nested = popByteBuffer();
+      _encodeWinnerScoreData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // repeated PointTransferData transfers = 8;
+  let array$transfers = message.transfers;
+  if (array$transfers !== undefined) {
+    for (let value of array$transfers) {
+      writeVarint32(bb, 66);
+      let nested = popByteBuffer();
+      _encodePointTransferData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional int32 next_dealer_seat = 9;
+  let $next_dealer_seat = message.next_dealer_seat;
+  if ($next_dealer_seat !== undefined) {
+    writeVarint32(bb, 72);
+    writeVarint64(bb, intToLong($next_dealer_seat));
+  }
+
+  // optional map<int32, int32> total_scores = 10;
+  let map$total_scores = message.total_scores;
+  if (map$total_scores !== undefined) {
+    for (let key in map$total_scores) {
+      let nested = popByteBuffer();
+      let value = map$total_scores[key];
+      writeVarint32(nested, 8);
+      writeVarint64(nested, intToLong(+key));
+      writeVarint32(nested, 16);
+      writeVarint64(nested, intToLong(value));
+      writeVarint32(bb, 82);
+      writeVarint32(bb, nested.offset);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional string draw_reason = 11;
+  let $draw_reason = message.draw_reason;
+  if ($draw_reason !== undefined) {
+    writeVarint32(bb, 90);
+    writeString(bb, $draw_reason);
+  }
+
+  // optional bool dealer_retained = 12;
+  let $dealer_retained = message.dealer_retained;
+  if ($dealer_retained !== undefined) {
+    writeVarint32(bb, 96);
+    writeByte(bb, $dealer_retained ? 1 : 0);
+  }
+}
+
+export function decodeRoundResultData(binary: Uint8Array): RoundResultData {
+  return _decodeRoundResultData(wrapByteBuffer(binary));
+}
+
+function _decodeRoundResultData(bb: ByteBuffer): RoundResultData {
+  let message: RoundResultData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // repeated int32 winner_seats = 2;
+      case 2: {
+        let values = message.winner_seats || (message.winner_seats = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // optional int32 discarder_seat = 3;
+      case 3: {
+        message.discarder_seat = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 winning_tile = 4;
+      case 4: {
+        message.winning_tile = readVarint32(bb);
+        break;
+      }
+
+      // optional bool is_draw = 5;
+      case 5: {
+        message.is_draw = !!readByte(bb);
+        break;
+      }
+
+      // repeated RevealedHandData hands = 6;
+      case 6: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.hands || (message.hands = []);
+        values.push(_decodeRevealedHandData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated WinnerScoreData scores = 7;
+      case 7: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.scores || (message.scores = []);
+        values.push(_decodeWinnerScoreData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated PointTransferData transfers = 8;
+      case 8: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.transfers || (message.transfers = []);
+        values.push(_decodePointTransferData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // optional int32 next_dealer_seat = 9;
+      case 9: {
+        message.next_dealer_seat = readVarint32(bb);
+        break;
+      }
+
+      // optional map<int32, int32> total_scores = 10;
+      case 10: {
+        let values = message.total_scores || (message.total_scores = {});
+        let outerLimit = pushTemporaryLength(bb);
+        let key: number = 0;
+        let value: number = 0;
+        end_of_entry: while (!isAtEnd(bb)) {
+          let tag = readVarint32(bb);
+          switch (tag >>> 3) {
+            case 0:
+              break end_of_entry;
+            case 1: {
+              key = readVarint32(bb);
+              break;
+            }
+            case 2: {
+              value = readVarint32(bb);
+              break;
+            }
+            default:
+              skipUnknownField(bb, tag & 7);
+          }
+        }
+        values[key] = value;
+        bb.limit = outerLimit;
+        break;
+      }
+
+      // optional string draw_reason = 11;
+      case 11: {
+        message.draw_reason = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional bool dealer_retained = 12;
+      case 12: {
+        message.dealer_retained = !!readByte(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface FinalStandingData {
+  seat?: number;
+  name?: string;
+  placement?: number;
+  net_points?: number;
+  wins?: number;
+  deal_ins?: number;
+  self_drawns?: number;
+  biggest_tai?: number;
+  biggest_patterns?: { [key: string]: number };
+}
+
+export function encodeFinalStandingData(message: FinalStandingData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeFinalStandingData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeFinalStandingData(message: FinalStandingData, bb: ByteBuffer): void {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional string name = 2;
+  let $name = message.name;
+  if ($name !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $name);
+  }
+
+  // optional int32 placement = 3;
+  let $placement = message.placement;
+  if ($placement !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($placement));
+  }
+
+  // optional int32 net_points = 4;
+  let $net_points = message.net_points;
+  if ($net_points !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($net_points));
+  }
+
+  // optional int32 wins = 5;
+  let $wins = message.wins;
+  if ($wins !== undefined) {
+    writeVarint32(bb, 40);
+    writeVarint64(bb, intToLong($wins));
+  }
+
+  // optional int32 deal_ins = 6;
+  let $deal_ins = message.deal_ins;
+  if ($deal_ins !== undefined) {
+    writeVarint32(bb, 48);
+    writeVarint64(bb, intToLong($deal_ins));
+  }
+
+  // optional int32 self_drawns = 7;
+  let $self_drawns = message.self_drawns;
+  if ($self_drawns !== undefined) {
+    writeVarint32(bb, 56);
+    writeVarint64(bb, intToLong($self_drawns));
+  }
+
+  // optional int32 biggest_tai = 8;
+  let $biggest_tai = message.biggest_tai;
+  if ($biggest_tai !== undefined) {
+    writeVarint32(bb, 64);
+    writeVarint64(bb, intToLong($biggest_tai));
+  }
+
+  // optional map<string, int32> biggest_patterns = 9;
+  let map$biggest_patterns = message.biggest_patterns;
+  if (map$biggest_patterns !== undefined) {
+    for (let key in map$biggest_patterns) {
+      let nested = popByteBuffer();
+      let value = map$biggest_patterns[key];
+      writeVarint32(nested, 10);
+      writeString(nested, key);
+      writeVarint32(nested, 16);
+      writeVarint64(nested, intToLong(value));
+      writeVarint32(bb, 74);
+      writeVarint32(bb, nested.offset);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+export function decodeFinalStandingData(binary: Uint8Array): FinalStandingData {
+  return _decodeFinalStandingData(wrapByteBuffer(binary));
+}
+
+function _decodeFinalStandingData(bb: ByteBuffer): FinalStandingData {
+  let message: FinalStandingData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 seat = 1;
+      case 1: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional string name = 2;
+      case 2: {
+        message.name = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 placement = 3;
+      case 3: {
+        message.placement = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 net_points = 4;
+      case 4: {
+        message.net_points = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 wins = 5;
+      case 5: {
+        message.wins = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 deal_ins = 6;
+      case 6: {
+        message.deal_ins = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 self_drawns = 7;
+      case 7: {
+        message.self_drawns = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 biggest_tai = 8;
+      case 8: {
+        message.biggest_tai = readVarint32(bb);
+        break;
+      }
+
+      // optional map<string, int32> biggest_patterns = 9;
+      case 9: {
+        let values = message.biggest_patterns || (message.biggest_patterns = {});
+        let outerLimit = pushTemporaryLength(bb);
+        let key: string = "";
+        let value: number = 0;
+        end_of_entry: while (!isAtEnd(bb)) {
+          let tag = readVarint32(bb);
+          switch (tag >>> 3) {
+            case 0:
+              break end_of_entry;
+            case 1: {
+              key = readString(bb, readVarint32(bb));
+              break;
+            }
+            case 2: {
+              value = readVarint32(bb);
+              break;
+            }
+            default:
+              skipUnknownField(bb, tag & 7);
+          }
+        }
+        values[key] = value;
+        bb.limit = outerLimit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface GameOverData {
+  room_id?: string;
+  rounds_played?: number;
+  standings?: FinalStandingData[];
+  end_reason?: string;
+}
+
+export function encodeGameOverData(message: GameOverData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeGameOverData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeGameOverData(message: GameOverData, bb: ByteBuffer): void {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 rounds_played = 2;
+  let $rounds_played = message.rounds_played;
+  if ($rounds_played !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($rounds_played));
+  }
+
+  // repeated FinalStandingData standings = 3;
+  let array$standings = message.standings;
+  if (array$standings !== undefined) {
+    for (let value of array$standings) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeFinalStandingData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional string end_reason = 4;
+  let $end_reason = message.end_reason;
+  if ($end_reason !== undefined) {
+    writeVarint32(bb, 34);
+    writeString(bb, $end_reason);
+  }
+}
+
+export function decodeGameOverData(binary: Uint8Array): GameOverData {
+  return _decodeGameOverData(wrapByteBuffer(binary));
+}
+
+function _decodeGameOverData(bb: ByteBuffer): GameOverData {
+  let message: GameOverData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 rounds_played = 2;
+      case 2: {
+        message.rounds_played = readVarint32(bb);
+        break;
+      }
+
+      // repeated FinalStandingData standings = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.standings || (message.standings = []);
+        values.push(_decodeFinalStandingData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // optional string end_reason = 4;
+      case 4: {
+        message.end_reason = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface DealTilesData {
+  tiles?: number[];
+}
+
+export function encodeDealTilesData(message: DealTilesData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeDealTilesData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeDealTilesData(message: DealTilesData, bb: ByteBuffer): void {
+  // repeated int32 tiles = 1;
+  let array$tiles = message.tiles;
+  if (array$tiles !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tiles) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 10);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+export function decodeDealTilesData(binary: Uint8Array): DealTilesData {
+  return _decodeDealTilesData(wrapByteBuffer(binary));
+}
+
+function _decodeDealTilesData(bb: ByteBuffer): DealTilesData {
+  let message: DealTilesData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // repeated int32 tiles = 1;
+      case 1: {
+        let values = message.tiles || (message.tiles = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface ActionBroadcastData {
+  player_id?: string;
+  action_type?: number;
+  tile_id?: number;
+  related_tiles?: number[];
+}
+
+export function encodeActionBroadcastData(message: ActionBroadcastData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeActionBroadcastData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeActionBroadcastData(message: ActionBroadcastData, bb: ByteBuffer): void {
+  // optional string player_id = 1;
+  let $player_id = message.player_id;
+  if ($player_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $player_id);
+  }
+
+  // optional int32 action_type = 2;
+  let $action_type = message.action_type;
+  if ($action_type !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($action_type));
+  }
+
+  // optional int32 tile_id = 3;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+
+  // repeated int32 related_tiles = 4;
+  let array$related_tiles = message.related_tiles;
+  if (array$related_tiles !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$related_tiles) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 34);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+export function decodeActionBroadcastData(binary: Uint8Array): ActionBroadcastData {
+  return _decodeActionBroadcastData(wrapByteBuffer(binary));
+}
+
+function _decodeActionBroadcastData(bb: ByteBuffer): ActionBroadcastData {
+  let message: ActionBroadcastData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string player_id = 1;
+      case 1: {
+        message.player_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 action_type = 2;
+      case 2: {
+        message.action_type = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tile_id = 3;
+      case 3: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 related_tiles = 4;
+      case 4: {
+        let values = message.related_tiles || (message.related_tiles = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface JoinRoomReq {
+  room_id?: string;
+  player_id?: string;
+  token?: string;
+  seat?: number;
+}
+
+export function encodeJoinRoomReq(message: JoinRoomReq): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeJoinRoomReq(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeJoinRoomReq(message: JoinRoomReq, bb: ByteBuffer): void {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional string player_id = 2;
+  let $player_id = message.player_id;
+  if ($player_id !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $player_id);
+  }
+
+  // optional string token = 3;
+  let $token = message.token;
+  if ($token !== undefined) {
+    writeVarint32(bb, 26);
+    writeString(bb, $token);
+  }
+
+  // optional int32 seat = 4;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($seat));
+  }
+}
+
+export function decodeJoinRoomReq(binary: Uint8Array): JoinRoomReq {
+  return _decodeJoinRoomReq(wrapByteBuffer(binary));
+}
+
+function _decodeJoinRoomReq(bb: ByteBuffer): JoinRoomReq {
+  let message: JoinRoomReq = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string player_id = 2;
+      case 2: {
+        message.player_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string token = 3;
+      case 3: {
+        message.token = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 4;
+      case 4: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface JoinRoomRes {
+  success?: boolean;
+  message?: string;
+}
+
+export function encodeJoinRoomRes(message: JoinRoomRes): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeJoinRoomRes(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeJoinRoomRes(message: JoinRoomRes, bb: ByteBuffer): void {
+  // optional bool success = 1;
+  let $success = message.success;
+  if ($success !== undefined) {
+    writeVarint32(bb, 8);
+    writeByte(bb, $success ? 1 : 0);
+  }
+
+  // optional string message = 2;
+  let $message = message.message;
+  if ($message !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $message);
+  }
+}
+
+export function decodeJoinRoomRes(binary: Uint8Array): JoinRoomRes {
+  return _decodeJoinRoomRes(wrapByteBuffer(binary));
+}
+
+function _decodeJoinRoomRes(bb: ByteBuffer): JoinRoomRes {
+  let message: JoinRoomRes = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional bool success = 1;
+      case 1: {
+        message.success = !!readByte(bb);
+        break;
+      }
+
+      // optional string message = 2;
+      case 2: {
+        message.message = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface PlayerActionData {
+  action_type?: number;
+  tile_id?: number;
+}
+
+export function encodePlayerActionData(message: PlayerActionData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodePlayerActionData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodePlayerActionData(message: PlayerActionData, bb: ByteBuffer): void {
+  // optional int32 action_type = 1;
+  let $action_type = message.action_type;
+  if ($action_type !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($action_type));
+  }
+
+  // optional int32 tile_id = 2;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+}
+
+export function decodePlayerActionData(binary: Uint8Array): PlayerActionData {
+  return _decodePlayerActionData(wrapByteBuffer(binary));
+}
+
+function _decodePlayerActionData(bb: ByteBuffer): PlayerActionData {
+  let message: PlayerActionData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 action_type = 1;
+      case 1: {
+        message.action_type = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tile_id = 2;
+      case 2: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface PlayerActionRes {
+  success?: boolean;
+  message?: string;
+}
+
+export function encodePlayerActionRes(message: PlayerActionRes): Uint8Array {
+  let bb = popByteBuffer();
+  _encodePlayerActionRes(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodePlayerActionRes(message: PlayerActionRes, bb: ByteBuffer): void {
+  // optional bool success = 1;
+  let $success = message.success;
+  if ($success !== undefined) {
+    writeVarint32(bb, 8);
+    writeByte(bb, $success ? 1 : 0);
   }
 
   // optional string message = 2;
   let $message = message.message;
   if ($message !== undefined) {
     writeVarint32(bb, 18);
-    writeString(bb, $message);
+    writeString(bb, $message);
+  }
+}
+
+export function decodePlayerActionRes(binary: Uint8Array): PlayerActionRes {
+  return _decodePlayerActionRes(wrapByteBuffer(binary));
+}
+
+function _decodePlayerActionRes(bb: ByteBuffer): PlayerActionRes {
+  let message: PlayerActionRes = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional bool success = 1;
+      case 1: {
+        message.success = !!readByte(bb);
+        break;
+      }
+
+      // optional string message = 2;
+      case 2: {
+        message.message = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface TenpaiHintsReq {
+  room_id?: string;
+  seat?: number;
+  enabled?: boolean;
+}
+
+export function encodeTenpaiHintsReq(message: TenpaiHintsReq): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeTenpaiHintsReq(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTenpaiHintsReq(message: TenpaiHintsReq, bb: ByteBuffer): void {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 2;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional bool enabled = 3;
+  let $enabled = message.enabled;
+  if ($enabled !== undefined) {
+    writeVarint32(bb, 24);
+    writeByte(bb, $enabled ? 1 : 0);
+  }
+}
+
+export function decodeTenpaiHintsReq(binary: Uint8Array): TenpaiHintsReq {
+  return _decodeTenpaiHintsReq(wrapByteBuffer(binary));
+}
+
+function _decodeTenpaiHintsReq(bb: ByteBuffer): TenpaiHintsReq {
+  let message: TenpaiHintsReq = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 2;
+      case 2: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional bool enabled = 3;
+      case 3: {
+        message.enabled = !!readByte(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface WaitHintData {
+  tile_id?: number;
+  notation?: string;
+  remaining?: number;
+  tai?: number;
+}
+
+export function encodeWaitHintData(message: WaitHintData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeWaitHintData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeWaitHintData(message: WaitHintData, bb: ByteBuffer): void {
+  // optional int32 tile_id = 1;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+
+  // optional string notation = 2;
+  let $notation = message.notation;
+  if ($notation !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $notation);
+  }
+
+  // optional int32 remaining = 3;
+  let $remaining = message.remaining;
+  if ($remaining !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($remaining));
+  }
+
+  // optional int32 tai = 4;
+  let $tai = message.tai;
+  if ($tai !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($tai));
+  }
+}
+
+export function decodeWaitHintData(binary: Uint8Array): WaitHintData {
+  return _decodeWaitHintData(wrapByteBuffer(binary));
+}
+
+function _decodeWaitHintData(bb: ByteBuffer): WaitHintData {
+  let message: WaitHintData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 tile_id = 1;
+      case 1: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      // optional string notation = 2;
+      case 2: {
+        message.notation = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 remaining = 3;
+      case 3: {
+        message.remaining = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tai = 4;
+      case 4: {
+        message.tai = readVarint32(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface DiscardHintData {
+  discard_tile_id?: number;
+  waits?: WaitHintData[];
+}
+
+export function encodeDiscardHintData(message: DiscardHintData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeDiscardHintData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeDiscardHintData(message: DiscardHintData, bb: ByteBuffer): void {
+  // optional int32 discard_tile_id = 1;
+  let $discard_tile_id = message.discard_tile_id;
+  if ($discard_tile_id !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($discard_tile_id));
+  }
+
+  // repeated WaitHintData waits = 2;
+  let array$waits = message.waits;
+  if (array$waits !== undefined) {
+    for (let value of array$waits) {
+      writeVarint32(bb, 18);
+      let nested = popByteBuffer();
+      _encodeWaitHintData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+export function decodeDiscardHintData(binary: Uint8Array): DiscardHintData {
+  return _decodeDiscardHintData(wrapByteBuffer(binary));
+}
+
+function _decodeDiscardHintData(bb: ByteBuffer): DiscardHintData {
+  let message: DiscardHintData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 discard_tile_id = 1;
+      case 1: {
+        message.discard_tile_id = readVarint32(bb);
+        break;
+      }
+
+      // repeated WaitHintData waits = 2;
+      case 2: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.waits || (message.waits = []);
+        values.push(_decodeWaitHintData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface TenpaiHintsData {
+  room_id?: string;
+  seat?: number;
+  hints?: DiscardHintData[];
+}
+
+export function encodeTenpaiHintsData(message: TenpaiHintsData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeTenpaiHintsData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTenpaiHintsData(message: TenpaiHintsData, bb: ByteBuffer): void {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 2;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // repeated DiscardHintData hints = 3;
+  let array$hints = message.hints;
+  if (array$hints !== undefined) {
+    for (let value of array$hints) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeDiscardHintData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+export function decodeTenpaiHintsData(binary: Uint8Array): TenpaiHintsData {
+  return _decodeTenpaiHintsData(wrapByteBuffer(binary));
+}
+
+function _decodeTenpaiHintsData(bb: ByteBuffer): TenpaiHintsData {
+  let message: TenpaiHintsData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 2;
+      case 2: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // repeated DiscardHintData hints = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.hints || (message.hints = []);
+        values.push(_decodeDiscardHintData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface BotJoinReq {
+  room_id?: string;
+  seat?: number;
+  token?: string;
+}
+
+export function encodeBotJoinReq(message: BotJoinReq): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeBotJoinReq(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotJoinReq(message: BotJoinReq, bb: ByteBuffer): void {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 2;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional string token = 3;
+  let $token = message.token;
+  if ($token !== undefined) {
+    writeVarint32(bb, 26);
+    writeString(bb, $token);
+  }
+}
+
+export function decodeBotJoinReq(binary: Uint8Array): BotJoinReq {
+  return _decodeBotJoinReq(wrapByteBuffer(binary));
+}
+
+function _decodeBotJoinReq(bb: ByteBuffer): BotJoinReq {
+  let message: BotJoinReq = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 2;
+      case 2: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional string token = 3;
+      case 3: {
+        message.token = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface BotMeldData {
+  type?: number;
+  tile_ids?: number[];
+}
+
+export function encodeBotMeldData(message: BotMeldData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeBotMeldData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotMeldData(message: BotMeldData, bb: ByteBuffer): void {
+  // optional int32 type = 1;
+  let $type = message.type;
+  if ($type !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($type));
+  }
+
+  // repeated int32 tile_ids = 2;
+  let array$tile_ids = message.tile_ids;
+  if (array$tile_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tile_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 18);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+export function decodeBotMeldData(binary: Uint8Array): BotMeldData {
+  return _decodeBotMeldData(wrapByteBuffer(binary));
+}
+
+function _decodeBotMeldData(bb: ByteBuffer): BotMeldData {
+  let message: BotMeldData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 type = 1;
+      case 1: {
+        message.type = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 tile_ids = 2;
+      case 2: {
+        let values = message.tile_ids || (message.tile_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface BotOpponentData {
+  seat?: number;
+  discard_ids?: number[];
+  melds?: BotMeldData[];
+}
+
+export function encodeBotOpponentData(message: BotOpponentData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeBotOpponentData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotOpponentData(message: BotOpponentData, bb: ByteBuffer): void {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // repeated int32 discard_ids = 2;
+  let array$discard_ids = message.discard_ids;
+  if (array$discard_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$discard_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 18);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // repeated BotMeldData melds = 3;
+  let array$melds = message.melds;
+  if (array$melds !== undefined) {
+    for (let value of array$melds) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeBotMeldData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+export function decodeBotOpponentData(binary: Uint8Array): BotOpponentData {
+  return _decodeBotOpponentData(wrapByteBuffer(binary));
+}
+
+function _decodeBotOpponentData(bb: ByteBuffer): BotOpponentData {
+  let message: BotOpponentData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 seat = 1;
+      case 1: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 discard_ids = 2;
+      case 2: {
+        let values = message.discard_ids || (message.discard_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // repeated BotMeldData melds = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeBotMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface BotRequestData {
+  seq?: number;
+  kind?: string;
+  room_id?: string;
+  seat?: number;
+  deadline_ms?: number;
+  hand_ids?: number[];
+  melds?: BotMeldData[];
+  visible_ids?: number[];
+  seat_wind?: number;
+  prevailing_wind?: number;
+  opponents?: BotOpponentData[];
+  tile_id?: number;
+  can_chow?: boolean;
+  can_hu?: boolean;
+  robbing_kong?: boolean;
+}
+
+export function encodeBotRequestData(message: BotRequestData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeBotRequestData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotRequestData(message: BotRequestData, bb: ByteBuffer): void {
+  // optional int32 seq = 1;
+  let $seq = message.seq;
+  if ($seq !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seq));
+  }
+
+  // optional string kind = 2;
+  let $kind = message.kind;
+  if ($kind !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $kind);
+  }
+
+  // optional string room_id = 3;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 26);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 4;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional int32 deadline_ms = 5;
+  let $deadline_ms = message.deadline_ms;
+  if ($deadline_ms !== undefined) {
+    writeVarint32(bb, 40);
+    writeVarint64(bb, intToLong($deadline_ms));
+  }
+
+  // repeated int32 hand_ids = 6;
+  let array$hand_ids = message.hand_ids;
+  if (array$hand_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$hand_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 50);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // repeated BotMeldData melds = 7;
+  let array$melds = message.melds;
+  if (array$melds !== undefined) {
+    for (let value of array$melds) {
+      writeVarint32(bb, 58);
+      let nested = popByteBuffer();
+      _encodeBotMeldData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // repeated int32 visible_ids = 8;
+  let array$visible_ids = message.visible_ids;
+  if (array$visible_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$visible_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 66);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // optional int32 seat_wind = 9;
+  let $seat_wind = message.seat_wind;
+  if ($seat_wind !== undefined) {
+    writeVarint32(bb, 72);
+    writeVarint64(bb, intToLong($seat_wind));
+  }
+
+  // optional int32 prevailing_wind = 10;
+  let $prevailing_wind = message.prevailing_wind;
+  if ($prevailing_wind !== undefined) {
+    writeVarint32(bb, 80);
+    writeVarint64(bb, intToLong($prevailing_wind));
+  }
+
+  // repeated BotOpponentData opponents = 11;
+  let array$opponents = message.opponents;
+  if (array$opponents !== undefined) {
+    for (let value of array$opponents) {
+      writeVarint32(bb, 90);
+      let nested = popByteBuffer();
+      _encodeBotOpponentData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional int32 tile_id = 12;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 96);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+
+  // optional bool can_chow = 13;
+  let $can_chow = message.can_chow;
+  if ($can_chow !== undefined) {
+    writeVarint32(bb, 104);
+    writeByte(bb, $can_chow ? 1 : 0);
+  }
+
+  // optional bool can_hu = 14;
+  let $can_hu = message.can_hu;
+  if ($can_hu !== undefined) {
+    writeVarint32(bb, 112);
+    writeByte(bb, $can_hu ? 1 : 0);
+  }
+
+  // optional bool robbing_kong = 15;
+  let $robbing_kong = message.robbing_kong;
+  if ($robbing_kong !== undefined) {
+    writeVarint32(bb, 120);
+    writeByte(bb, $robbing_kong ? 1 : 0);
   }
 }
 
-export function decodePlayerActionRes(binary: Uint8Array): PlayerActionRes {
-  return _decodePlayerActionRes(wrapByteBuffer(binary));
+export function decodeBotRequestData(binary: Uint8Array): BotRequestData {
+  return _decodeBotRequestData(wrapByteBuffer(binary));
 }
 
-function _decodePlayerActionRes(bb: ByteBuffer): PlayerActionRes {
-  let message: PlayerActionRes = {} as any;
+function _decodeBotRequestData(bb: ByteBuffer): BotRequestData {
+  let message: BotRequestData = {} as any;
 
   end_of_message: while (!isAtEnd(bb)) {
     let tag = readVarint32(bb);
@@ -821,15 +2809,207 @@ function _decodePlayerActionRes(bb: ByteBuffer): PlayerActionRes {
       case 0:
         break end_of_message;
 
-      // optional bool success = 1;
+      // optional int32 seq = 1;
       case 1: {
-        message.success = !!readByte(bb);
+        message.seq = readVarint32(bb);
         break;
       }
 
-      // optional string message = 2;
+      // optional string kind = 2;
       case 2: {
-        message.message = readString(bb, readVarint32(bb));
+        message.kind = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string room_id = 3;
+      case 3: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 4;
+      case 4: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 deadline_ms = 5;
+      case 5: {
+        message.deadline_ms = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 hand_ids = 6;
+      case 6: {
+        let values = message.hand_ids || (message.hand_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // repeated BotMeldData melds = 7;
+      case 7: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeBotMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated int32 visible_ids = 8;
+      case 8: {
+        let values = message.visible_ids || (message.visible_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // optional int32 seat_wind = 9;
+      case 9: {
+        message.seat_wind = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 prevailing_wind = 10;
+      case 10: {
+        message.prevailing_wind = readVarint32(bb);
+        break;
+      }
+
+      // repeated BotOpponentData opponents = 11;
+      case 11: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.opponents || (message.opponents = []);
+        values.push(_decodeBotOpponentData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // optional int32 tile_id = 12;
+      case 12: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      // optional bool can_chow = 13;
+      case 13: {
+        message.can_chow = !!readByte(bb);
+        break;
+      }
+
+      // optional bool can_hu = 14;
+      case 14: {
+        message.can_hu = !!readByte(bb);
+        break;
+      }
+
+      // optional bool robbing_kong = 15;
+      case 15: {
+        message.robbing_kong = !!readByte(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+export interface BotResponseData {
+  seq?: number;
+  action?: string;
+  tile_ids?: number[];
+}
+
+export function encodeBotResponseData(message: BotResponseData): Uint8Array {
+  let bb = popByteBuffer();
+  _encodeBotResponseData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotResponseData(message: BotResponseData, bb: ByteBuffer): void {
+  // optional int32 seq = 1;
+  let $seq = message.seq;
+  if ($seq !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seq));
+  }
+
+  // optional string action = 2;
+  let $action = message.action;
+  if ($action !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $action);
+  }
+
+  // repeated int32 tile_ids = 3;
+  let array$tile_ids = message.tile_ids;
+  if (array$tile_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tile_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 26);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+export function decodeBotResponseData(binary: Uint8Array): BotResponseData {
+  return _decodeBotResponseData(wrapByteBuffer(binary));
+}
+
+function _decodeBotResponseData(bb: ByteBuffer): BotResponseData {
+  let message: BotResponseData = {} as any;
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 seq = 1;
+      case 1: {
+        message.seq = readVarint32(bb);
+        break;
+      }
+
+      // optional string action = 2;
+      case 2: {
+        message.action = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // repeated int32 tile_ids = 3;
+      case 3: {
+        let values = message.tile_ids || (message.tile_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
         break;
       }
 
@@ -1340,8 +3520,26 @@ declare namespace mahjong_pb {
   export function decodePlayerInfo(binary: Uint8Array): PlayerInfo;
   export function encodeMeldData(message: MeldData): Uint8Array;
   export function decodeMeldData(binary: Uint8Array): MeldData;
+  export function encodeTileDefData(message: TileDefData): Uint8Array;
+  export function decodeTileDefData(binary: Uint8Array): TileDefData;
+  export function encodeTileRegistryData(message: TileRegistryData): Uint8Array;
+  export function decodeTileRegistryData(binary: Uint8Array): TileRegistryData;
   export function encodeSyncStateData(message: SyncStateData): Uint8Array;
   export function decodeSyncStateData(binary: Uint8Array): SyncStateData;
+  export function encodePatternData(message: PatternData): Uint8Array;
+  export function decodePatternData(binary: Uint8Array): PatternData;
+  export function encodeWinnerScoreData(message: WinnerScoreData): Uint8Array;
+  export function decodeWinnerScoreData(binary: Uint8Array): WinnerScoreData;
+  export function encodePointTransferData(message: PointTransferData): Uint8Array;
+  export function decodePointTransferData(binary: Uint8Array): PointTransferData;
+  export function encodeRevealedHandData(message: RevealedHandData): Uint8Array;
+  export function decodeRevealedHandData(binary: Uint8Array): RevealedHandData;
+  export function encodeRoundResultData(message: RoundResultData): Uint8Array;
+  export function decodeRoundResultData(binary: Uint8Array): RoundResultData;
+  export function encodeFinalStandingData(message: FinalStandingData): Uint8Array;
+  export function decodeFinalStandingData(binary: Uint8Array): FinalStandingData;
+  export function encodeGameOverData(message: GameOverData): Uint8Array;
+  export function decodeGameOverData(binary: Uint8Array): GameOverData;
   export function encodeDealTilesData(message: DealTilesData): Uint8Array;
   export function decodeDealTilesData(binary: Uint8Array): DealTilesData;
   export function encodeActionBroadcastData(message: ActionBroadcastData): Uint8Array;
@@ -1354,6 +3552,24 @@ declare namespace mahjong_pb {
   export function decodePlayerActionData(binary: Uint8Array): PlayerActionData;
   export function encodePlayerActionRes(message: PlayerActionRes): Uint8Array;
   export function decodePlayerActionRes(binary: Uint8Array): PlayerActionRes;
+  export function encodeTenpaiHintsReq(message: TenpaiHintsReq): Uint8Array;
+  export function decodeTenpaiHintsReq(binary: Uint8Array): TenpaiHintsReq;
+  export function encodeWaitHintData(message: WaitHintData): Uint8Array;
+  export function decodeWaitHintData(binary: Uint8Array): WaitHintData;
+  export function encodeDiscardHintData(message: DiscardHintData): Uint8Array;
+  export function decodeDiscardHintData(binary: Uint8Array): DiscardHintData;
+  export function encodeTenpaiHintsData(message: TenpaiHintsData): Uint8Array;
+  export function decodeTenpaiHintsData(binary: Uint8Array): TenpaiHintsData;
+  export function encodeBotJoinReq(message: BotJoinReq): Uint8Array;
+  export function decodeBotJoinReq(binary: Uint8Array): BotJoinReq;
+  export function encodeBotMeldData(message: BotMeldData): Uint8Array;
+  export function decodeBotMeldData(binary: Uint8Array): BotMeldData;
+  export function encodeBotOpponentData(message: BotOpponentData): Uint8Array;
+  export function decodeBotOpponentData(binary: Uint8Array): BotOpponentData;
+  export function encodeBotRequestData(message: BotRequestData): Uint8Array;
+  export function decodeBotRequestData(binary: Uint8Array): BotRequestData;
+  export function encodeBotResponseData(message: BotResponseData): Uint8Array;
+  export function decodeBotResponseData(binary: Uint8Array): BotResponseData;
 }
 
 declare global {
@@ -89,30 +89,18 @@ function _encodePlayerInfo(message, bb) {
     writeVarint64(bb, intToLong($score));
   }
 
-  // repeated int32 hand_tiles = 5;
-  let array$hand_tiles = message.hand_tiles;
-  if (array$hand_tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$hand_tiles) {
-      writeVarint64(packed, intToLong(value));
-    }
-    writeVarint32(bb, 42);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
+  // optional int32 hand_count = 5;
+  let $hand_count = message.hand_count;
+  if ($hand_count !== undefined) {
+    writeVarint32(bb, 40);
+    writeVarint64(bb, intToLong($hand_count));
   }
 
-  // repeated int32 discarded_tiles = 6;
-  let array$discarded_tiles = message.discarded_tiles;
-  if (array$discarded_tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$discarded_tiles) {
-      writeVarint64(packed, intToLong(value));
-    }
+  // optional string connection_status = 6;
+  let $connection_status = message.connection_status;
+  if ($connection_status !== undefined) {
     writeVarint32(bb, 50);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
+    writeString(bb, $connection_status);
   }
 
   // repeated MeldData melds = 7;
@@ -127,6 +115,50 @@ function _encodePlayerInfo(message, bb) {
       pushByteBuffer(nested);
     }
   }
+
+  // repeated int32 flowers = 8;
+  let array$flowers = message.flowers;
+  if (array$flowers !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$flowers) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 66);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // optional int32 total_tai = 9;
+  let $total_tai = message.total_tai;
+  if ($total_tai !== undefined) {
+    writeVarint32(bb, 72);
+    writeVarint64(bb, intToLong($total_tai));
+  }
+
+  // optional map<string, int32> patterns = 10;
+  let map$patterns = message.patterns;
+  if (map$patterns !== undefined) {
+    for (let key in map$patterns) {
+      let nested = popByteBuffer();
+      let value = map$patterns[key];
+      writeVarint32(nested, 10);
+      writeString(nested, key);
+      writeVarint32(nested, 16);
+      writeVarint64(nested, intToLong(value));
+      writeVarint32(bb, 82);
+      writeVarint32(bb, nested.offset);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional bool is_dead_hand = 11;
+  let $is_dead_hand = message.is_dead_hand;
+  if ($is_dead_hand !== undefined) {
+    writeVarint32(bb, 88);
+    writeByte(bb, $is_dead_hand ? 1 : 0);
+  }
 }
 
 function decodePlayerInfo(binary) {
@@ -167,9 +199,30 @@ function _decodePlayerInfo(bb) {
         break;
       }
 
-      // repeated int32 hand_tiles = 5;
+      // optional int32 hand_count = 5;
       case 5: {
-        let values = message.hand_tiles || (message.hand_tiles = []);
+        message.hand_count = readVarint32(bb);
+        break;
+      }
+
+      // optional string connection_status = 6;
+      case 6: {
+        message.connection_status = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // repeated MeldData melds = 7;
+      case 7: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated int32 flowers = 8;
+      case 8: {
+        let values = message.flowers || (message.flowers = []);
         if ((tag & 7) === 2) {
           let outerLimit = pushTemporaryLength(bb);
           while (!isAtEnd(bb)) {
@@ -182,27 +235,43 @@ function _decodePlayerInfo(bb) {
         break;
       }
 
-      // repeated int32 discarded_tiles = 6;
-      case 6: {
-        let values = message.discarded_tiles || (message.discarded_tiles = []);
-        if ((tag & 7) === 2) {
-          let outerLimit = pushTemporaryLength(bb);
-          while (!isAtEnd(bb)) {
-            values.push(readVarint32(bb));
+      // optional int32 total_tai = 9;
+      case 9: {
+        message.total_tai = readVarint32(bb);
+        break;
+      }
+
+      // optional map<string, int32> patterns = 10;
+      case 10: {
+        let values = message.patterns || (message.patterns = {});
+        let outerLimit = pushTemporaryLength(bb);
+        let key = "";
+        let value = 0;
+        end_of_entry: while (!isAtEnd(bb)) {
+          let tag = readVarint32(bb);
+          switch (tag >>> 3) {
+            case 0:
+              break end_of_entry;
+            case 1: {
+              key = readString(bb, readVarint32(bb));
+              break;
+            }
+            case 2: {
+              value = readVarint32(bb);
+              break;
+            }
+            default:
+              skipUnknownField(bb, tag & 7);
           }
-          bb.limit = outerLimit;
-        } else {
-          values.push(readVarint32(bb));
         }
+        values[key] = value;
+        bb.limit = outerLimit;
         break;
       }
 
-      // repeated MeldData melds = 7;
-      case 7: {
-        let limit = pushTemporaryLength(bb);
-        let values = message.melds || (message.melds = []);
-        values.push(_decodeMeldData(bb));
-        bb.limit = limit;
+      // optional bool is_dead_hand = 11;
+      case 11: {
+        message.is_dead_hand = !!readByte(bb);
         break;
       }
 
@@ -285,6 +354,166 @@ function _decodeMeldData(bb) {
   return message;
 }
 
+function encodeTileDefData(message) {
+  let bb = popByteBuffer();
+  _encodeTileDefData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTileDefData(message, bb) {
+  // optional int32 id = 1;
+  let $id = message.id;
+  if ($id !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($id));
+  }
+
+  // optional int32 type = 2;
+  let $type = message.type;
+  if ($type !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($type));
+  }
+
+  // optional int32 value = 3;
+  let $value = message.value;
+  if ($value !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($value));
+  }
+
+  // optional int32 copy = 4;
+  let $copy = message.copy;
+  if ($copy !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($copy));
+  }
+
+  // optional string notation = 5;
+  let $notation = message.notation;
+  if ($notation !== undefined) {
+    writeVarint32(bb, 42);
+    writeString(bb, $notation);
+  }
+
+  // optional string name = 6;
+  let $name = message.name;
+  if ($name !== undefined) {
+    writeVarint32(bb, 50);
+    writeString(bb, $name);
+  }
+}
+
+function decodeTileDefData(binary) {
+  return _decodeTileDefData(wrapByteBuffer(binary));
+}
+
+function _decodeTileDefData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 id = 1;
+      case 1: {
+        message.id = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 type = 2;
+      case 2: {
+        message.type = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 value = 3;
+      case 3: {
+        message.value = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 copy = 4;
+      case 4: {
+        message.copy = readVarint32(bb);
+        break;
+      }
+
+      // optional string notation = 5;
+      case 5: {
+        message.notation = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string name = 6;
+      case 6: {
+        message.name = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeTileRegistryData(message) {
+  let bb = popByteBuffer();
+  _encodeTileRegistryData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTileRegistryData(message, bb) {
+  // repeated TileDefData tiles = 1;
+  let array$tiles = message.tiles;
+  if (array$tiles !== undefined) {
+    for (let value of array$tiles) {
+      writeVarint32(bb, 10);
+      let nested = popByteBuffer();
+      _encodeTileDefData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+function decodeTileRegistryData(binary) {
+  return _decodeTileRegistryData(wrapByteBuffer(binary));
+}
+
+function _decodeTileRegistryData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // repeated TileDefData tiles = 1;
+      case 1: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.tiles || (message.tiles = []);
+        values.push(_decodeTileDefData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
 function encodeSyncStateData(message) {
   let bb = popByteBuffer();
   _encodeSyncStateData(message, bb);
@@ -339,6 +568,15 @@ function _encodeSyncStateData(message, bb) {
       pushByteBuffer(nested);
     }
   }
+
+  // repeated string winner_ids = 7;
+  let array$winner_ids = message.winner_ids;
+  if (array$winner_ids !== undefined) {
+    for (let value of array$winner_ids) {
+      writeVarint32(bb, 58);
+      writeString(bb, value);
+    }
+  }
 }
 
 function decodeSyncStateData(binary) {
@@ -394,6 +632,13 @@ function _decodeSyncStateData(bb) {
         break;
       }
 
+      // repeated string winner_ids = 7;
+      case 7: {
+        let values = message.winner_ids || (message.winner_ids = []);
+        values.push(readString(bb, readVarint32(bb)));
+        break;
+      }
+
       default:
         skipUnknownField(bb, tag & 7);
     }
@@ -402,32 +647,33 @@ function _decodeSyncStateData(bb) {
   return message;
 }
 
-function encodeDealTilesData(message) {
+function encodePatternData(message) {
   let bb = popByteBuffer();
-  _encodeDealTilesData(message, bb);
+  _encodePatternData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeDealTilesData(message, bb) {
-  // repeated int32 tiles = 1;
-  let array$tiles = message.tiles;
-  if (array$tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$tiles) {
-      writeVarint64(packed, intToLong(value));
-    }
+function _encodePatternData(message, bb) {
+  // optional string name = 1;
+  let $name = message.name;
+  if ($name !== undefined) {
     writeVarint32(bb, 10);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
+    writeString(bb, $name);
+  }
+
+  // optional int32 tai = 2;
+  let $tai = message.tai;
+  if ($tai !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($tai));
   }
 }
 
-function decodeDealTilesData(binary) {
-  return _decodeDealTilesData(wrapByteBuffer(binary));
+function decodePatternData(binary) {
+  return _decodePatternData(wrapByteBuffer(binary));
 }
 
-function _decodeDealTilesData(bb) {
+function _decodePatternData(bb) {
   let message = {};
 
   end_of_message: while (!isAtEnd(bb)) {
@@ -437,18 +683,15 @@ function _decodeDealTilesData(bb) {
       case 0:
         break end_of_message;
 
-      // repeated int32 tiles = 1;
+      // optional string name = 1;
       case 1: {
-        let values = message.tiles || (message.tiles = []);
-        if ((tag & 7) === 2) {
-          let outerLimit = pushTemporaryLength(bb);
-          while (!isAtEnd(bb)) {
-            values.push(readVarint32(bb));
-          }
-          bb.limit = outerLimit;
-        } else {
-          values.push(readVarint32(bb));
-        }
+        message.name = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 tai = 2;
+      case 2: {
+        message.tai = readVarint32(bb);
         break;
       }
 
@@ -460,53 +703,46 @@ function _decodeDealTilesData(bb) {
   return message;
 }
 
-function encodeActionBroadcastData(message) {
+function encodeWinnerScoreData(message) {
   let bb = popByteBuffer();
-  _encodeActionBroadcastData(message, bb);
+  _encodeWinnerScoreData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeActionBroadcastData(message, bb) {
-  // optional string player_id = 1;
-  let $player_id = message.player_id;
-  if ($player_id !== undefined) {
-    writeVarint32(bb, 10);
-    writeString(bb, $player_id);
+function _encodeWinnerScoreData(message, bb) {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seat));
   }
 
-  // optional int32 action_type = 2;
-  let $action_type = message.action_type;
-  if ($action_type !== undefined) {
+  // optional int32 total_tai = 2;
+  let $total_tai = message.total_tai;
+  if ($total_tai !== undefined) {
     writeVarint32(bb, 16);
-    writeVarint64(bb, intToLong($action_type));
+    writeVarint64(bb, intToLong($total_tai));
   }
 
-  // optional int32 tile_id = 3;
-  let $tile_id = message.tile_id;
-  if ($tile_id !== undefined) {
-    writeVarint32(bb, 24);
-    writeVarint64(bb, intToLong($tile_id));
+  // repeated PatternData patterns = 3;
+  let array$patterns = message.patterns;
+  if (array$patterns !== undefined) {
+    for (let value of array$patterns) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodePatternData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
   }
+}
 
-  // repeated int32 related_tiles = 4;
-  let array$related_tiles = message.related_tiles;
-  if (array$related_tiles !== undefined) {
-    let packed = popByteBuffer();
-    for (let value of array$related_tiles) {
-      writeVarint64(packed, intToLong(value));
-    }
-    writeVarint32(bb, 34);
-    writeVarint32(bb, packed.offset);
-    writeByteBuffer(bb, packed);
-    pushByteBuffer(packed);
-  }
-}
-
-function decodeActionBroadcastData(binary) {
-  return _decodeActionBroadcastData(wrapByteBuffer(binary));
+function decodeWinnerScoreData(binary) {
+  return _decodeWinnerScoreData(wrapByteBuffer(binary));
 }
 
-function _decodeActionBroadcastData(bb) {
+function _decodeWinnerScoreData(bb) {
   let message = {};
 
   end_of_message: while (!isAtEnd(bb)) {
@@ -516,36 +752,24 @@ function _decodeActionBroadcastData(bb) {
       case 0:
         break end_of_message;
 
-      // optional string player_id = 1;
+      // optional int32 seat = 1;
       case 1: {
-        message.player_id = readString(bb, readVarint32(bb));
+        message.seat = readVarint32(bb);
         break;
       }
 
-      // optional int32 action_type = 2;
+      // optional int32 total_tai = 2;
       case 2: {
-        message.action_type = readVarint32(bb);
+        message.total_tai = readVarint32(bb);
         break;
       }
 
-      // optional int32 tile_id = 3;
+      // repeated PatternData patterns = 3;
       case 3: {
-        message.tile_id = readVarint32(bb);
-        break;
-      }
-
-      // repeated int32 related_tiles = 4;
-      case 4: {
-        let values = message.related_tiles || (message.related_tiles = []);
-        if ((tag & 7) === 2) {
-          let outerLimit = pushTemporaryLength(bb);
-          while (!isAtEnd(bb)) {
-            values.push(readVarint32(bb));
-          }
-          bb.limit = outerLimit;
-        } else {
-          values.push(readVarint32(bb));
-        }
+        let limit = pushTemporaryLength(bb);
+        let values = message.patterns || (message.patterns = []);
+        values.push(_decodePatternData(bb));
+        bb.limit = limit;
         break;
       }
 
@@ -557,33 +781,47 @@ function _decodeActionBroadcastData(bb) {
   return message;
 }
 
-function encodeJoinRoomReq(message) {
+function encodePointTransferData(message) {
   let bb = popByteBuffer();
-  _encodeJoinRoomReq(message, bb);
+  _encodePointTransferData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeJoinRoomReq(message, bb) {
-  // optional string room_id = 1;
-  let $room_id = message.room_id;
-  if ($room_id !== undefined) {
-    writeVarint32(bb, 10);
-    writeString(bb, $room_id);
+function _encodePointTransferData(message, bb) {
+  // optional int32 from = 1;
+  let $from = message.from;
+  if ($from !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($from));
   }
 
-  // optional string player_id = 2;
-  let $player_id = message.player_id;
-  if ($player_id !== undefined) {
-    writeVarint32(bb, 18);
-    writeString(bb, $player_id);
+  // optional int32 to = 2;
+  let $to = message.to;
+  if ($to !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($to));
+  }
+
+  // optional int32 tai = 3;
+  let $tai = message.tai;
+  if ($tai !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($tai));
+  }
+
+  // optional int32 points = 4;
+  let $points = message.points;
+  if ($points !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($points));
   }
 }
 
-function decodeJoinRoomReq(binary) {
-  return _decodeJoinRoomReq(wrapByteBuffer(binary));
+function decodePointTransferData(binary) {
+  return _decodePointTransferData(wrapByteBuffer(binary));
 }
 
-function _decodeJoinRoomReq(bb) {
+function _decodePointTransferData(bb) {
   let message = {};
 
   end_of_message: while (!isAtEnd(bb)) {
@@ -593,15 +831,27 @@ function _decodeJoinRoomReq(bb) {
       case 0:
         break end_of_message;
 
-      // optional string room_id = 1;
+      // optional int32 from = 1;
       case 1: {
-        message.room_id = readString(bb, readVarint32(bb));
+        message.from = readVarint32(bb);
         break;
       }
 
-      // optional string player_id = 2;
+      // optional int32 to = 2;
       case 2: {
-        message.player_id = readString(bb, readVarint32(bb));
+        message.to = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tai = 3;
+      case 3: {
+        message.tai = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 points = 4;
+      case 4: {
+        message.points = readVarint32(bb);
         break;
       }
 
@@ -613,33 +863,72 @@ function _decodeJoinRoomReq(bb) {
   return message;
 }
 
-function encodeJoinRoomRes(message) {
+function encodeRevealedHandData(message) {
   let bb = popByteBuffer();
-  _encodeJoinRoomRes(message, bb);
+  _encodeRevealedHandData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodeJoinRoomRes(message, bb) {
-  // optional bool success = 1;
-  let $success = message.success;
-  if ($success !== undefined) {
+function _encodeRevealedHandData(message, bb) {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
     writeVarint32(bb, 8);
-    writeByte(bb, $success ? 1 : 0);
+    writeVarint64(bb, intToLong($seat));
   }
 
-  // optional string message = 2;
-  let $message = message.message;
-  if ($message !== undefined) {
+  // repeated int32 tiles = 2;
+  let array$tiles = message.tiles;
+  if (array$tiles !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tiles) {
+      writeVarint64(packed, intToLong(value));
+    }
     writeVarint32(bb, 18);
-    writeString(bb, $message);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // repeated MeldData melds = 3;
+  let array$melds = message.melds;
+  if (array$melds !== undefined) {
+    for (let value of array$melds) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeMeldData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // repeated int32 flowers = 4;
+  let array$flowers = message.flowers;
+  if (array$flowers !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$flowers) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 34);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // optional string notation = 5;
+  let $notation = message.notation;
+  if ($notation !== undefined) {
+    writeVarint32(bb, 42);
+    writeString(bb, $notation);
   }
 }
 
-function decodeJoinRoomRes(binary) {
-  return _decodeJoinRoomRes(wrapByteBuffer(binary));
+function decodeRevealedHandData(binary) {
+  return _decodeRevealedHandData(wrapByteBuffer(binary));
 }
 
-function _decodeJoinRoomRes(bb) {
+function _decodeRevealedHandData(bb) {
   let message = {};
 
   end_of_message: while (!isAtEnd(bb)) {
@@ -649,15 +938,54 @@ function _decodeJoinRoomRes(bb) {
       case 0:
         break end_of_message;
 
-      // optional bool success = 1;
+      // optional int32 seat = 1;
       case 1: {
-        message.success = !!readByte(bb);
+        message.seat = readVarint32(bb);
         break;
       }
 
-      // optional string message = 2;
+      // repeated int32 tiles = 2;
       case 2: {
-        message.message = readString(bb, readVarint32(bb));
+        let values = message.tiles || (message.tiles = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // repeated MeldData melds = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated int32 flowers = 4;
+      case 4: {
+        let values = message.flowers || (message.flowers = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // optional string notation = 5;
+      case 5: {
+        message.notation = readString(bb, readVarint32(bb));
         break;
       }
 
@@ -669,33 +997,137 @@ function _decodeJoinRoomRes(bb) {
   return message;
 }
 
-function encodePlayerActionData(message) {
+function encodeRoundResultData(message) {
   let bb = popByteBuffer();
-  _encodePlayerActionData(message, bb);
+  _encodeRoundResultData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodePlayerActionData(message, bb) {
-  // optional int32 action_type = 1;
-  let $action_type = message.action_type;
-  if ($action_type !== undefined) {
-    writeVarint32(bb, 8);
-    writeVarint64(bb, intToLong($action_type));
+function _encodeRoundResultData(message, bb) {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
   }
 
-  // optional int32 tile_id = 2;
-  let $tile_id = message.tile_id;
-  if ($tile_id !== undefined) {
-    writeVarint32(bb, 16);
-    writeVarint64(bb, intToLong($tile_id));
+  // repeated int32 winner_seats = 2;
+  let array$winner_seats = message.winner_seats;
+  if (array$winner_seats !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$winner_seats) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 18);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // optional int32 discarder_seat = 3;
+  let $discarder_seat = message.discarder_seat;
+  if ($discarder_seat !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($discarder_seat));
+  }
+
+  // optional int32 winning_tile = 4;
+  let $winning_tile = message.winning_tile;
+  if ($winning_tile !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($winning_tile));
+  }
+
+  // optional bool is_draw = 5;
+  let $is_draw = message.is_draw;
+  if ($is_draw !== undefined) {
+    writeVarint32(bb, 40);
+    writeByte(bb, $is_draw ? 1 : 0);
+  }
+
+  // repeated RevealedHandData hands = 6;
+  let array$hands = message.hands;
+  if (array$hands !== undefined) {
+    for (let value of array$hands) {
+      writeVarint32(bb, 50);
+      let nested = popByteBuffer();
+      _encodeRevealedHandData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // repeated WinnerScoreData scores = 7;
+  let array$scores = message.scores;
+  if (array$scores !== undefined) {
+    for (let value of array$scores) {
+      writeVarint32(bb, 58);
+      let nested = popByteBuffer();
+      _encodeWinnerScoreData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // repeated PointTransferData transfers = 8;
+  let array$transfers = message.transfers;
+  if (array$transfers !== undefined) {
+    for (let value of array$transfers) {
+      writeVarint32(bb, 66);
+      let nested = popByteBuffer();
+      _encodePointTransferData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional int32 next_dealer_seat = 9;
+  let $next_dealer_seat = message.next_dealer_seat;
+  if ($next_dealer_seat !== undefined) {
+    writeVarint32(bb, 72);
+    writeVarint64(bb, intToLong($next_dealer_seat));
+  }
+
+  // optional map<int32, int32> total_scores = 10;
+  let map$total_scores = message.total_scores;
+  if (map$total_scores !== undefined) {
+    for (let key in map$total_scores) {
+      let nested = popByteBuffer();
+      let value = map$total_scores[key];
+      writeVarint32(nested, 8);
+      writeVarint64(nested, intToLong(+key));
+      writeVarint32(nested, 16);
+      writeVarint64(nested, intToLong(value));
+      writeVarint32(bb, 82);
+      writeVarint32(bb, nested.offset);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional string draw_reason = 11;
+  let $draw_reason = message.draw_reason;
+  if ($draw_reason !== undefined) {
+    writeVarint32(bb, 90);
+    writeString(bb, $draw_reason);
+  }
+
+  // optional bool dealer_retained = 12;
+  let $dealer_retained = message.dealer_retained;
+  if ($dealer_retained !== undefined) {
+    writeVarint32(bb, 96);
+    writeByte(bb, $dealer_retained ? 1 : 0);
   }
 }
 
-function decodePlayerActionData(binary) {
-  return _decodePlayerActionData(wrapByteBuffer(binary));
+function decodeRoundResultData(binary) {
+  return _decodeRoundResultData(wrapByteBuffer(binary));
 }
 
-function _decodePlayerActionData(bb) {
+function _decodeRoundResultData(bb) {
   let message = {};
 
   end_of_message: while (!isAtEnd(bb)) {
@@ -705,58 +1137,1641 @@ function _decodePlayerActionData(bb) {
       case 0:
         break end_of_message;
 
-      // optional int32 action_type = 1;
+      // optional string room_id = 1;
       case 1: {
-        message.action_type = readVarint32(bb);
+        message.room_id = readString(bb, readVarint32(bb));
         break;
       }
 
-      // optional int32 tile_id = 2;
+      // repeated int32 winner_seats = 2;
       case 2: {
-        message.tile_id = readVarint32(bb);
+        let values = message.winner_seats || (message.winner_seats = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
         break;
       }
 
-      default:
-        skipUnknownField(bb, tag & 7);
+      // optional int32 discarder_seat = 3;
+      case 3: {
+        message.discarder_seat = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 winning_tile = 4;
+      case 4: {
+        message.winning_tile = readVarint32(bb);
+        break;
+      }
+
+      // optional bool is_draw = 5;
+      case 5: {
+        message.is_draw = !!readByte(bb);
+        break;
+      }
+
+      // repeated RevealedHandData hands = 6;
+      case 6: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.hands || (message.hands = []);
+        values.push(_decodeRevealedHandData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated WinnerScoreData scores = 7;
+      case 7: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.scores || (message.scores = []);
+        values.push(_decodeWinnerScoreData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated PointTransferData transfers = 8;
+      case 8: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.transfers || (message.transfers = []);
+        values.push(_decodePointTransferData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // optional int32 next_dealer_seat = 9;
+      case 9: {
+        message.next_dealer_seat = readVarint32(bb);
+        break;
+      }
+
+      // optional map<int32, int32> total_scores = 10;
+      case 10: {
+        let values = message.total_scores || (message.total_scores = {});
+        let outerLimit = pushTemporaryLength(bb);
+        let key = 0;
+        let value = 0;
+        end_of_entry: while (!isAtEnd(bb)) {
+          let tag = readVarint32(bb);
+          switch (tag >>> 3) {
+            case 0:
+              break end_of_entry;
+            case 1: {
+              key = readVarint32(bb);
+              break;
+            }
+            case 2: {
+              value = readVarint32(bb);
+              break;
+            }
+            default:
+              skipUnknownField(bb, tag & 7);
+          }
+        }
+        values[key] = value;
+        bb.limit = outerLimit;
+        break;
+      }
+
+      // optional string draw_reason = 11;
+      case 11: {
+        message.draw_reason = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional bool dealer_retained = 12;
+      case 12: {
+        message.dealer_retained = !!readByte(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeFinalStandingData(message) {
+  let bb = popByteBuffer();
+  _encodeFinalStandingData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeFinalStandingData(message, bb) {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional string name = 2;
+  let $name = message.name;
+  if ($name !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $name);
+  }
+
+  // optional int32 placement = 3;
+  let $placement = message.placement;
+  if ($placement !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($placement));
+  }
+
+  // optional int32 net_points = 4;
+  let $net_points = message.net_points;
+  if ($net_points !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($net_points));
+  }
+
+  // optional int32 wins = 5;
+  let $wins = message.wins;
+  if ($wins !== undefined) {
+    writeVarint32(bb, 40);
+    writeVarint64(bb, intToLong($wins));
+  }
+
+  // optional int32 deal_ins = 6;
+  let $deal_ins = message.deal_ins;
+  if ($deal_ins !== undefined) {
+    writeVarint32(bb, 48);
+    writeVarint64(bb, intToLong($deal_ins));
+  }
+
+  // optional int32 self_drawns = 7;
+  let $self_drawns = message.self_drawns;
+  if ($self_drawns !== undefined) {
+    writeVarint32(bb, 56);
+    writeVarint64(bb, intToLong($self_drawns));
+  }
+
+  // optional int32 biggest_tai = 8;
+  let $biggest_tai = message.biggest_tai;
+  if ($biggest_tai !== undefined) {
+    writeVarint32(bb, 64);
+    writeVarint64(bb, intToLong($biggest_tai));
+  }
+
+  // optional map<string, int32> biggest_patterns = 9;
+  let map$biggest_patterns = message.biggest_patterns;
+  if (map$biggest_patterns !== undefined) {
+    for (let key in map$biggest_patterns) {
+      let nested = popByteBuffer();
+      let value = map$biggest_patterns[key];
+      writeVarint32(nested, 10);
+      writeString(nested, key);
+      writeVarint32(nested, 16);
+      writeVarint64(nested, intToLong(value));
+      writeVarint32(bb, 74);
+      writeVarint32(bb, nested.offset);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+function decodeFinalStandingData(binary) {
+  return _decodeFinalStandingData(wrapByteBuffer(binary));
+}
+
+function _decodeFinalStandingData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 seat = 1;
+      case 1: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional string name = 2;
+      case 2: {
+        message.name = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 placement = 3;
+      case 3: {
+        message.placement = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 net_points = 4;
+      case 4: {
+        message.net_points = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 wins = 5;
+      case 5: {
+        message.wins = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 deal_ins = 6;
+      case 6: {
+        message.deal_ins = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 self_drawns = 7;
+      case 7: {
+        message.self_drawns = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 biggest_tai = 8;
+      case 8: {
+        message.biggest_tai = readVarint32(bb);
+        break;
+      }
+
+      // optional map<string, int32> biggest_patterns = 9;
+      case 9: {
+        let values = message.biggest_patterns || (message.biggest_patterns = {});
+        let outerLimit = pushTemporaryLength(bb);
+        let key = "";
+        let value = 0;
+        end_of_entry: while (!isAtEnd(bb)) {
+          let tag = readVarint32(bb);
+          switch (tag >>> 3) {
+            case 0:
+              break end_of_entry;
+            case 1: {
+              key = readString(bb, readVarint32(bb));
+              break;
+            }
+            case 2: {
+              value = readVarint32(bb);
+              break;
+            }
+            default:
+              skipUnknownField(bb, tag & 7);
+          }
+        }
+        values[key] = value;
+        bb.limit = outerLimit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeGameOverData(message) {
+  let bb = popByteBuffer();
+  _encodeGameOverData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeGameOverData(message, bb) {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 rounds_played = 2;
+  let $rounds_played = message.rounds_played;
+  if ($rounds_played !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($rounds_played));
+  }
+
+  // repeated FinalStandingData standings = 3;
+  let array$standings = message.standings;
+  if (array$standings !== undefined) {
+    for (let value of array$standings) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeFinalStandingData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional string end_reason = 4;
+  let $end_reason = message.end_reason;
+  if ($end_reason !== undefined) {
+    writeVarint32(bb, 34);
+    writeString(bb, $end_reason);
+  }
+}
+
+function decodeGameOverData(binary) {
+  return _decodeGameOverData(wrapByteBuffer(binary));
+}
+
+function _decodeGameOverData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 rounds_played = 2;
+      case 2: {
+        message.rounds_played = readVarint32(bb);
+        break;
+      }
+
+      // repeated FinalStandingData standings = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.standings || (message.standings = []);
+        values.push(_decodeFinalStandingData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // optional string end_reason = 4;
+      case 4: {
+        message.end_reason = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeDealTilesData(message) {
+  let bb = popByteBuffer();
+  _encodeDealTilesData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeDealTilesData(message, bb) {
+  // repeated int32 tiles = 1;
+  let array$tiles = message.tiles;
+  if (array$tiles !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tiles) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 10);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+function decodeDealTilesData(binary) {
+  return _decodeDealTilesData(wrapByteBuffer(binary));
+}
+
+function _decodeDealTilesData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // repeated int32 tiles = 1;
+      case 1: {
+        let values = message.tiles || (message.tiles = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeActionBroadcastData(message) {
+  let bb = popByteBuffer();
+  _encodeActionBroadcastData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeActionBroadcastData(message, bb) {
+  // optional string player_id = 1;
+  let $player_id = message.player_id;
+  if ($player_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $player_id);
+  }
+
+  // optional int32 action_type = 2;
+  let $action_type = message.action_type;
+  if ($action_type !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($action_type));
+  }
+
+  // optional int32 tile_id = 3;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+
+  // repeated int32 related_tiles = 4;
+  let array$related_tiles = message.related_tiles;
+  if (array$related_tiles !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$related_tiles) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 34);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+function decodeActionBroadcastData(binary) {
+  return _decodeActionBroadcastData(wrapByteBuffer(binary));
+}
+
+function _decodeActionBroadcastData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string player_id = 1;
+      case 1: {
+        message.player_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 action_type = 2;
+      case 2: {
+        message.action_type = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tile_id = 3;
+      case 3: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 related_tiles = 4;
+      case 4: {
+        let values = message.related_tiles || (message.related_tiles = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeJoinRoomReq(message) {
+  let bb = popByteBuffer();
+  _encodeJoinRoomReq(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeJoinRoomReq(message, bb) {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional string player_id = 2;
+  let $player_id = message.player_id;
+  if ($player_id !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $player_id);
+  }
+
+  // optional string token = 3;
+  let $token = message.token;
+  if ($token !== undefined) {
+    writeVarint32(bb, 26);
+    writeString(bb, $token);
+  }
+
+  // optional int32 seat = 4;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($seat));
+  }
+}
+
+function decodeJoinRoomReq(binary) {
+  return _decodeJoinRoomReq(wrapByteBuffer(binary));
+}
+
+function _decodeJoinRoomReq(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string player_id = 2;
+      case 2: {
+        message.player_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string token = 3;
+      case 3: {
+        message.token = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 4;
+      case 4: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeJoinRoomRes(message) {
+  let bb = popByteBuffer();
+  _encodeJoinRoomRes(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeJoinRoomRes(message, bb) {
+  // optional bool success = 1;
+  let $success = message.success;
+  if ($success !== undefined) {
+    writeVarint32(bb, 8);
+    writeByte(bb, $success ? 1 : 0);
+  }
+
+  // optional string message = 2;
+  let $message = message.message;
+  if ($message !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $message);
+  }
+}
+
+function decodeJoinRoomRes(binary) {
+  return _decodeJoinRoomRes(wrapByteBuffer(binary));
+}
+
+function _decodeJoinRoomRes(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional bool success = 1;
+      case 1: {
+        message.success = !!readByte(bb);
+        break;
+      }
+
+      // optional string message = 2;
+      case 2: {
+        message.message = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodePlayerActionData(message) {
+  let bb = popByteBuffer();
+  _encodePlayerActionData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodePlayerActionData(message, bb) {
+  // optional int32 action_type = 1;
+  let $action_type = message.action_type;
+  if ($action_type !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($action_type));
+  }
+
+  // optional int32 tile_id = 2;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+}
+
+function decodePlayerActionData(binary) {
+  return _decodePlayerActionData(wrapByteBuffer(binary));
+}
+
+function _decodePlayerActionData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 action_type = 1;
+      case 1: {
+        message.action_type = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tile_id = 2;
+      case 2: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodePlayerActionRes(message) {
+  let bb = popByteBuffer();
+  _encodePlayerActionRes(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodePlayerActionRes(message, bb) {
+  // optional bool success = 1;
+  let $success = message.success;
+  if ($success !== undefined) {
+    writeVarint32(bb, 8);
+    writeByte(bb, $success ? 1 : 0);
+  }
+
+  // optional string message = 2;
+  let $message = message.message;
+  if ($message !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $message);
+  }
+}
+
+function decodePlayerActionRes(binary) {
+  return _decodePlayerActionRes(wrapByteBuffer(binary));
+}
+
+function _decodePlayerActionRes(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional bool success = 1;
+      case 1: {
+        message.success = !!readByte(bb);
+        break;
+      }
+
+      // optional string message = 2;
+      case 2: {
+        message.message = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeTenpaiHintsReq(message) {
+  let bb = popByteBuffer();
+  _encodeTenpaiHintsReq(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTenpaiHintsReq(message, bb) {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 2;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional bool enabled = 3;
+  let $enabled = message.enabled;
+  if ($enabled !== undefined) {
+    writeVarint32(bb, 24);
+    writeByte(bb, $enabled ? 1 : 0);
+  }
+}
+
+function decodeTenpaiHintsReq(binary) {
+  return _decodeTenpaiHintsReq(wrapByteBuffer(binary));
+}
+
+function _decodeTenpaiHintsReq(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 2;
+      case 2: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional bool enabled = 3;
+      case 3: {
+        message.enabled = !!readByte(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeWaitHintData(message) {
+  let bb = popByteBuffer();
+  _encodeWaitHintData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeWaitHintData(message, bb) {
+  // optional int32 tile_id = 1;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+
+  // optional string notation = 2;
+  let $notation = message.notation;
+  if ($notation !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $notation);
+  }
+
+  // optional int32 remaining = 3;
+  let $remaining = message.remaining;
+  if ($remaining !== undefined) {
+    writeVarint32(bb, 24);
+    writeVarint64(bb, intToLong($remaining));
+  }
+
+  // optional int32 tai = 4;
+  let $tai = message.tai;
+  if ($tai !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($tai));
+  }
+}
+
+function decodeWaitHintData(binary) {
+  return _decodeWaitHintData(wrapByteBuffer(binary));
+}
+
+function _decodeWaitHintData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 tile_id = 1;
+      case 1: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      // optional string notation = 2;
+      case 2: {
+        message.notation = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 remaining = 3;
+      case 3: {
+        message.remaining = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 tai = 4;
+      case 4: {
+        message.tai = readVarint32(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeDiscardHintData(message) {
+  let bb = popByteBuffer();
+  _encodeDiscardHintData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeDiscardHintData(message, bb) {
+  // optional int32 discard_tile_id = 1;
+  let $discard_tile_id = message.discard_tile_id;
+  if ($discard_tile_id !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($discard_tile_id));
+  }
+
+  // repeated WaitHintData waits = 2;
+  let array$waits = message.waits;
+  if (array$waits !== undefined) {
+    for (let value of array$waits) {
+      writeVarint32(bb, 18);
+      let nested = popByteBuffer();
+      _encodeWaitHintData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+function decodeDiscardHintData(binary) {
+  return _decodeDiscardHintData(wrapByteBuffer(binary));
+}
+
+function _decodeDiscardHintData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 discard_tile_id = 1;
+      case 1: {
+        message.discard_tile_id = readVarint32(bb);
+        break;
+      }
+
+      // repeated WaitHintData waits = 2;
+      case 2: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.waits || (message.waits = []);
+        values.push(_decodeWaitHintData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeTenpaiHintsData(message) {
+  let bb = popByteBuffer();
+  _encodeTenpaiHintsData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeTenpaiHintsData(message, bb) {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 2;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // repeated DiscardHintData hints = 3;
+  let array$hints = message.hints;
+  if (array$hints !== undefined) {
+    for (let value of array$hints) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeDiscardHintData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+function decodeTenpaiHintsData(binary) {
+  return _decodeTenpaiHintsData(wrapByteBuffer(binary));
+}
+
+function _decodeTenpaiHintsData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 2;
+      case 2: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // repeated DiscardHintData hints = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.hints || (message.hints = []);
+        values.push(_decodeDiscardHintData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeBotJoinReq(message) {
+  let bb = popByteBuffer();
+  _encodeBotJoinReq(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotJoinReq(message, bb) {
+  // optional string room_id = 1;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 10);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 2;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 16);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional string token = 3;
+  let $token = message.token;
+  if ($token !== undefined) {
+    writeVarint32(bb, 26);
+    writeString(bb, $token);
+  }
+}
+
+function decodeBotJoinReq(binary) {
+  return _decodeBotJoinReq(wrapByteBuffer(binary));
+}
+
+function _decodeBotJoinReq(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional string room_id = 1;
+      case 1: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 2;
+      case 2: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional string token = 3;
+      case 3: {
+        message.token = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeBotMeldData(message) {
+  let bb = popByteBuffer();
+  _encodeBotMeldData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotMeldData(message, bb) {
+  // optional int32 type = 1;
+  let $type = message.type;
+  if ($type !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($type));
+  }
+
+  // repeated int32 tile_ids = 2;
+  let array$tile_ids = message.tile_ids;
+  if (array$tile_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tile_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 18);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+function decodeBotMeldData(binary) {
+  return _decodeBotMeldData(wrapByteBuffer(binary));
+}
+
+function _decodeBotMeldData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 type = 1;
+      case 1: {
+        message.type = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 tile_ids = 2;
+      case 2: {
+        let values = message.tile_ids || (message.tile_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
+}
+
+function encodeBotOpponentData(message) {
+  let bb = popByteBuffer();
+  _encodeBotOpponentData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotOpponentData(message, bb) {
+  // optional int32 seat = 1;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // repeated int32 discard_ids = 2;
+  let array$discard_ids = message.discard_ids;
+  if (array$discard_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$discard_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 18);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // repeated BotMeldData melds = 3;
+  let array$melds = message.melds;
+  if (array$melds !== undefined) {
+    for (let value of array$melds) {
+      writeVarint32(bb, 26);
+      let nested = popByteBuffer();
+      _encodeBotMeldData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+}
+
+function decodeBotOpponentData(binary) {
+  return _decodeBotOpponentData(wrapByteBuffer(binary));
+}
+
+function _decodeBotOpponentData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 seat = 1;
+      case 1: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 discard_ids = 2;
+      case 2: {
+        let values = message.discard_ids || (message.discard_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // repeated BotMeldData melds = 3;
+      case 3: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeBotMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
     }
   }
 
   return message;
 }
 
-function encodePlayerActionRes(message) {
+function encodeBotRequestData(message) {
   let bb = popByteBuffer();
-  _encodePlayerActionRes(message, bb);
+  _encodeBotRequestData(message, bb);
   return toUint8Array(bb);
 }
 
-function _encodePlayerActionRes(message, bb) {
-  // optional bool success = 1;
-  let $success = message.success;
-  if ($success !== undefined) {
+function _encodeBotRequestData(message, bb) {
+  // optional int32 seq = 1;
+  let $seq = message.seq;
+  if ($seq !== undefined) {
     writeVarint32(bb, 8);
-    writeByte(bb, $success ? 1 : 0);
+    writeVarint64(bb, intToLong($seq));
   }
 
-  // optional string message = 2;
-  let $message = message.message;
-  if ($message !== undefined) {
+  // optional string kind = 2;
+  let $kind = message.kind;
+  if ($kind !== undefined) {
     writeVarint32(bb, 18);
-    writeString(bb, $message);
+    writeString(bb, $kind);
+  }
+
+  // optional string room_id = 3;
+  let $room_id = message.room_id;
+  if ($room_id !== undefined) {
+    writeVarint32(bb, 26);
+    writeString(bb, $room_id);
+  }
+
+  // optional int32 seat = 4;
+  let $seat = message.seat;
+  if ($seat !== undefined) {
+    writeVarint32(bb, 32);
+    writeVarint64(bb, intToLong($seat));
+  }
+
+  // optional int32 deadline_ms = 5;
+  let $deadline_ms = message.deadline_ms;
+  if ($deadline_ms !== undefined) {
+    writeVarint32(bb, 40);
+    writeVarint64(bb, intToLong($deadline_ms));
+  }
+
+  // repeated int32 hand_ids = 6;
+  let array$hand_ids = message.hand_ids;
+  if (array$hand_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$hand_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 50);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // repeated BotMeldData melds = 7;
+  let array$melds = message.melds;
+  if (array$melds !== undefined) {
+    for (let value of array$melds) {
+      writeVarint32(bb, 58);
+      let nested = popByteBuffer();
+      _encodeBotMeldData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // repeated int32 visible_ids = 8;
+  let array$visible_ids = message.visible_ids;
+  if (array$visible_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$visible_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 66);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+
+  // optional int32 seat_wind = 9;
+  let $seat_wind = message.seat_wind;
+  if ($seat_wind !== undefined) {
+    writeVarint32(bb, 72);
+    writeVarint64(bb, intToLong($seat_wind));
+  }
+
+  // optional int32 prevailing_wind = 10;
+  let $prevailing_wind = message.prevailing_wind;
+  if ($prevailing_wind !== undefined) {
+    writeVarint32(bb, 80);
+    writeVarint64(bb, intToLong($prevailing_wind));
+  }
+
+  // repeated BotOpponentData opponents = 11;
+  let array$opponents = message.opponents;
+  if (array$opponents !== undefined) {
+    for (let value of array$opponents) {
+      writeVarint32(bb, 90);
+      let nested = popByteBuffer();
+      _encodeBotOpponentData(value, nested);
+      writeVarint32(bb, nested.limit);
+      writeByteBuffer(bb, nested);
+      pushByteBuffer(nested);
+    }
+  }
+
+  // optional int32 tile_id = 12;
+  let $tile_id = message.tile_id;
+  if ($tile_id !== undefined) {
+    writeVarint32(bb, 96);
+    writeVarint64(bb, intToLong($tile_id));
+  }
+
+  // optional bool can_chow = 13;
+  let $can_chow = message.can_chow;
+  if ($can_chow !== undefined) {
+    writeVarint32(bb, 104);
+    writeByte(bb, $can_chow ? 1 : 0);
+  }
+
+  // optional bool can_hu = 14;
+  let $can_hu = message.can_hu;
+  if ($can_hu !== undefined) {
+    writeVarint32(bb, 112);
+    writeByte(bb, $can_hu ? 1 : 0);
+  }
+
+  // optional bool robbing_kong = 15;
+  let $robbing_kong = message.robbing_kong;
+  if ($robbing_kong !== undefined) {
+    writeVarint32(bb, 120);
+    writeByte(bb, $robbing_kong ? 1 : 0);
   }
 }
 
-window.mahjong_pb = window.mahjong_pb || {};
-window.mahjong_pb.encodeWSMessage = encodeWSMessage;
-window.mahjong_pb.decodeWSMessage = decodeWSMessage;
-window.mahjong_pb.encodePlayerInfo = encodePlayerInfo;
+function decodeBotRequestData(binary) {
+  return _decodeBotRequestData(wrapByteBuffer(binary));
+}
 
-function decodePlayerActionRes(binary) {
-  return _decodePlayerActionRes(wrapByteBuffer(binary));
+function _decodeBotRequestData(bb) {
+  let message = {};
+
+  end_of_message: while (!isAtEnd(bb)) {
+    let tag = readVarint32(bb);
+
+    switch (tag >>> 3) {
+      case 0:
+        break end_of_message;
+
+      // optional int32 seq = 1;
+      case 1: {
+        message.seq = readVarint32(bb);
+        break;
+      }
+
+      // optional string kind = 2;
+      case 2: {
+        message.kind = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional string room_id = 3;
+      case 3: {
+        message.room_id = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // optional int32 seat = 4;
+      case 4: {
+        message.seat = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 deadline_ms = 5;
+      case 5: {
+        message.deadline_ms = readVarint32(bb);
+        break;
+      }
+
+      // repeated int32 hand_ids = 6;
+      case 6: {
+        let values = message.hand_ids || (message.hand_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // repeated BotMeldData melds = 7;
+      case 7: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.melds || (message.melds = []);
+        values.push(_decodeBotMeldData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // repeated int32 visible_ids = 8;
+      case 8: {
+        let values = message.visible_ids || (message.visible_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
+        break;
+      }
+
+      // optional int32 seat_wind = 9;
+      case 9: {
+        message.seat_wind = readVarint32(bb);
+        break;
+      }
+
+      // optional int32 prevailing_wind = 10;
+      case 10: {
+        message.prevailing_wind = readVarint32(bb);
+        break;
+      }
+
+      // repeated BotOpponentData opponents = 11;
+      case 11: {
+        let limit = pushTemporaryLength(bb);
+        let values = message.opponents || (message.opponents = []);
+        values.push(_decodeBotOpponentData(bb));
+        bb.limit = limit;
+        break;
+      }
+
+      // optional int32 tile_id = 12;
+      case 12: {
+        message.tile_id = readVarint32(bb);
+        break;
+      }
+
+      // optional bool can_chow = 13;
+      case 13: {
+        message.can_chow = !!readByte(bb);
+        break;
+      }
+
+      // optional bool can_hu = 14;
+      case 14: {
+        message.can_hu = !!readByte(bb);
+        break;
+      }
+
+      // optional bool robbing_kong = 15;
+      case 15: {
+        message.robbing_kong = !!readByte(bb);
+        break;
+      }
+
+      default:
+        skipUnknownField(bb, tag & 7);
+    }
+  }
+
+  return message;
 }
 
-function _decodePlayerActionRes(bb) {
+function encodeBotResponseData(message) {
+  let bb = popByteBuffer();
+  _encodeBotResponseData(message, bb);
+  return toUint8Array(bb);
+}
+
+function _encodeBotResponseData(message, bb) {
+  // optional int32 seq = 1;
+  let $seq = message.seq;
+  if ($seq !== undefined) {
+    writeVarint32(bb, 8);
+    writeVarint64(bb, intToLong($seq));
+  }
+
+  // optional string action = 2;
+  let $action = message.action;
+  if ($action !== undefined) {
+    writeVarint32(bb, 18);
+    writeString(bb, $action);
+  }
+
+  // repeated int32 tile_ids = 3;
+  let array$tile_ids = message.tile_ids;
+  if (array$tile_ids !== undefined) {
+    let packed = popByteBuffer();
+    for (let value of array$tile_ids) {
+      writeVarint64(packed, intToLong(value));
+    }
+    writeVarint32(bb, 26);
+    writeVarint32(bb, packed.offset);
+    writeByteBuffer(bb, packed);
+    pushByteBuffer(packed);
+  }
+}
+
+function decodeBotResponseData(binary) {
+  return _decodeBotResponseData(wrapByteBuffer(binary));
+}
+
+function _decodeBotResponseData(bb) {
   let message = {};
 
   end_of_message: while (!isAtEnd(bb)) {
@@ -766,15 +2781,30 @@ function _decodePlayerActionRes(bb) {
       case 0:
         break end_of_message;
 
-      // optional bool success = 1;
+      // optional int32 seq = 1;
       case 1: {
-        message.success = !!readByte(bb);
+        message.seq = readVarint32(bb);
         break;
       }
 
-      // optional string message = 2;
+      // optional string action = 2;
       case 2: {
-        message.message = readString(bb, readVarint32(bb));
+        message.action = readString(bb, readVarint32(bb));
+        break;
+      }
+
+      // repeated int32 tile_ids = 3;
+      case 3: {
+        let values = message.tile_ids || (message.tile_ids = []);
+        if ((tag & 7) === 2) {
+          let outerLimit = pushTemporaryLength(bb);
+          while (!isAtEnd(bb)) {
+            values.push(readVarint32(bb));
+          }
+          bb.limit = outerLimit;
+        } else {
+          values.push(readVarint32(bb));
+        }
         break;
       }
 
@@ -1274,8 +3304,26 @@ window.mahjong_pb.encodePlayerInfo = encodePlayerInfo;
 window.mahjong_pb.decodePlayerInfo = decodePlayerInfo;
 window.mahjong_pb.encodeMeldData = encodeMeldData;
 window.mahjong_pb.decodeMeldData = decodeMeldData;
+window.mahjong_pb.encodeTileDefData = encodeTileDefData;
+window.mahjong_pb.decodeTileDefData = decodeTileDefData;
+window.mahjong_pb.encodeTileRegistryData = encodeTileRegistryData;
+window.mahjong_pb.decodeTileRegistryData = decodeTileRegistryData;
 window.mahjong_pb.encodeSyncStateData = encodeSyncStateData;
 window.mahjong_pb.decodeSyncStateData = decodeSyncStateData;
+window.mahjong_pb.encodePatternData = encodePatternData;
+window.mahjong_pb.decodePatternData = decodePatternData;
+window.mahjong_pb.encodeWinnerScoreData = encodeWinnerScoreData;
+window.mahjong_pb.decodeWinnerScoreData = decodeWinnerScoreData;
+window.mahjong_pb.encodePointTransferData = encodePointTransferData;
+window.mahjong_pb.decodePointTransferData = decodePointTransferData;
+window.mahjong_pb.encodeRevealedHandData = encodeRevealedHandData;
+window.mahjong_pb.decodeRevealedHandData = decodeRevealedHandData;
+window.mahjong_pb.encodeRoundResultData = encodeRoundResultData;
+window.mahjong_pb.decodeRoundResultData = decodeRoundResultData;
+window.mahjong_pb.encodeFinalStandingData = encodeFinalStandingData;
+window.mahjong_pb.decodeFinalStandingData = decodeFinalStandingData;
+window.mahjong_pb.encodeGameOverData = encodeGameOverData;
+window.mahjong_pb.decodeGameOverData = decodeGameOverData;
 window.mahjong_pb.encodeDealTilesData = encodeDealTilesData;
 window.mahjong_pb.decodeDealTilesData = decodeDealTilesData;
 window.mahjong_pb.encodeActionBroadcastData = encodeActionBroadcastData;
@@ -1288,3 +3336,21 @@ window.mahjong_pb.encodePlayerActionData = encodePlayerActionData;
 window.mahjong_pb.decodePlayerActionData = decodePlayerActionData;
 window.mahjong_pb.encodePlayerActionRes = encodePlayerActionRes;
 window.mahjong_pb.decodePlayerActionRes = decodePlayerActionRes;
+window.mahjong_pb.encodeTenpaiHintsReq = encodeTenpaiHintsReq;
+window.mahjong_pb.decodeTenpaiHintsReq = decodeTenpaiHintsReq;
+window.mahjong_pb.encodeWaitHintData = encodeWaitHintData;
+window.mahjong_pb.decodeWaitHintData = decodeWaitHintData;
+window.mahjong_pb.encodeDiscardHintData = encodeDiscardHintData;
+window.mahjong_pb.decodeDiscardHintData = decodeDiscardHintData;
+window.mahjong_pb.encodeTenpaiHintsData = encodeTenpaiHintsData;
+window.mahjong_pb.decodeTenpaiHintsData = decodeTenpaiHintsData;
+window.mahjong_pb.encodeBotJoinReq = encodeBotJoinReq;
+window.mahjong_pb.decodeBotJoinReq = decodeBotJoinReq;
+window.mahjong_pb.encodeBotMeldData = encodeBotMeldData;
+window.mahjong_pb.decodeBotMeldData = decodeBotMeldData;
+window.mahjong_pb.encodeBotOpponentData = encodeBotOpponentData;
+window.mahjong_pb.decodeBotOpponentData = decodeBotOpponentData;
+window.mahjong_pb.encodeBotRequestData = encodeBotRequestData;
+window.mahjong_pb.decodeBotRequestData = decodeBotRequestData;
+window.mahjong_pb.encodeBotResponseData = encodeBotResponseData;
+window.mahjong_pb.decodeBotResponseData = decodeBotResponseData;
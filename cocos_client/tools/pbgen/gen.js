@@ -0,0 +1,302 @@
+#!/usr/bin/env node
+// 由 proto/mahjong.proto 產生 assets/Scripts/Core/mahjong_pb.js 與 mahjong_pb.d.ts
+// 用法: node tools/pbgen/gen.js [proto 路徑] (預設 ../proto/mahjong.proto)
+// 產生的程式碼風格與原本的 pbjs 輸出一致，runtime 取自同目錄的 runtime.*.txt
+
+const fs = require('fs');
+const path = require('path');
+const protobuf = require('protobufjs');
+
+const root = path.resolve(__dirname, '..', '..');
+const protoPath = path.resolve(process.argv[2] || path.join(root, '..', 'proto', 'mahjong.proto'));
+const outDir = path.join(root, 'assets', 'Scripts', 'Core');
+
+const parsed = protobuf.parse(fs.readFileSync(protoPath, 'utf8'), { keepCase: true });
+const pkg = parsed.package ? parsed.root.lookup(parsed.package) : parsed.root;
+const messages = pkg.nestedArray.filter(t => t instanceof protobuf.Type);
+
+const scalarTS = { int32: 'number', string: 'string', bool: 'boolean', bytes: 'Uint8Array' };
+
+function isMessage(type) {
+    return !(type in scalarTS);
+}
+
+function wireType(type) {
+    return type === 'int32' || type === 'bool' ? 0 : 2;
+}
+
+function tsType(field) {
+    if (field.map) {
+        return `{ [key: ${scalarTS[field.keyType]}]: ${scalarTS[field.type]} }`;
+    }
+    const t = isMessage(field.type) ? field.type : scalarTS[field.type];
+    return field.repeated ? `${t}[]` : t;
+}
+
+function comment(field) {
+    if (field.map) return `// optional map<${field.keyType}, ${field.type}> ${field.name} = ${field.id};`;
+    return `// ${field.repeated ? 'repeated' : 'optional'} ${field.type} ${field.name} = ${field.id};`;
+}
+
+// writeValue 寫入單一值 (不含 tag)
+function writeValue(type, target, v) {
+    switch (type) {
+        case 'int32': return [`writeVarint64(${target}, intToLong(${v}));`];
+        case 'string': return [`writeString(${target}, ${v});`];
+        case 'bool': return [`writeByte(${target}, ${v} ? 1 : 0);`];
+        case 'bytes': return [`writeVarint32(${target}, ${v}.length), writeBytes(${target}, ${v});`];
+    }
+    throw new Error(`unsupported type ${type}`);
+}
+
+// readValue 讀取單一值的運算式
+function readValue(type) {
+    switch (type) {
+        case 'int32': return 'readVarint32(bb)';
+        case 'string': return 'readString(bb, readVarint32(bb))';
+        case 'bool': return '!!readByte(bb)';
+        case 'bytes': return 'readBytes(bb, readVarint32(bb))';
+    }
+    throw new Error(`unsupported type ${type}`);
+}
+
+function nestedWrite(type, value, tag) {
+    return [
+        `writeVarint32(bb, ${tag});`,
+        'let nested = popByteBuffer();',
+        `_encode${type}(${value}, nested);`,
+        'writeVarint32(bb, nested.limit);',
+        'writeByteBuffer(bb, nested);',
+        'pushByteBuffer(nested);',
+    ];
+}
+
+function indent(lines, n) {
+    const pad = ' '.repeat(n);
+    return lines.map(l => (l ? pad + l : l));
+}
+
+function encodeField(field) {
+    const name = field.name;
+    const lines = [comment(field)];
+    if (field.map) {
+        const keyValue = field.keyType === 'int32' ? '+key' : 'key';
+        lines.push(
+            `let map$${name} = message.${name};`,
+            `if (map$${name} !== undefined) {`,
+            `  for (let key in map$${name}) {`,
+            '    let nested = popByteBuffer();',
+            `    let value = map$${name}[key];`,
+            `    writeVarint32(nested, ${(1 << 3) | wireType(field.keyType)});`,
+            ...indent(writeValue(field.keyType, 'nested', keyValue), 4),
+            `    writeVarint32(nested, ${(2 << 3) | wireType(field.type)});`,
+            ...indent(writeValue(field.type, 'nested', 'value'), 4),
+            `    writeVarint32(bb, ${(field.id << 3) | 2});`,
+            '    writeVarint32(bb, nested.offset);',
+            '    writeByteBuffer(bb, nested);',
+            '    pushByteBuffer(nested);',
+            '  }',
+            '}',
+        );
+    } else if (field.repeated && field.type === 'int32') {
+        lines.push(
+            `let array$${name} = message.${name};`,
+            `if (array$${name} !== undefined) {`,
+            '  let packed = popByteBuffer();',
+            `  for (let value of array$${name}) {`,
+            '    writeVarint64(packed, intToLong(value));',
+            '  }',
+            `  writeVarint32(bb, ${(field.id << 3) | 2});`,
+            '  writeVarint32(bb, packed.offset);',
+            '  writeByteBuffer(bb, packed);',
+            '  pushByteBuffer(packed);',
+            '}',
+        );
+    } else if (field.repeated) {
+        const tag = (field.id << 3) | 2;
+        const body = isMessage(field.type)
+            ? nestedWrite(field.type, 'value', tag)
+            : [`writeVarint32(bb, ${tag});`, ...writeValue(field.type, 'bb', 'value')];
+        lines.push(
+            `let array$${name} = message.${name};`,
+            `if (array$${name} !== undefined) {`,
+            `  for (let value of array$${name}) {`,
+            ...indent(body, 4),
+            '  }',
+            '}',
+        );
+    } else {
+        const tag = (field.id << 3) | (isMessage(field.type) ? 2 : wireType(field.type));
+        const body = isMessage(field.type)
+            ? nestedWrite(field.type, `$${name}`, tag)
+            : [`writeVarint32(bb, ${tag});`, ...writeValue(field.type, 'bb', `$${name}`)];
+        lines.push(
+            `let $${name} = message.${name};`,
+            `if ($${name} !== undefined) {`,
+            ...indent(body, 2),
+            '}',
+        );
+    }
+    return lines;
+}
+
+function decodeField(field, ts) {
+    const name = field.name;
+    const lines = [comment(field), `case ${field.id}: {`];
+    if (field.map) {
+        const keyType = scalarTS[field.keyType];
+        const valueType = scalarTS[field.type];
+        const zero = t => (t === 'string' ? '""' : t === 'bool' ? 'false' : '0');
+        lines.push(
+            `  let values = message.${name} || (message.${name} = {});`,
+            '  let outerLimit = pushTemporaryLength(bb);',
+            `  let key${ts ? `: ${keyType}` : ''} = ${zero(field.keyType)};`,
+            `  let value${ts ? `: ${valueType}` : ''} = ${zero(field.type)};`,
+            '  end_of_entry: while (!isAtEnd(bb)) {',
+            '    let tag = readVarint32(bb);',
+            '    switch (tag >>> 3) {',
+            '      case 0:',
+            '        break end_of_entry;',
+            '      case 1: {',
+            `        key = ${readValue(field.keyType)};`,
+            '        break;',
+            '      }',
+            '      case 2: {',
+            `        value = ${readValue(field.type)};`,
+            '        break;',
+            '      }',
+            '      default:',
+            '        skipUnknownField(bb, tag & 7);',
+            '    }',
+            '  }',
+            '  values[key] = value;',
+            '  bb.limit = outerLimit;',
+        );
+    } else if (field.repeated && field.type === 'int32') {
+        lines.push(
+            `  let values = message.${name} || (message.${name} = []);`,
+            '  if ((tag & 7) === 2) {',
+            '    let outerLimit = pushTemporaryLength(bb);',
+            '    while (!isAtEnd(bb)) {',
+            '      values.push(readVarint32(bb));',
+            '    }',
+            '    bb.limit = outerLimit;',
+            '  } else {',
+            '    values.push(readVarint32(bb));',
+            '  }',
+        );
+    } else if (field.repeated && isMessage(field.type)) {
+        lines.push(
+            '  let limit = pushTemporaryLength(bb);',
+            `  let values = message.${name} || (message.${name} = []);`,
+            `  values.push(_decode${field.type}(bb));`,
+            '  bb.limit = limit;',
+        );
+    } else if (field.repeated) {
+        lines.push(
+            `  let values = message.${name} || (message.${name} = []);`,
+            `  values.push(${readValue(field.type)});`,
+        );
+    } else if (isMessage(field.type)) {
+        lines.push(
+            '  let limit = pushTemporaryLength(bb);',
+            `  message.${name} = _decode${field.type}(bb);`,
+            '  bb.limit = limit;',
+        );
+    } else {
+        lines.push(`  message.${name} = ${readValue(field.type)};`);
+    }
+    lines.push('  break;', '}');
+    return lines;
+}
+
+function joinBlocks(blocks) {
+    const out = [];
+    blocks.forEach((b, i) => {
+        if (i > 0) out.push('');
+        out.push(...b);
+    });
+    return out;
+}
+
+function genMessage(type, ts) {
+    const n = type.name;
+    const fields = type.fieldsArray;
+    const out = [];
+    if (ts) {
+        out.push(`export interface ${n} {`);
+        for (const f of fields) out.push(`  ${f.name}?: ${tsType(f)};`);
+        out.push('}', '');
+    }
+    const exp = ts ? 'export ' : '';
+    out.push(
+        `${exp}function encode${n}(message${ts ? `: ${n}` : ''})${ts ? ': Uint8Array' : ''} {`,
+        '  let bb = popByteBuffer();',
+        `  _encode${n}(message, bb);`,
+        '  return toUint8Array(bb);',
+        '}',
+        '',
+        `function _encode${n}(message${ts ? `: ${n}, bb: ByteBuffer): void` : ', bb)'} {`,
+        ...indent(joinBlocks(fields.map(encodeField)), 2),
+        '}',
+        '',
+        `${exp}function decode${n}(binary${ts ? `: Uint8Array): ${n}` : ')'} {`,
+        `  return _decode${n}(wrapByteBuffer(binary));`,
+        '}',
+        '',
+        `function _decode${n}(bb${ts ? `: ByteBuffer): ${n}` : ')'} {`,
+        `  let message${ts ? `: ${n} = {} as any` : ' = {}'};`,
+        '',
+        '  end_of_message: while (!isAtEnd(bb)) {',
+        '    let tag = readVarint32(bb);',
+        '',
+        '    switch (tag >>> 3) {',
+        '      case 0:',
+        '        break end_of_message;',
+        '',
+        ...indent(fields.map(f => [...decodeField(f, ts), '']).flat(), 6),
+        '      default:',
+        '        skipUnknownField(bb, tag & 7);',
+        '    }',
+        '  }',
+        '',
+        '  return message;',
+        '}',
+    );
+    return out;
+}
+
+function runtime(ext) {
+    return fs.readFileSync(path.join(__dirname, `runtime.${ext}.txt`), 'utf8').replace(/\n+$/, '').split('\n');
+}
+
+function generate(ts) {
+    const out = joinBlocks(messages.map(m => genMessage(m, ts)));
+    out.push('', ...runtime(ts ? 'ts' : 'js'));
+    const names = messages.flatMap(m => [`encode${m.name}`, `decode${m.name}`]);
+    if (ts) {
+        out.push('declare namespace mahjong_pb {');
+        for (const m of messages) {
+            out.push(`  export function encode${m.name}(message: ${m.name}): Uint8Array;`);
+            out.push(`  export function decode${m.name}(binary: Uint8Array): ${m.name};`);
+        }
+        out.push(
+            '}',
+            '',
+            'declare global {',
+            '  interface Window {',
+            '    mahjong_pb: typeof mahjong_pb;',
+            '  }',
+            '}',
+            'export { };',
+        );
+    } else {
+        out.push('', 'window.mahjong_pb = window.mahjong_pb || {};');
+        for (const fn of names) out.push(`window.mahjong_pb.${fn} = ${fn};`);
+    }
+    return out.join('\n') + '\n';
+}
+
+fs.writeFileSync(path.join(outDir, 'mahjong_pb.d.ts'), generate(true));
+fs.writeFileSync(path.join(outDir, 'mahjong_pb.js'), generate(false));
+console.log(`generated ${messages.length} messages from ${path.relative(process.cwd(), protoPath)}`);
@@ -0,0 +1,9 @@
+// Package config 內嵌隨程式發佈的預設設定檔
+package config
+
+import _ "embed"
+
+// TaiRules 內建的台數表 (tai_rules.yaml)，未另外載入設定檔時使用
+//
+//go:embed tai_rules.yaml
+var TaiRules []byte
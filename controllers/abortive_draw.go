@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"webmajiang/models"
+	"webmajiang/utils"
+)
+
+// abortRound 以流局結束本局 (不儲存狀態，由呼叫端儲存)
+func abortRound(ctx context.Context, gameID string, state *models.GameState, reason models.DrawReason) error {
+	state.Stage = models.StageRoundOver
+	state.WinnerIDs = nil
+	state.ScoreResults = nil
+	state.DrawReason = reason
+	utils.Info("[Draw] Game %s round ended in a draw: %s", gameID, reason.String())
+	return finishRound(ctx, gameID, state, 0, nil)
+}
+
+// checkFourKongsAbort 開槓後檢查四槓散了，成立時以流局結束本局並回傳 true
+func checkFourKongsAbort(ctx context.Context, gameID string, state *models.GameState) (bool, error) {
+	if !state.Rules.AbortiveDraws.FourKongs {
+		return false, nil
+	}
+
+	kongCounts := make(map[int]int)
+	for seat := 1; seat <= 4; seat++ {
+		melds, err := GetPlayerMelds(ctx, gameID, seat)
+		if err != nil {
+			return false, err
+		}
+		for _, m := range melds {
+			switch m.Type {
+			case models.MeldTypeKong, models.MeldTypeHiddenKong, models.MeldTypeAddKong:
+				kongCounts[seat]++
+			}
+		}
+	}
+
+	if !models.IsFourKongsAbort(kongCounts) {
+		return false, nil
+	}
+	return true, abortRound(ctx, gameID, state, models.DrawFourKongs)
+}
+
+// recordFirstDiscard 記錄未被打斷的第一巡出牌，四風連打成立時以流局結束本局
+func recordFirstDiscard(ctx context.Context, gameID string, state *models.GameState, tile models.Tile) error {
+	if state.IsInterrupted || state.DiscardCount > 4 {
+		return nil
+	}
+
+	state.FirstDiscards = append(state.FirstDiscards, tile)
+	if state.Rules.AbortiveDraws.FourWinds && models.IsFourWindsAbort(state.FirstDiscards) {
+		return abortRound(ctx, gameID, state, models.DrawFourWinds)
+	}
+	return nil
+}
+
+// DeclareNineTerminals 玩家宣告九種九牌流局 (13 張玩法、未被打斷的第一巡、自己的出牌階段)
+func DeclareNineTerminals(ctx context.Context, gameID string, playerID int) (*models.GameState, error) {
+	state, err := loadActiveGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !state.Rules.AbortiveDraws.NineTerminals || state.Rules.GameType != models.GameType13 {
+		return nil, fmt.Errorf("nine terminals draw is not enabled for this game")
+	}
+	if state.Stage != models.StagePlayerDiscard {
+		return nil, fmt.Errorf("action not allowed in current stage: %s", state.Stage)
+	}
+	if state.CurrentPlayerID != playerID {
+		return nil, fmt.Errorf("not your turn, current player is %d", state.CurrentPlayerID)
+	}
+	// 未被打斷且出牌次數未滿四張，代表目前玩家尚未打出第一張牌
+	if state.IsInterrupted || state.DiscardCount >= 4 {
+		return nil, fmt.Errorf("nine terminals can only be declared on the first uninterrupted turn")
+	}
+
+	hand, err := GetPlayerHand(ctx, gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if !models.IsNineTerminals(hand) {
+		return nil, fmt.Errorf("hand does not have nine kinds of terminals and honors")
+	}
+
+	if err := abortRound(ctx, gameID, state, models.DrawNineTerminals); err != nil {
+		return nil, err
+	}
+	if err := SaveGameState(ctx, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"webmajiang/models"
+	"webmajiang/utils"
+)
+
+// LoadTableSnapshot 從 Redis 讀取牌堆與四家的手牌、副露、花牌、河牌
+func LoadTableSnapshot(ctx context.Context, gameID string) (models.TableSnapshot, error) {
+	wall, err := GetDeckTiles(ctx, gameID)
+	if err != nil {
+		return models.TableSnapshot{}, err
+	}
+
+	snap := models.TableSnapshot{
+		Wall:     wall,
+		Hands:    make(map[int][]models.Tile, 4),
+		Melds:    make(map[int][]models.Meld, 4),
+		Flowers:  make(map[int][]models.Tile, 4),
+		Discards: make(map[int][]models.Tile, 4),
+	}
+	for seat := 1; seat <= 4; seat++ {
+		if snap.Hands[seat], err = GetPlayerHand(ctx, gameID, seat); err != nil {
+			return models.TableSnapshot{}, err
+		}
+		if snap.Melds[seat], err = GetPlayerMelds(ctx, gameID, seat); err != nil {
+			return models.TableSnapshot{}, err
+		}
+		if snap.Flowers[seat], err = GetPlayerFlowers(ctx, gameID, seat); err != nil {
+			return models.TableSnapshot{}, err
+		}
+		if snap.Discards[seat], err = GetPlayerDiscards(ctx, gameID, seat); err != nil {
+			return models.TableSnapshot{}, err
+		}
+	}
+	return snap, nil
+}
+
+// AuditGame 稽核牌桌守恆與狀態一致性
+// 有違規時記錄完整快照；房間規則開啟 audit.freeze 時凍結遊戲，之後的變更皆被拒絕
+func AuditGame(ctx context.Context, gameID string) (*models.AuditReport, error) {
+	state, err := LoadGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := LoadTableSnapshot(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load table snapshot: %w", err)
+	}
+
+	report := &models.AuditReport{
+		GameID:     gameID,
+		Stage:      state.Stage,
+		Violations: models.AuditTable(state, snap),
+		Snapshot:   &snap,
+	}
+	if report.OK() {
+		return report, nil
+	}
+
+	snapJSON, _ := json.Marshal(struct {
+		State *models.GameState    `json:"state"`
+		Table models.TableSnapshot `json:"table"`
+	}{state, snap})
+	for _, v := range report.Violations {
+		utils.Error("[Audit] game %s (%s): %s", gameID, state.Stage, v.Message)
+	}
+	utils.Error("[Audit] game %s snapshot: %s", gameID, snapJSON)
+
+	if state.Rules.Audit.Freeze && !state.IsFrozen && !state.IsFinished {
+		state.IsFrozen = true
+		state.FrozenReason = report.Violations[0].Message
+		if err := SaveGameState(ctx, state); err != nil {
+			return report, err
+		}
+		utils.Error("[Audit] game %s frozen", gameID)
+	}
+	return report, nil
+}
+
+// auditAfterAction 房間規則開啟 audit.every_action 時，於每次動作後稽核
+func auditAfterAction(ctx context.Context, gameID string, state *models.GameState) {
+	if !state.Rules.Audit.EveryAction || state.IsFrozen {
+		return
+	}
+	if _, err := AuditGame(ctx, gameID); err != nil {
+		utils.Error("[Audit] game %s audit failed: %v", gameID, err)
+	}
+}
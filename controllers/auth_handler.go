@@ -161,7 +161,8 @@ func LoginHandler(c *hypcontext.Context) {
 	}
 
 	// Generate JWT string
-	token, err := utils.GenerateJWT(user.ID, user.Username, 24*time.Hour)
+	role := models.RoleOf(user.Email)
+	token, err := utils.GenerateJWT(user.ID, user.Username, string(role), 24*time.Hour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "failed to generate login token"})
 		return
@@ -174,6 +175,7 @@ func LoginHandler(c *hypcontext.Context) {
 			"id":       user.ID,
 			"username": user.Username,
 			"email":    user.Email,
+			"role":     role,
 		},
 	})
 }
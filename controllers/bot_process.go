@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"webmajiang/models/pb"
+	"webmajiang/utils"
+)
+
+// 子程序 AI 的編碼方式
+const (
+	BotCodecJSON  = "json"  // 每行一筆 JSON (欄位名稱同 proto，例如 hand_ids)
+	BotCodecProto = "proto" // varint 長度前綴的 protobuf
+)
+
+// processBotTransport 本機子程序 AI：請求寫入 stdin，回應從 stdout 讀取，stderr 直接輸出到伺服器
+type processBotTransport struct {
+	name  string
+	codec string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	writeMu sync.Mutex
+}
+
+// startBotProcess 啟動子程序 AI，讀到的回應交給 deliver
+func startBotProcess(cfg BotProcessConfig, deliver func(*pb.BotResponseData)) (*processBotTransport, error) {
+	if len(cfg.Command) == 0 {
+		return nil, fmt.Errorf("bot process %q has no command", cfg.Name)
+	}
+	codec := cfg.Codec
+	if codec == "" {
+		codec = BotCodecJSON
+	}
+	if codec != BotCodecJSON && codec != BotCodecProto {
+		return nil, fmt.Errorf("bot process %q: unknown codec %q", cfg.Name, codec)
+	}
+
+	cmd := exec.Command(cfg.Command[0], cfg.Command[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bot stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bot stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start bot process %q: %w", cfg.Name, err)
+	}
+
+	t := &processBotTransport{name: cfg.Name, codec: codec, cmd: cmd, stdin: stdin}
+	go t.readLoop(stdout, deliver)
+	return t, nil
+}
+
+// readLoop 持續讀取回應直到子程序結束；無法解析的回應略過 (該請求會逾時由內建 AI 代打)
+func (t *processBotTransport) readLoop(stdout io.Reader, deliver func(*pb.BotResponseData)) {
+	r := bufio.NewReader(stdout)
+	for {
+		res := &pb.BotResponseData{}
+		var err error
+		if t.codec == BotCodecProto {
+			err = protodelim.UnmarshalFrom(r, res)
+		} else {
+			var line []byte
+			if line, err = r.ReadBytes('\n'); err == nil || (errors.Is(err, io.EOF) && len(line) > 0) {
+				err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(line, res)
+				if err != nil {
+					utils.Error("[Bot] process %s: invalid response: %v", t.name, err)
+					continue
+				}
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				utils.Error("[Bot] process %s: read failed: %v", t.name, err)
+			}
+			return
+		}
+		deliver(res)
+	}
+}
+
+func (t *processBotTransport) send(req *pb.BotRequestData) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	if t.codec == BotCodecProto {
+		_, err := protodelim.MarshalTo(t.stdin, req)
+		return err
+	}
+	line, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = t.stdin.Write(append(line, '\n'))
+	return err
+}
+
+// close 關閉 stdin 並結束子程序
+func (t *processBotTransport) close() error {
+	t.stdin.Close()
+	if err := t.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("failed to stop bot process %q: %w", t.name, err)
+	}
+	go t.cmd.Wait()
+	return nil
+}
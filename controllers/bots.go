@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"hash/fnv"
+	"io"
+	"strconv"
+	"sync"
+
+	"webmajiang/models"
+	"webmajiang/utils"
+)
+
+// AI 玩家實體：gameID → 座位 → Bot
+// 未指定時依 Player.Strategy 建立內建 AI，以 gameID 與座位為隨機種子 (同一局重播結果相同)
+var (
+	botsMu sync.Mutex
+	bots   = make(map[string]map[int]models.Bot)
+)
+
+// AssignBot 指定座位使用的 AI，原本的 AI 若持有連線則關閉
+func AssignBot(gameID string, seat int, bot models.Bot) {
+	botsMu.Lock()
+	seats, ok := bots[gameID]
+	if !ok {
+		seats = make(map[int]models.Bot)
+		bots[gameID] = seats
+	}
+	old := seats[seat]
+	seats[seat] = bot
+	botsMu.Unlock()
+
+	if old != nil && old != bot {
+		closeBot(old)
+	}
+}
+
+// unassignBot 座位目前的 AI 仍是 bot 時移除，下次決策改用內建 AI
+func unassignBot(gameID string, seat int, bot models.Bot) {
+	botsMu.Lock()
+	defer botsMu.Unlock()
+	if bots[gameID][seat] == bot {
+		delete(bots[gameID], seat)
+	}
+}
+
+// releaseBots 一將結束後釋放該局的 AI 並關閉外部連線
+func releaseBots(gameID string) {
+	botsMu.Lock()
+	seats := bots[gameID]
+	delete(bots, gameID)
+	botsMu.Unlock()
+
+	for _, bot := range seats {
+		closeBot(bot)
+	}
+}
+
+// closeBot 關閉外部 AI 的連線 (內建 AI 不需處理)
+func closeBot(bot models.Bot) {
+	if c, ok := bot.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			utils.Error("[Bot] close failed: %v", err)
+		}
+	}
+}
+
+// builtinBot 依玩家記錄的策略建立內建 AI；外部 AI 或未記錄時採房間規則
+func builtinBot(state *models.GameState, seat int) models.Bot {
+	strategy := state.Players[seat].Strategy
+	if !strategy.Valid() {
+		strategy = state.Rules.BotStrategy(seat)
+	}
+	h := fnv.New64a()
+	h.Write([]byte(state.GameID + ":" + strconv.Itoa(seat)))
+	return models.NewBot(strategy, h.Sum64())
+}
+
+// botFor 取得座位的 AI，尚未指定時建立內建 AI
+func botFor(state *models.GameState, seat int) models.Bot {
+	botsMu.Lock()
+	defer botsMu.Unlock()
+
+	if bot, ok := bots[state.GameID][seat]; ok {
+		return bot
+	}
+
+	bot := builtinBot(state, seat)
+	seats, ok := bots[state.GameID]
+	if !ok {
+		seats = make(map[int]models.Bot)
+		bots[state.GameID] = seats
+	}
+	seats[seat] = bot
+	return bot
+}
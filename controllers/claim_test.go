@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"webmajiang/models"
+	"webmajiang/service"
+	"webmajiang/utils"
+)
+
+// setupClaimWindow 以記憶體 Redis 建立一局停在 WAIT_ACTION 的牌桌：座位 1 打出 discard，其餘座位手牌依 hands 設定
+// 每張牌依牌種分配不重複的登錄表 ID
+func setupClaimWindow(t *testing.T, rules models.RoomRules, hands map[int]string, discard string) (context.Context, string) {
+	t.Helper()
+	utils.SetQuiet(true)
+	if _, err := service.InitMemoryRedis(); err != nil {
+		t.Fatalf("InitMemoryRedis failed: %v", err)
+	}
+	if err := models.LoadTaiTables("../config/tai_rules.yaml"); err != nil {
+		t.Fatalf("LoadTaiTables failed: %v", err)
+	}
+
+	ctx := context.Background()
+	gameID := "claim_test"
+	state, err := StartNewGame(ctx, gameID, rules)
+	if err != nil {
+		t.Fatalf("StartNewGame failed: %v", err)
+	}
+
+	copies := make(map[int]int)
+	assignID := func(tile models.Tile) models.Tile {
+		id := models.KindTileID(tile)
+		tile.ID = id + copies[id]
+		copies[id]++
+		return tile
+	}
+
+	for seat := 1; seat <= 4; seat++ {
+		for _, tile := range models.MustParseTiles(hands[seat]) {
+			data, _ := json.Marshal(assignID(tile))
+			if err := service.RedisClient.RPush(ctx, PlayerHandKey(gameID, seat), data).Err(); err != nil {
+				t.Fatalf("RPush failed: %v", err)
+			}
+		}
+		player := state.Players[seat]
+		player.IsBot = false
+		state.Players[seat] = player
+	}
+
+	tile := assignID(models.MustParseTiles(discard)[0])
+	state.Stage = models.StageWaitAction
+	state.DealerPlayerID = 1
+	state.CurrentPlayerID = 1
+	state.LastDiscardPlayerID = 1
+	state.LastDiscardTile = &tile
+	state.ActionDeclarations = map[int]string{}
+	if err := SaveGameState(ctx, state); err != nil {
+		t.Fatalf("SaveGameState failed: %v", err)
+	}
+	return ctx, gameID
+}
+
+func TestPlayerDeclareClaim_FalseHuKeepsWindowOpen(t *testing.T) {
+	ctx, gameID := setupClaimWindow(t, models.DefaultRoomRules(models.GameType13), map[int]string{
+		1: "1m",
+		2: "147m147p147s1234z",
+		3: "55p123456m789s12z",
+		4: "2589m2589p2589s5z",
+	}, "5p")
+
+	state, err := PlayerDeclareClaim(ctx, gameID, 2, "hu", nil)
+	if !errors.Is(err, ErrFalseWin) {
+		t.Fatalf("Expected ErrFalseWin, got %v", err)
+	}
+	if state.Stage != models.StageWaitAction {
+		t.Fatalf("False hu should keep the claim window open, got stage %s", state.Stage)
+	}
+	if state.ActionDeclarations[2] != "pass" {
+		t.Errorf("False hu should be recorded as pass, got %q", state.ActionDeclarations[2])
+	}
+	if state.Scores[2] >= 0 {
+		t.Errorf("False hu should be penalized, got scores %v", state.Scores)
+	}
+
+	if state, err = PlayerDeclareClaim(ctx, gameID, 3, "pong", nil); err != nil {
+		t.Fatalf("pong failed: %v", err)
+	}
+	if state.Stage != models.StageWaitAction {
+		t.Fatalf("Window should stay open until seat 4 declares, got stage %s", state.Stage)
+	}
+	if state, err = PlayerDeclareClaim(ctx, gameID, 4, "pass", nil); err != nil {
+		t.Fatalf("pass failed: %v", err)
+	}
+	if state.Stage != models.StagePlayerDiscard || state.CurrentPlayerID != 3 {
+		t.Errorf("Expected seat 3 to pong after the false hu, got stage %s, current %d", state.Stage, state.CurrentPlayerID)
+	}
+}
+
+func TestPlayerDeclareClaim_HuWaitsForPossibleWinners(t *testing.T) {
+	ctx, gameID := setupClaimWindow(t, models.DefaultRoomRules(models.GameType13), map[int]string{
+		1: "1m",
+		2: "46p123456m789s11z",
+		3: "46p123456m789s22z",
+		4: "2589m2589p2589s5z",
+	}, "5p")
+
+	state, err := PlayerDeclareClaim(ctx, gameID, 2, "hu", nil)
+	if err != nil {
+		t.Fatalf("hu failed: %v", err)
+	}
+	if state.Stage != models.StageWaitAction {
+		t.Fatalf("Seat 3 can still win, window should stay open, got stage %s", state.Stage)
+	}
+
+	// 座位 4 不可能胡這張牌，座位 3 表態後即結算
+	if state, err = PlayerDeclareClaim(ctx, gameID, 3, "pass", nil); err != nil {
+		t.Fatalf("pass failed: %v", err)
+	}
+	if state.Stage != models.StageRoundOver {
+		t.Errorf("Expected the hu to resolve once seat 3 passed, got stage %s", state.Stage)
+	}
+}
+
+// runBotClaims 將座位 2-4 交給 AI，執行出牌後的 AI 宣告與結算
+func runBotClaims(t *testing.T, ctx context.Context, gameID string) *models.GameState {
+	t.Helper()
+	state, err := LoadGameState(ctx, gameID)
+	if err != nil {
+		t.Fatalf("LoadGameState failed: %v", err)
+	}
+	for seat := 2; seat <= 4; seat++ {
+		player := state.Players[seat]
+		player.IsBot = true
+		state.Players[seat] = player
+	}
+	if err := SaveGameState(ctx, state); err != nil {
+		t.Fatalf("SaveGameState failed: %v", err)
+	}
+
+	if err := driveAI(ctx, nil, gameID); err != nil {
+		t.Fatalf("driveAI failed: %v", err)
+	}
+	if state, err = LoadGameState(ctx, gameID); err != nil {
+		t.Fatalf("LoadGameState failed: %v", err)
+	}
+	return state
+}
+
+func TestDriveAI_MultiWin(t *testing.T) {
+	hands := map[int]string{
+		1: "1m",
+		2: "46p123456m789s11z",
+		3: "46p123456m789s22z",
+		4: "2589m2589p2589s5z",
+	}
+	tests := []struct {
+		mode    models.MultiWinMode
+		winners []int
+	}{
+		{models.MultiWinAll, []int{2, 3}},
+		{models.MultiWinFirst, []int{2}},
+	}
+	for _, tt := range tests {
+		rules := models.DefaultRoomRules(models.GameType13)
+		rules.MultiWin = tt.mode
+		ctx, gameID := setupClaimWindow(t, rules, hands, "5p")
+
+		state := runBotClaims(t, ctx, gameID)
+		if state.Stage != models.StageRoundOver {
+			t.Fatalf("%s: expected ROUND_OVER, got %s", tt.mode, state.Stage)
+		}
+		if !reflect.DeepEqual(state.WinnerIDs, tt.winners) {
+			t.Errorf("%s: expected winners %v, got %v", tt.mode, tt.winners, state.WinnerIDs)
+		}
+	}
+}
+
+func TestDriveAI_ReleasesLockWhileThinking(t *testing.T) {
+	rules := models.DefaultRoomRules(models.GameType13)
+	rules.Timers.AIThinkMillis = 200
+	ctx, gameID := setupClaimWindow(t, rules, map[int]string{}, "5p")
+
+	// 輪到 AI 座位 2 摸牌
+	state, err := LoadGameState(ctx, gameID)
+	if err != nil {
+		t.Fatalf("LoadGameState failed: %v", err)
+	}
+	player := state.Players[2]
+	player.IsBot = true
+	state.Players[2] = player
+	state.Stage = models.StagePlayerDraw
+	state.CurrentPlayerID = 2
+	if err := SaveGameState(ctx, state); err != nil {
+		t.Fatalf("SaveGameState failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- driveAI(ctx, nil, gameID) }()
+	time.Sleep(50 * time.Millisecond)
+
+	// AI 思考中不持有遊戲鎖：此時推進狀態 (改由真人座位 3 摸牌)，AI 醒來後不得再摸牌
+	locked := make(chan func(), 1)
+	go func() { locked <- lockGame(gameID) }()
+	select {
+	case unlock := <-locked:
+		state.CurrentPlayerID = 3
+		if err := SaveGameState(ctx, state); err != nil {
+			t.Fatalf("SaveGameState failed: %v", err)
+		}
+		unlock()
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("AI should not hold the game lock while thinking")
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("driveAI failed: %v", err)
+	}
+	if state, err = LoadGameState(ctx, gameID); err != nil {
+		t.Fatalf("LoadGameState failed: %v", err)
+	}
+	if state.Stage != models.StagePlayerDraw || state.CurrentPlayerID != 3 {
+		t.Errorf("Stale AI draw should be dropped, got stage %s, current %d", state.Stage, state.CurrentPlayerID)
+	}
+}
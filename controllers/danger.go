@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"webmajiang/models"
+)
+
+// LoadDangerView 讀取座位評估危險度所需的資訊：自己的手牌與副露、場上已現身的牌、三家的河牌與副露
+func LoadDangerView(ctx context.Context, gameID string, state *models.GameState, seat int) (models.DangerView, error) {
+	if seat < 1 || seat > 4 {
+		return models.DangerView{}, fmt.Errorf("invalid seat: %d", seat)
+	}
+
+	hand, err := GetPlayerHand(ctx, gameID, seat)
+	if err != nil {
+		return models.DangerView{}, err
+	}
+	melds, err := GetPlayerMelds(ctx, gameID, seat)
+	if err != nil {
+		return models.DangerView{}, err
+	}
+	visible, err := GetVisibleTiles(ctx, gameID, seat)
+	if err != nil {
+		return models.DangerView{}, err
+	}
+
+	view := models.DangerView{
+		DiscardView: models.DiscardView{
+			Hand:           hand,
+			Melds:          melds,
+			Visible:        visible,
+			Shapes:         state.SpecialShapes(),
+			SeatWind:       models.SeatWind(seat, state.DealerPlayerID),
+			PrevailingWind: state.Round.PrevailingWind,
+		},
+		Seat:     seat,
+		GameType: state.Rules.GameType,
+	}
+
+	for p := 1; p <= 4; p++ {
+		if p == seat {
+			continue
+		}
+		opp := models.OpponentView{Seat: p}
+		if opp.Discards, err = GetPlayerDiscards(ctx, gameID, p); err != nil {
+			return models.DangerView{}, err
+		}
+		if opp.Melds, err = GetPlayerMelds(ctx, gameID, p); err != nil {
+			return models.DangerView{}, err
+		}
+		// 他家的暗槓只看得到有一組
+		for i, m := range opp.Melds {
+			if m.Type == models.MeldTypeHiddenKong {
+				opp.Melds[i].Tiles = nil
+			}
+		}
+		view.Opponents = append(view.Opponents, opp)
+	}
+	return view, nil
+}
+
+// AssessSeatDanger 評估座位目前手牌每種牌的放槍危險度與是否建議棄胡
+func AssessSeatDanger(ctx context.Context, gameID string, seat int) (models.DangerReport, error) {
+	state, err := LoadGameState(ctx, gameID)
+	if err != nil {
+		return models.DangerReport{}, err
+	}
+	view, err := LoadDangerView(ctx, gameID, state, seat)
+	if err != nil {
+		return models.DangerReport{}, err
+	}
+	return models.AssessDanger(view), nil
+}
@@ -3,10 +3,12 @@ package controllers
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"sort"
+	"sync"
 
 	"golang.org/x/crypto/chacha20"
 
@@ -14,69 +16,11 @@ import (
 	"webmajiang/service"
 )
 
-// NewDeck 根據遊戲類型初始化麻將牌
-// GameType13: 136 張 (不含花牌)
-// GameType16: 144 張 (含花牌)
-func NewDeck(gameType models.GameType) []models.Tile {
-	capacity := 144
-	if gameType == models.GameType13 {
-		capacity = 136
-	}
-	deck := make([]models.Tile, 0, capacity)
-	id := 0
-
-	// 萬、筒、條 各 1-9，每種 4 張 → 3 × 9 × 4 = 108 張
-	for _, tileType := range []models.TileType{models.Wan, models.Tong, models.Tiao} {
-		for value := 1; value <= 9; value++ {
-			for copy := 0; copy < 4; copy++ {
-				deck = append(deck, models.Tile{
-					ID:    id,
-					Type:  tileType,
-					Value: value,
-				})
-				id++
-			}
-		}
-	}
-
-	// 風牌: 東南西北，各 4 張 → 4 × 4 = 16 張
-	for value := 1; value <= 4; value++ {
-		for copy := 0; copy < 4; copy++ {
-			deck = append(deck, models.Tile{
-				ID:    id,
-				Type:  models.Wind,
-				Value: value,
-			})
-			id++
-		}
-	}
-
-	// 元牌 (三元牌): 中發白，各 4 張 → 3 × 4 = 12 張
-	for value := 1; value <= 3; value++ {
-		for copy := 0; copy < 4; copy++ {
-			deck = append(deck, models.Tile{
-				ID:    id,
-				Type:  models.Dragon,
-				Value: value,
-			})
-			id++
-		}
-	}
-
-	// 花牌: 只有 16 張玩法才加入花牌
-	if gameType == models.GameType16 {
-		// 花牌: 梅蘭竹菊春夏秋冬，各 1 張 → 8 張
-		for value := 1; value <= 8; value++ {
-			deck = append(deck, models.Tile{
-				ID:    id,
-				Type:  models.Flower,
-				Value: value,
-			})
-			id++
-		}
-	}
-
-	return deck
+// NewDeck 依牌 ID 登錄表初始化麻將牌 (ID 1 起算，見 models.TileRegistry)
+// withFlowers 為 false: 136 張 (不含花牌)
+// withFlowers 為 true: 144 張 (含花牌)
+func NewDeck(withFlowers bool) []models.Tile {
+	return models.RegistryTiles(withFlowers)
 }
 
 // chacha20Rand 使用 ChaCha20 產生密碼學安全的偽隨機數
@@ -86,17 +30,47 @@ type chacha20Rand struct {
 	buf    [8]byte
 }
 
-// newChaCha20Rand 建立 ChaCha20 隨機數產生器 (crypto/rand 做種子)
-func newChaCha20Rand() (*chacha20Rand, error) {
-	// 使用 crypto/rand 產生 32-byte key + 12-byte nonce
-	var key [32]byte
-	var nonce [12]byte
+// 固定種子 (模擬對局用)：設定後 key 由種子衍生、nonce 依建立順序遞增，洗牌與擲骰結果可重現
+var (
+	seedMu    sync.Mutex
+	seedKey   *[32]byte
+	seedCount uint64
+)
+
+// SetRandomSeed 以固定種子取代 crypto/rand，只供模擬與重現對局使用
+func SetRandomSeed(seed uint64) {
+	key := sha256.Sum256(binary.LittleEndian.AppendUint64([]byte("webmajiang:"), seed))
+
+	seedMu.Lock()
+	defer seedMu.Unlock()
+	seedKey = &key
+	seedCount = 0
+}
 
-	if _, err := rand.Read(key[:]); err != nil {
-		return nil, fmt.Errorf("failed to generate ChaCha20 key: %w", err)
+// seededKeyNonce 已設定固定種子時回傳下一組 key + nonce
+func seededKeyNonce() (key [32]byte, nonce [12]byte, ok bool) {
+	seedMu.Lock()
+	defer seedMu.Unlock()
+	if seedKey == nil {
+		return key, nonce, false
 	}
-	if _, err := rand.Read(nonce[:]); err != nil {
-		return nil, fmt.Errorf("failed to generate ChaCha20 nonce: %w", err)
+	seedCount++
+	binary.LittleEndian.PutUint64(nonce[:], seedCount)
+	return *seedKey, nonce, true
+}
+
+// newChaCha20Rand 建立 ChaCha20 隨機數產生器 (crypto/rand 做種子，模擬時改用固定種子)
+func newChaCha20Rand() (*chacha20Rand, error) {
+	key, nonce, seeded := seededKeyNonce()
+
+	// 使用 crypto/rand 產生 32-byte key + 12-byte nonce
+	if !seeded {
+		if _, err := rand.Read(key[:]); err != nil {
+			return nil, fmt.Errorf("failed to generate ChaCha20 key: %w", err)
+		}
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return nil, fmt.Errorf("failed to generate ChaCha20 nonce: %w", err)
+		}
 	}
 
 	cipher, err := chacha20.NewUnauthenticatedCipher(key[:], nonce[:])
@@ -148,12 +122,12 @@ func DeckRedisKey(gameID string) string {
 	return fmt.Sprintf("game:%s:deck", gameID)
 }
 
-// InitDeckToRedis 根據遊戲類型生成牌堆 → ChaCha20 洗牌 → LPUSH 到 Redis list
-// GameType13: 136 張 (不含花牌)
-// GameType16: 144 張 (含花牌)
-func InitDeckToRedis(ctx context.Context, gameID string, gameType models.GameType) error {
+// InitDeckToRedis 生成牌堆 → ChaCha20 洗牌 → LPUSH 到 Redis list
+// withFlowers 為 false: 136 張 (不含花牌)
+// withFlowers 為 true: 144 張 (含花牌)
+func InitDeckToRedis(ctx context.Context, gameID string, withFlowers bool) error {
 	// 1. 生成牌堆
-	deck := NewDeck(gameType)
+	deck := NewDeck(withFlowers)
 
 	// 2. 使用 crypto/rand + ChaCha20 洗牌
 	if err := ShuffleChacha20(deck); err != nil {
@@ -226,6 +200,22 @@ func PlayerFlowersKey(gameID string, playerID int) string {
 	return fmt.Sprintf("game:%s:player%d:flowers", gameID, playerID)
 }
 
+// PlayerDiscardsKey 玩家河牌 (打出且未被吃碰槓的牌) 在 Redis 中的 key 格式
+func PlayerDiscardsKey(gameID string, playerID int) string {
+	return fmt.Sprintf("game:%s:player%d:discards", gameID, playerID)
+}
+
+// ClearRoundTiles 清除上一局留下的副露、花牌與河牌
+func ClearRoundTiles(ctx context.Context, gameID string) error {
+	for p := 1; p <= 4; p++ {
+		keys := []string{PlayerMeldsKey(gameID, p), PlayerFlowersKey(gameID, p), PlayerDiscardsKey(gameID, p)}
+		if err := service.RedisClient.Del(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("failed to clear player%d round tiles: %w", p, err)
+		}
+	}
+	return nil
+}
+
 // DealTiles 發牌：從 Redis 牌堆 RPOP，按麻將規則輪流發給 4 位玩家
 // 發牌順序：
 //  1. 輪流摸 4 張 × 3 輪 = 每人 12 張
@@ -357,44 +347,146 @@ func GetPlayerHand(ctx context.Context, gameID string, playerID int) ([]models.T
 	return tiles, nil
 }
 
-// RemoveTileFromPlayerHand 從玩家手牌中移除特定的一張牌
-func RemoveTileFromPlayerHand(ctx context.Context, gameID string, playerID int, targetTile models.Tile) error {
+// GetPlayerFlowers 取得玩家已補出的花牌
+func GetPlayerFlowers(ctx context.Context, gameID string, playerID int) ([]models.Tile, error) {
+	tileJSONs, err := service.RedisClient.LRange(ctx, PlayerFlowersKey(gameID, playerID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player%d flowers: %w", playerID, err)
+	}
+
+	tiles := make([]models.Tile, 0, len(tileJSONs))
+	for _, tj := range tileJSONs {
+		var tile models.Tile
+		if err := json.Unmarshal([]byte(tj), &tile); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal flower: %w", err)
+		}
+		tiles = append(tiles, tile)
+	}
+
+	return tiles, nil
+}
+
+// GetPlayerDiscards 取得玩家的河牌
+func GetPlayerDiscards(ctx context.Context, gameID string, playerID int) ([]models.Tile, error) {
+	tileJSONs, err := service.RedisClient.LRange(ctx, PlayerDiscardsKey(gameID, playerID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player%d discards: %w", playerID, err)
+	}
+
+	tiles := make([]models.Tile, 0, len(tileJSONs))
+	for _, tj := range tileJSONs {
+		var tile models.Tile
+		if err := json.Unmarshal([]byte(tj), &tile); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal discard: %w", err)
+		}
+		tiles = append(tiles, tile)
+	}
+
+	return tiles, nil
+}
+
+// GetDeckTiles 取得牌堆中剩餘的所有牌 (稽核用)
+func GetDeckTiles(ctx context.Context, gameID string) ([]models.Tile, error) {
+	tileJSONs, err := service.RedisClient.LRange(ctx, DeckRedisKey(gameID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deck: %w", err)
+	}
+
+	tiles := make([]models.Tile, 0, len(tileJSONs))
+	for _, tj := range tileJSONs {
+		var tile models.Tile
+		if err := json.Unmarshal([]byte(tj), &tile); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal deck tile: %w", err)
+		}
+		tiles = append(tiles, tile)
+	}
+
+	return tiles, nil
+}
+
+// GetPlayerMelds 取得玩家的副露 (吃/碰/槓)
+func GetPlayerMelds(ctx context.Context, gameID string, playerID int) ([]models.Meld, error) {
+	meldJSONs, err := service.RedisClient.LRange(ctx, PlayerMeldsKey(gameID, playerID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player%d melds: %w", playerID, err)
+	}
+
+	melds := make([]models.Meld, 0, len(meldJSONs))
+	for _, mj := range meldJSONs {
+		var meld models.Meld
+		if err := json.Unmarshal([]byte(mj), &meld); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal meld: %w", err)
+		}
+		melds = append(melds, meld)
+	}
+
+	return melds, nil
+}
+
+// GetVisibleTiles 取得座位 viewer 看得到的已現身牌：四家河牌與副露 (他家的暗槓看不到)
+func GetVisibleTiles(ctx context.Context, gameID string, viewer int) ([]models.Tile, error) {
+	var visible []models.Tile
+	for p := 1; p <= 4; p++ {
+		discards, err := GetPlayerDiscards(ctx, gameID, p)
+		if err != nil {
+			return nil, err
+		}
+		visible = append(visible, discards...)
+
+		melds, err := GetPlayerMelds(ctx, gameID, p)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range melds {
+			if m.Type == models.MeldTypeHiddenKong && p != viewer {
+				continue
+			}
+			visible = append(visible, m.Tiles...)
+		}
+	}
+	return visible, nil
+}
+
+// RemoveTileFromPlayerHand 從玩家手牌中移除特定的一張牌 (依 ID 比對)，並回傳手牌中完整的那張牌
+func RemoveTileFromPlayerHand(ctx context.Context, gameID string, playerID int, targetTile models.Tile) (models.Tile, error) {
 	rdb := service.RedisClient
 	playerKey := PlayerHandKey(gameID, playerID)
 
 	// 1. 讀取所有手牌
 	tileJSONs, err := rdb.LRange(ctx, playerKey, 0, -1).Result()
 	if err != nil {
-		return fmt.Errorf("failed to read player%d hand: %w", playerID, err)
+		return models.Tile{}, fmt.Errorf("failed to read player%d hand: %w", playerID, err)
 	}
 
 	// 2. 找到並移除指定的牌 (只移除一張)
 	removed := false
+	var removedTile models.Tile
 	remainingTiles := make([]models.Tile, 0, len(tileJSONs)-1)
 
 	for _, tj := range tileJSONs {
 		var tile models.Tile
 		if err := json.Unmarshal([]byte(tj), &tile); err != nil {
-			return fmt.Errorf("failed to unmarshal tile: %w", err)
+			return models.Tile{}, fmt.Errorf("failed to unmarshal tile: %w", err)
 		}
 
 		// 比對 Type 和 Value 即可，因為 ID 是唯一對應特定的實體牌
 		// 我們比對 ID 也行，確保移除的那張就是他在畫面上點擊的
 		if !removed && tile.ID == targetTile.ID {
 			removed = true
+			removedTile = tile
 			continue // 跳過這張牌，不加入 remainingTiles
 		}
 		remainingTiles = append(remainingTiles, tile)
 	}
 
 	if !removed {
-		return fmt.Errorf("tile not found in player hand")
+		return models.Tile{}, fmt.Errorf("tile not found in player hand")
 	}
 
 	// 3. 重新寫回 Redis (已移除指定的牌)
 	// 先清除原有 list
 	if err := rdb.Del(ctx, playerKey).Err(); err != nil {
-		return fmt.Errorf("failed to clear player%d hand for removal: %w", playerID, err)
+		return models.Tile{}, fmt.Errorf("failed to clear player%d hand for removal: %w", playerID, err)
 	}
 
 	// 寫入剩餘的牌
@@ -403,17 +495,17 @@ func RemoveTileFromPlayerHand(ctx context.Context, gameID string, playerID int,
 		for i, tile := range remainingTiles {
 			data, err := json.Marshal(tile)
 			if err != nil {
-				return fmt.Errorf("failed to marshal remaining tile: %w", err)
+				return models.Tile{}, fmt.Errorf("failed to marshal remaining tile: %w", err)
 			}
 			sortedJSONs[i] = string(data)
 		}
 
 		if err := rdb.RPush(ctx, playerKey, sortedJSONs...).Err(); err != nil {
-			return fmt.Errorf("failed to write remaining hand: %w", err)
+			return models.Tile{}, fmt.Errorf("failed to write remaining hand: %w", err)
 		}
 	}
 
-	return nil
+	return removedTile, nil
 }
 
 // RemoveTilesFromPlayerHand 從玩家手牌中移除指定數量、特定花色與數字的牌，並回傳被移除的牌陣列 (用於吃碰槓)
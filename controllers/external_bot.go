@@ -0,0 +1,446 @@
+package controllers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/maoxiaoyue/hypgo/pkg/websocket"
+	"google.golang.org/protobuf/proto"
+
+	"webmajiang/models"
+	"webmajiang/models/pb"
+	"webmajiang/service"
+	"webmajiang/utils"
+)
+
+// 外部 AI：第三方程式透過 WebSocket (bot token 入座) 或本機子程序接手座位
+// 每次決策送出 BotRequestData，須在期限內回覆 BotResponseData；逾時或動作不合法時由內建 AI 代打
+
+// ErrBotTimeout 外部 AI 未在期限內回應
+var ErrBotTimeout = errors.New("bot did not answer before the deadline")
+
+// BotConfig 外部 AI 設定 (config.yaml 的 bots 區塊)
+type BotConfig struct {
+	TimeoutMillis int                `yaml:"timeout_ms"` // 房間未設宣告/出牌時限時的回應期限 (毫秒)，預設 5000
+	Processes     []BotProcessConfig `yaml:"processes"`  // 可供房間使用的本機子程序 AI
+}
+
+// BotProcessConfig 本機子程序 AI
+type BotProcessConfig struct {
+	Name    string   `yaml:"name"`    // 房間指定時使用的名稱
+	Command []string `yaml:"command"` // 執行指令與參數
+	Codec   string   `yaml:"codec"`   // "json" (每行一筆 JSON，預設) 或 "proto" (varint 長度前綴的 protobuf)
+}
+
+var botConfig = BotConfig{TimeoutMillis: 5000}
+
+// InitBots 載入外部 AI 設定
+func InitBots(cfg BotConfig) {
+	if cfg.TimeoutMillis <= 0 {
+		cfg.TimeoutMillis = 5000
+	}
+	botConfig = cfg
+}
+
+// lookupBotProcess 依名稱取得設定中的子程序 AI
+func lookupBotProcess(name string) (BotProcessConfig, bool) {
+	for _, p := range botConfig.Processes {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return BotProcessConfig{}, false
+}
+
+// botTransport 外部 AI 的連線方式
+type botTransport interface {
+	send(req *pb.BotRequestData) error
+	close() error
+}
+
+// externalBot 以外部程式決策的 AI，實作 models.Bot
+type externalBot struct {
+	gameID    string
+	seat      int
+	label     string // 連線方式，記錄用 (websocket 或 process:<name>)
+	transport botTransport
+	fallback  models.Bot // 逾時或動作不合法時代打的內建 AI
+
+	claimTimeout time.Duration // 回應他家出牌的期限
+	turnTimeout  time.Duration // 自己回合的期限
+
+	mu        sync.Mutex // 同一座位一次只送出一個請求
+	seq       int32
+	responses chan *pb.BotResponseData
+}
+
+// newExternalBot 依房間時限建立外部 AI (未設時限時採 BotConfig.TimeoutMillis)
+func newExternalBot(state *models.GameState, seat int, label string, fallback models.Bot) *externalBot {
+	timers := state.Rules.Timers.Normalize()
+	deadline := func(d time.Duration) time.Duration {
+		if d <= 0 {
+			return time.Duration(botConfig.TimeoutMillis) * time.Millisecond
+		}
+		return d
+	}
+	return &externalBot{
+		gameID:       state.GameID,
+		seat:         seat,
+		label:        label,
+		fallback:     fallback,
+		claimTimeout: deadline(timers.ActionTimeout()),
+		turnTimeout:  deadline(timers.TurnTimeout()),
+		responses:    make(chan *pb.BotResponseData, 8),
+	}
+}
+
+// deliver 收到外部 AI 的回應 (由連線的讀取端呼叫)
+func (b *externalBot) deliver(res *pb.BotResponseData) {
+	select {
+	case b.responses <- res:
+	default:
+		utils.Error("[Bot] game %s seat %d: response queue full, drop seq %d", b.gameID, b.seat, res.Seq)
+	}
+}
+
+// Close 關閉連線
+func (b *externalBot) Close() error {
+	if b.transport == nil {
+		return nil
+	}
+	return b.transport.close()
+}
+
+// exchange 送出請求並等待相同序號的回應
+func (b *externalBot) exchange(req *pb.BotRequestData, timeout time.Duration) (models.BotReply, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.transport == nil {
+		return models.BotReply{}, fmt.Errorf("bot is not connected")
+	}
+
+	b.seq++
+	req.Seq = b.seq
+	req.DeadlineMs = int32(timeout / time.Millisecond)
+
+	// 丟棄上一個請求逾時後才到的回應
+	for len(b.responses) > 0 {
+		<-b.responses
+	}
+	if err := b.transport.send(req); err != nil {
+		return models.BotReply{}, fmt.Errorf("failed to send bot request: %w", err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case res := <-b.responses:
+			if res.Seq != req.Seq {
+				continue
+			}
+			reply := models.BotReply{Action: res.Action}
+			for _, id := range res.TileIds {
+				reply.TileIDs = append(reply.TileIDs, int(id))
+			}
+			return reply, nil
+		case <-timer.C:
+			return models.BotReply{}, ErrBotTimeout
+		}
+	}
+}
+
+// useFallback 記錄外部 AI 失敗的原因，改由內建 AI 代打
+func (b *externalBot) useFallback(kind string, err error) {
+	utils.Error("[Bot] game %s seat %d (%s) %s: %v，由內建 AI (%s) 代打", b.gameID, b.seat, b.label, kind, err, b.fallback.Strategy())
+}
+
+func (b *externalBot) Strategy() models.AIStrategy { return models.AIStrategyExternal }
+
+func (b *externalBot) RespondToDiscard(view models.ClaimView) models.ClaimDecision {
+	req := buildBotRequest("claim", b.gameID, view.DangerView)
+	req.TileId = int32(view.Discard.ID)
+	req.CanChow = view.CanChow
+	req.CanHu = view.CanHu
+	req.RobbingKong = view.RobbingKong
+
+	reply, err := b.exchange(req, b.claimTimeout)
+	if err == nil {
+		var decision models.ClaimDecision
+		if decision, err = reply.Claim(view); err == nil {
+			return decision
+		}
+	}
+	b.useFallback("claim", err)
+	return b.fallback.RespondToDiscard(view)
+}
+
+func (b *externalBot) ChooseDiscard(view models.DangerView) models.Tile {
+	reply, err := b.exchange(buildBotRequest("discard", b.gameID, view), b.turnTimeout)
+	if err == nil {
+		var tile models.Tile
+		if tile, err = reply.Discard(view); err == nil {
+			return tile
+		}
+	}
+	b.useFallback("discard", err)
+	return b.fallback.ChooseDiscard(view)
+}
+
+func (b *externalBot) ChooseSelfAction(view models.SelfView) models.SelfAction {
+	req := buildBotRequest("self", b.gameID, view.DangerView)
+	if view.Drawn != nil {
+		req.TileId = int32(view.Drawn.ID)
+	}
+	req.CanHu = view.CanHu
+
+	reply, err := b.exchange(req, b.turnTimeout)
+	if err == nil {
+		var action models.SelfAction
+		if action, err = reply.Self(view); err == nil {
+			return action
+		}
+	}
+	b.useFallback("self", err)
+	return b.fallback.ChooseSelfAction(view)
+}
+
+// buildBotRequest 將座位看得到的資訊轉為 BotRequestData
+func buildBotRequest(kind string, gameID string, view models.DangerView) *pb.BotRequestData {
+	req := &pb.BotRequestData{
+		Kind:           kind,
+		RoomId:         gameID,
+		Seat:           int32(view.Seat),
+		HandIds:        tileIDs(view.Hand),
+		Melds:          botMelds(view.Melds),
+		VisibleIds:     tileIDs(view.Visible),
+		SeatWind:       int32(view.SeatWind),
+		PrevailingWind: int32(view.PrevailingWind),
+	}
+	for _, opp := range view.Opponents {
+		req.Opponents = append(req.Opponents, &pb.BotOpponentData{
+			Seat:       int32(opp.Seat),
+			DiscardIds: tileIDs(opp.Discards),
+			Melds:      botMelds(opp.Melds),
+		})
+	}
+	return req
+}
+
+// 幫助函數：副露轉為 BotMeldData
+func botMelds(melds []models.Meld) []*pb.BotMeldData {
+	data := make([]*pb.BotMeldData, 0, len(melds))
+	for _, m := range melds {
+		data = append(data, &pb.BotMeldData{Type: int32(m.Type), TileIds: tileIDs(m.Tiles)})
+	}
+	return data
+}
+
+// =====================================
+// WebSocket 連線
+// =====================================
+
+// wsBotTransport 以 WebSocket 連入的外部 AI：請求為 bot_request，回應為 bot_response
+type wsBotTransport struct {
+	hub      *websocket.Hub
+	clientID string
+}
+
+func (t *wsBotTransport) send(req *pb.BotRequestData) error {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	outBytes, err := proto.Marshal(&pb.WSMessage{Action: "bot_request", Data: b})
+	if err != nil {
+		return err
+	}
+	return t.hub.SendToClient(t.clientID, outBytes)
+}
+
+func (t *wsBotTransport) close() error {
+	detachBotClient(t.clientID)
+	return nil
+}
+
+// WebSocket client ID → 外部 AI
+var (
+	botClientsMu sync.Mutex
+	botClients   = make(map[string]*externalBot)
+)
+
+// attachBotClient 記錄 client 對應的外部 AI，同一 client 只能接手一個座位
+func attachBotClient(clientID string, bot *externalBot) {
+	botClientsMu.Lock()
+	defer botClientsMu.Unlock()
+	botClients[clientID] = bot
+}
+
+// detachBotClient 取消 client 對應的外部 AI，回傳原本的 AI
+func detachBotClient(clientID string) *externalBot {
+	botClientsMu.Lock()
+	defer botClientsMu.Unlock()
+	bot := botClients[clientID]
+	delete(botClients, clientID)
+	return bot
+}
+
+// HandleBotDisconnect WebSocket 斷線時釋放外部 AI，座位改由內建 AI 接手 (可再以 token 重新入座)
+func HandleBotDisconnect(clientID string) {
+	if bot := detachBotClient(clientID); bot != nil {
+		utils.Info("[Bot] game %s seat %d disconnected, built-in AI takes over", bot.gameID, bot.seat)
+		unassignBot(bot.gameID, bot.seat, bot)
+	}
+}
+
+// BotTokenKey 外部 AI 入座用的 bot token 在 Redis 中的 key
+func BotTokenKey(gameID string, seat int) string {
+	return fmt.Sprintf("game:%s:player%d:bot_token", gameID, seat)
+}
+
+// botTokenTTL bot token 的有效期限
+const botTokenTTL = 24 * time.Hour
+
+// 外部 AI 的連線方式
+const (
+	BotTransportWebSocket = "websocket"
+	BotTransportProcess   = "process"
+)
+
+// AttachExternalBot 座位改由外部 AI 接手 (僅限等待玩家階段，已有帳號入座的座位不可)，接上前與斷線期間由房間設定的內建 AI 代打
+//   - websocket：回傳 bot token，外部 AI 連上 WebSocket 後以 bot_join 入座
+//   - process：啟動設定檔中名為 name 的子程序 AI
+func AttachExternalBot(ctx context.Context, gameID string, seat int, transport, name string) (string, error) {
+	defer lockGame(gameID)()
+
+	state, err := loadActiveGameState(ctx, gameID)
+	if err != nil {
+		return "", err
+	}
+	if state.Stage != models.StageWaitingPlayers {
+		return "", fmt.Errorf("action not allowed in current stage: %s", state.Stage)
+	}
+	player, ok := state.Players[seat]
+	if !ok {
+		return "", fmt.Errorf("invalid seat: %d", seat)
+	}
+	if player.UserID != 0 {
+		return "", ErrSeatTaken
+	}
+	player.IsBot = true
+	player.Strategy = models.AIStrategyExternal
+	state.Players[seat] = player
+
+	var token string
+	var bot *externalBot
+	switch transport {
+	case BotTransportWebSocket:
+		if token, err = utils.GenerateRandomToken(16); err != nil {
+			return "", fmt.Errorf("failed to generate bot token: %w", err)
+		}
+		if err := service.RedisClient.Set(ctx, BotTokenKey(gameID, seat), token, botTokenTTL).Err(); err != nil {
+			return "", fmt.Errorf("failed to save bot token: %w", err)
+		}
+
+	case BotTransportProcess:
+		cfg, ok := lookupBotProcess(name)
+		if !ok {
+			return "", fmt.Errorf("unknown bot process: %q", name)
+		}
+		bot = newExternalBot(state, seat, BotTransportProcess+":"+name, builtinBot(state, seat))
+		t, err := startBotProcess(cfg, bot.deliver)
+		if err != nil {
+			return "", err
+		}
+		bot.transport = t
+
+	default:
+		return "", fmt.Errorf("unknown bot transport: %q", transport)
+	}
+
+	if err := SaveGameState(ctx, state); err != nil {
+		if bot != nil {
+			bot.Close()
+		}
+		return "", err
+	}
+	if bot != nil {
+		AssignBot(gameID, seat, bot)
+	}
+
+	// 遊戲狀況紀錄中的玩家識別改為 bot
+	if status, err := LoadGameStatus(ctx, gameID); err == nil {
+		setStatusPlayer(status, seat, buildPlayerIdentifier(player))
+		if err := SaveGameStatus(ctx, gameID, status); err != nil {
+			utils.Error("[Bot] game %s: failed to update status: %v", gameID, err)
+		}
+	}
+	utils.Info("[Bot] game %s seat %d attached to external bot (%s)", gameID, seat, transport)
+	return token, nil
+}
+
+// handleBotJoin 外部 AI 以 bot token 入座，之後的決策請求以 bot_request 送到此連線
+func handleBotJoin(ctx context.Context, client *websocket.Client, action string, data []byte) {
+	var req pb.BotJoinReq
+	if err := proto.Unmarshal(data, &req); err != nil {
+		sendWSError(client, action, "invalid BotJoinReq data")
+		return
+	}
+	gameID := req.RoomId
+	if gameID == "" {
+		gameID = "default_room"
+	}
+	seat := int(req.Seat)
+
+	defer lockGame(gameID)()
+	token, err := service.RedisClient.Get(ctx, BotTokenKey(gameID, seat)).Result()
+	if err != nil || subtle.ConstantTimeCompare([]byte(token), []byte(req.Token)) != 1 {
+		sendWSError(client, action, "invalid bot token")
+		return
+	}
+	state, err := loadActiveGameState(ctx, gameID)
+	if err != nil {
+		sendWSError(client, action, err.Error())
+		return
+	}
+	if state.Players[seat].Strategy != models.AIStrategyExternal {
+		sendWSError(client, action, "seat is not reserved for an external bot")
+		return
+	}
+
+	bot := newExternalBot(state, seat, BotTransportWebSocket, builtinBot(state, seat))
+	bot.transport = &wsBotTransport{hub: client.Hub, clientID: client.ID}
+
+	// 同一連線只接手一個座位；同座位原本的連線被取代
+	if old := detachBotClient(client.ID); old != nil {
+		unassignBot(old.gameID, old.seat, old)
+	}
+	AssignBot(gameID, seat, bot)
+	attachBotClient(client.ID, bot)
+
+	utils.Info("[Bot] game %s seat %d joined via websocket client %s", gameID, seat, client.ID)
+	sendProtoResponse(client, action+"_res", &pb.PlayerActionRes{Success: true, Message: "AI 入座成功"})
+}
+
+// handleBotResponse 外部 AI 對 bot_request 的回應
+func handleBotResponse(client *websocket.Client, action string, data []byte) {
+	var res pb.BotResponseData
+	if err := proto.Unmarshal(data, &res); err != nil {
+		sendWSError(client, action, "invalid BotResponseData data")
+		return
+	}
+
+	botClientsMu.Lock()
+	bot := botClients[client.ID]
+	botClientsMu.Unlock()
+	if bot == nil {
+		sendWSError(client, action, "not seated as a bot")
+		return
+	}
+	bot.deliver(&res)
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"webmajiang/models"
+	"webmajiang/utils"
+)
+
+var (
+	// ErrFalseWin 宣告胡牌不成立 (未成胡牌型或未達起胡台數)
+	ErrFalseWin = errors.New("false win")
+	// ErrDeadHand 相公 (本局已詐胡) 不能再胡牌
+	ErrDeadHand = errors.New("dead hand cannot win")
+)
+
+// checkWinClaim 檢查宣告胡牌是否成立：非相公、符合胡牌型且達到起胡台數
+// 成立時回傳計台結果；不成立時回傳包裝 ErrFalseWin 或 ErrDeadHand 的錯誤
+func checkWinClaim(ctx context.Context, gameID string, state *models.GameState, playerID int, winningTile models.Tile, isSelfDrawn bool) (models.ScoreResult, error) {
+	if state.DeadHands[playerID] {
+		return models.ScoreResult{}, ErrDeadHand
+	}
+
+	scoreCtx, err := loadScoringContext(ctx, gameID, state, playerID, winningTile, isSelfDrawn)
+	if err != nil {
+		return models.ScoreResult{}, err
+	}
+
+	fullHand := append(append([]models.Tile{}, scoreCtx.ClosedHand...), winningTile)
+	if !state.IsWinningHand(fullHand) {
+		return models.ScoreResult{}, fmt.Errorf("%w: hand is not a winning hand", ErrFalseWin)
+	}
+
+	scoreResult := models.CalculateScore(scoreCtx)
+	if !state.Rules.WinRule.MeetsMinimum(scoreResult.TotalTai) {
+		return scoreResult, fmt.Errorf("%w: %d tai is below the minimum of %d", ErrFalseWin, scoreResult.TotalTai, state.Rules.WinRule.MinTai)
+	}
+	return scoreResult, nil
+}
+
+// applyFalseWin 依房間規則處罰詐胡：賠三家 (點數轉移計入本局) 或成為相公
+func applyFalseWin(state *models.GameState, offenderID int, reason error) {
+	if state.Stats == nil {
+		state.Stats = make(map[int]models.SeatStats)
+	}
+	models.RecordFalseWin(state.Stats, offenderID)
+
+	winRule := state.Rules.WinRule.Normalize()
+	if winRule.Penalty == models.FalseWinDeadHand {
+		if state.DeadHands == nil {
+			state.DeadHands = make(map[int]bool)
+		}
+		state.DeadHands[offenderID] = true
+		utils.Info("[FalseWin] Player %d declared a false win (%v), plays on as a dead hand", offenderID, reason)
+		return
+	}
+
+	rule := state.Rules.PointRule
+	if rule == (models.PointRule{}) {
+		rule = models.DefaultPointRule
+	}
+	if state.Scores == nil {
+		state.Scores = make(map[int]int)
+	}
+	transfers := models.SettleFalseWin(rule, winRule, offenderID)
+	models.ApplyTransfers(state.Scores, transfers)
+	state.RoundTransfers = append(state.RoundTransfers, transfers...)
+	utils.Info("[FalseWin] Player %d declared a false win (%v), pays all: %v, scores: %v", offenderID, reason, transfers, state.Scores)
+}
+
+// validateHuClaim 於宣告當下驗證 WAIT_ACTION 階段的胡牌宣告
+// 詐胡者依規則處罰並回傳包裝 ErrFalseWin 的錯誤；相公回傳 ErrDeadHand；兩者都應記為 pass
+func validateHuClaim(ctx context.Context, gameID string, state *models.GameState, playerID int) error {
+	if state.LastDiscardTile == nil {
+		return fmt.Errorf("no discard to claim")
+	}
+
+	_, err := checkWinClaim(ctx, gameID, state, playerID, *state.LastDiscardTile, false)
+	switch {
+	case errors.Is(err, ErrFalseWin):
+		applyFalseWin(state, playerID, err)
+	case errors.Is(err, ErrDeadHand):
+		utils.Info("[FalseWin] Player %d is a dead hand, hu declaration ignored", playerID)
+	}
+	return err
+}
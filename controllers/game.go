@@ -3,9 +3,13 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+
 	"webmajiang/models"
 	"webmajiang/service"
 	"webmajiang/utils"
@@ -30,7 +34,8 @@ type GameStatus struct {
 	Player4  string `json:"player4"`  // 玩家4識別
 	Dealer   string `json:"dealer"`   // 莊家 (例: "player2")
 	Start    int64  `json:"start"`    // 遊戲開始時間戳
-	Progress string `json:"progress"` // 目前進度 (例: "2-3" 表示南風西局)
+	Progress string `json:"progress"` // 目前進度 (例: "2-3" 表示南風西局，固定局數制為 "3/8")
+	Finished bool   `json:"finished"` // 一將是否已結束 (結束後唯讀)
 }
 
 // SaveGameStatus 儲存遊戲狀況紀錄到 Redis
@@ -64,13 +69,14 @@ func LoadGameStatus(ctx context.Context, gameID string) (*GameStatus, error) {
 	return &status, nil
 }
 
-// UpdateGameStatusProgress 更新遊戲進度 (局號)
-func UpdateGameStatusProgress(ctx context.Context, gameID string, round models.GameRound) error {
+// UpdateGameStatusProgress 更新遊戲進度 (局號或局數，依遊戲長度設定) 與莊家
+func UpdateGameStatusProgress(ctx context.Context, gameID string, state *models.GameState) error {
 	status, err := LoadGameStatus(ctx, gameID)
 	if err != nil {
 		return err
 	}
-	status.Progress = round.RoundCode()
+	status.Progress = state.Progress()
+	status.Dealer = fmt.Sprintf("player%d", state.DealerPlayerID)
 	return SaveGameStatus(ctx, gameID, status)
 }
 
@@ -83,6 +89,20 @@ func buildPlayerIdentifier(player models.Player) string {
 	return fmt.Sprintf("user:%d", player.ID)
 }
 
+// setStatusPlayer 更新遊戲狀況紀錄中座位 (1-4) 的玩家識別
+func setStatusPlayer(status *GameStatus, seat int, id string) {
+	switch seat {
+	case 1:
+		status.Player1 = id
+	case 2:
+		status.Player2 = id
+	case 3:
+		status.Player3 = id
+	case 4:
+		status.Player4 = id
+	}
+}
+
 // RollDice 使用 ChaCha20 擲兩顆骰子
 func RollDice() (models.DiceResult, error) {
 	rng, err := newChaCha20Rand()
@@ -100,6 +120,14 @@ func RollDice() (models.DiceResult, error) {
 	}, nil
 }
 
+// rollRoomDice 依房間規則擲兩顆或三顆骰子
+func rollRoomDice(rules models.RoomRules) (models.DiceResult, error) {
+	if rules.Dice == 3 {
+		return RollDice3()
+	}
+	return RollDice()
+}
+
 // RollDice3 使用 ChaCha20 擲三顆骰子 (16張用)
 func RollDice3() (models.DiceResult, error) {
 	rng, err := newChaCha20Rand()
@@ -167,30 +195,33 @@ func LoadGameState(ctx context.Context, gameID string) (*models.GameState, error
 
 // StartNewGame 開始新的一將（第一局）
 // 初始化遊戲狀態，並進入 StageWaitingPlayers 階段
-// gameType: 13 為 13 張玩法 (不含花牌)，16 為 16 張玩法 (含花牌)
-func StartNewGame(ctx context.Context, gameID string, gameType models.GameType) (*models.GameState, error) {
-	// 驗證遊戲類型
-	if gameType != models.GameType13 && gameType != models.GameType16 {
-		gameType = models.GameType16 // 預設 16 張
+// rules: 房間規則 (張數、花牌、底台、起胡、遊戲長度、時限、一砲多響、台數表等)，未設定的欄位採遊戲類型預設
+func StartNewGame(ctx context.Context, gameID string, rules models.RoomRules) (*models.GameState, error) {
+	rules = rules.Normalize()
+	if err := rules.Validate(); err != nil {
+		return nil, err
 	}
 
 	state := &models.GameState{
 		GameID:          gameID,
-		GameType:        gameType,
+		Rules:           rules,
 		Stage:           models.StageWaitingPlayers,
 		CurrentPlayerID: 0,
 		Round:           models.NewFirstRound(), // 東風東 (1-1)
 		DealerPlayerID:  0,
 		IsStarted:       true,
+		StartedAt:       time.Now().UnixNano(),
 		IsFinished:      false,
 		Players:         make(map[int]models.Player),
+		Scores:          map[int]int{1: 0, 2: 0, 3: 0, 4: 0},
+		Stats:           make(map[int]models.SeatStats),
 	}
 
 	// 暫時設定為「Seat 1,2 為真人玩家，Seat 3,4 為 AI 玩家」
 	state.Players[1] = models.Player{ID: 1, Name: "真人玩家1", IsBot: false, Hand: []models.Tile{}}
 	state.Players[2] = models.Player{ID: 2, Name: "真人玩家2", IsBot: false, Hand: []models.Tile{}}
-	state.Players[3] = models.Player{ID: 3, Name: "AI 電腦1", IsBot: true, Hand: []models.Tile{}}
-	state.Players[4] = models.Player{ID: 4, Name: "AI 電腦2", IsBot: true, Hand: []models.Tile{}}
+	state.Players[3] = models.Player{ID: 3, Name: "AI 電腦1", IsBot: true, Strategy: rules.BotStrategy(3), Hand: []models.Tile{}}
+	state.Players[4] = models.Player{ID: 4, Name: "AI 電腦2", IsBot: true, Strategy: rules.BotStrategy(4), Hand: []models.Tile{}}
 
 	if err := SaveGameState(ctx, state); err != nil {
 		return nil, err
@@ -198,14 +229,14 @@ func StartNewGame(ctx context.Context, gameID string, gameType models.GameType)
 
 	// 記錄遊戲狀況到 mjgame:<gameid>:status
 	status := &GameStatus{
-		Type:     int(gameType),
+		Type:     int(rules.GameType),
 		Player1:  buildPlayerIdentifier(state.Players[1]),
 		Player2:  buildPlayerIdentifier(state.Players[2]),
 		Player3:  buildPlayerIdentifier(state.Players[3]),
 		Player4:  buildPlayerIdentifier(state.Players[4]),
 		Dealer:   "", // 尚未決定莊家
 		Start:    time.Now().Unix(),
-		Progress: state.Round.RoundCode(), // "1-1" 東風東
+		Progress: state.Progress(), // "1-1" 東風東
 	}
 	if err := SaveGameStatus(ctx, gameID, status); err != nil {
 		return nil, fmt.Errorf("failed to save game status: %w", err)
@@ -216,7 +247,7 @@ func StartNewGame(ctx context.Context, gameID string, gameType models.GameType)
 
 // RollPositions 決定座位 (擲骰子)
 func RollPositions(ctx context.Context, gameID string) (*models.GameState, error) {
-	state, err := LoadGameState(ctx, gameID)
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
@@ -224,12 +255,7 @@ func RollPositions(ctx context.Context, gameID string) (*models.GameState, error
 		return nil, fmt.Errorf("action not allowed in current stage: %s", state.Stage)
 	}
 
-	var dice models.DiceResult
-	if state.GameType == models.GameType16 {
-		dice, err = RollDice3()
-	} else {
-		dice, err = RollDice()
-	}
+	dice, err := rollRoomDice(state.Rules)
 	if err != nil {
 		return nil, err
 	}
@@ -244,7 +270,7 @@ func RollPositions(ctx context.Context, gameID string) (*models.GameState, error
 
 // RollDealer 決定第一局莊家
 func RollDealer(ctx context.Context, gameID string) (*models.GameState, error) {
-	state, err := LoadGameState(ctx, gameID)
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
@@ -252,12 +278,7 @@ func RollDealer(ctx context.Context, gameID string) (*models.GameState, error) {
 		return nil, fmt.Errorf("action not allowed in current stage: %s", state.Stage)
 	}
 
-	var dice models.DiceResult
-	if state.GameType == models.GameType16 {
-		dice, err = RollDice3()
-	} else {
-		dice, err = RollDice()
-	}
+	dice, err := rollRoomDice(state.Rules)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +303,7 @@ func RollDealer(ctx context.Context, gameID string) (*models.GameState, error) {
 // 13張玩法: 每人13張，莊家14張 (136張牌，不含花牌)
 // 16張玩法: 每人16張，莊家17張 (144張牌，含花牌)
 func DealTilesAction(ctx context.Context, gameID string) (*models.GameState, error) {
-	state, err := LoadGameState(ctx, gameID)
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
@@ -290,15 +311,18 @@ func DealTilesAction(ctx context.Context, gameID string) (*models.GameState, err
 		return nil, fmt.Errorf("action not allowed in current stage: %s", state.Stage)
 	}
 
-	if err := InitDeckToRedis(ctx, gameID, state.GameType); err != nil {
+	if err := ClearRoundTiles(ctx, gameID); err != nil {
+		return nil, err
+	}
+	if err := InitDeckToRedis(ctx, gameID, state.Rules.Flowers); err != nil {
 		return nil, fmt.Errorf("init deck failed: %w", err)
 	}
 
-	if err := DealTilesFromSeat(ctx, gameID, state.DealerPlayerID, state.GameType); err != nil {
+	if err := DealTilesFromSeat(ctx, gameID, state.DealerPlayerID, state.Rules.GameType); err != nil {
 		return nil, fmt.Errorf("deal tiles failed: %w", err)
 	}
 
-	if state.GameType == models.GameType16 {
+	if state.Rules.Flowers {
 		state.Stage = models.StageReplaceFlower
 		if err := SaveGameState(ctx, state); err != nil {
 			return nil, err
@@ -306,12 +330,26 @@ func DealTilesAction(ctx context.Context, gameID string) (*models.GameState, err
 		if err := ExecuteAutoFlowerReplacement(ctx, gameID); err != nil {
 			return nil, fmt.Errorf("auto flower replacement failed: %w", err)
 		}
+
+		// 補花後若成立八仙過海/七搶一，本局直接結束
+		if state, err = LoadGameState(ctx, gameID); err != nil {
+			return nil, err
+		}
+		if state.Stage == models.StageRoundOver {
+			return state, nil
+		}
 	}
 
 	// 莊家已拿到多一張開門牌，接下來換莊家打牌
 	state.Stage = models.StagePlayerDiscard
 	state.CurrentPlayerID = state.DealerPlayerID
 	state.ActionDeclarations = make(map[int]string)
+	state.ClaimTiles = nil
+	state.DiscardCount = 0
+	state.FirstDiscards = nil
+	state.IsInterrupted = false
+	state.IsAfterKong = false
+	state.IsRobbingKong = false
 
 	if err := SaveGameState(ctx, state); err != nil {
 		return nil, err
@@ -321,7 +359,7 @@ func DealTilesAction(ctx context.Context, gameID string) (*models.GameState, err
 
 // ExecuteAutoFlowerReplacement 執行開局自動補花
 func ExecuteAutoFlowerReplacement(ctx context.Context, gameID string) error {
-	state, err := LoadGameState(ctx, gameID)
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return err
 	}
@@ -355,7 +393,7 @@ func ExecuteAutoFlowerReplacement(ctx context.Context, gameID string) error {
 				if tile.Type == models.Flower {
 					flowerCount++
 					flowers = append(flowers, tj)
-					utils.Info("[Flower] player%d draws a flower: %v", p, tile)
+					utils.Info("[Flower] player%d draws a flower: %s", p, models.NotationOf(tile))
 				} else {
 					nonFlowers = append(nonFlowers, tj)
 				}
@@ -402,12 +440,79 @@ func ExecuteAutoFlowerReplacement(ctx context.Context, gameID string) error {
 		SortPlayerHand(ctx, gameID, p)
 	}
 
+	// 開局補花完畢，檢查八仙過海/七搶一
+	won, err := resolveFlowerWin(ctx, gameID, state)
+	if err != nil {
+		return err
+	}
+	if won {
+		return SaveGameState(ctx, state)
+	}
+
 	return nil
 }
 
+// resolveFlowerWin 檢查四家花牌是否成立八仙過海或七搶一，成立時直接結算並進入 ROUND_OVER
+// 七搶一時，第八張花由持有者移到贏家的花牌區，並由該持有者支付
+func resolveFlowerWin(ctx context.Context, gameID string, state *models.GameState) (bool, error) {
+	if !state.Rules.Flowers {
+		return false, nil
+	}
+
+	flowers := make(map[int][]models.Tile)
+	for seat := 1; seat <= 4; seat++ {
+		tiles, err := GetPlayerFlowers(ctx, gameID, seat)
+		if err != nil {
+			return false, err
+		}
+		flowers[seat] = tiles
+	}
+
+	winnerID, loserID, winType := models.CheckFlowerWin(flowers)
+	if winType == models.FlowerWinNone {
+		return false, nil
+	}
+
+	winningTile := flowers[winnerID][len(flowers[winnerID])-1]
+	if winType == models.FlowerWinSevenRobOne {
+		rdb := service.RedisClient
+		loserKey := PlayerFlowersKey(gameID, loserID)
+		robbedJSON, err := rdb.LIndex(ctx, loserKey, 0).Result()
+		if err != nil {
+			return false, fmt.Errorf("failed to read robbed flower: %w", err)
+		}
+		if err := rdb.LRem(ctx, loserKey, 1, robbedJSON).Err(); err != nil {
+			return false, fmt.Errorf("failed to remove robbed flower: %w", err)
+		}
+		if err := rdb.RPush(ctx, PlayerFlowersKey(gameID, winnerID), robbedJSON).Err(); err != nil {
+			return false, fmt.Errorf("failed to move robbed flower: %w", err)
+		}
+		winningTile = flowers[loserID][0]
+	}
+
+	scoreCtx, err := loadScoringContext(ctx, gameID, state, winnerID, winningTile, loserID == 0)
+	if err != nil {
+		return false, err
+	}
+	scoreCtx.FlowerWin = winType
+	scoreResult := models.CalculateScore(scoreCtx)
+	utils.Info("[Flower] Player %d flower win (%d)! TotalTai: %d, Patterns: %v", winnerID, winType, scoreResult.TotalTai, scoreResult.Patterns)
+
+	state.Stage = models.StageRoundOver
+	state.CurrentPlayerID = winnerID
+	state.WinnerIDs = []int{winnerID}
+	state.ScoreResults = map[int]models.ScoreResult{winnerID: scoreResult}
+	settleRound(state, loserID)
+
+	if err := finishRound(ctx, gameID, state, loserID, &winningTile); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // DiscardTileAction 玩家出牌
 func DiscardTileAction(ctx context.Context, gameID string, playerID int, tile models.Tile) (*models.GameState, error) {
-	state, err := LoadGameState(ctx, gameID)
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
@@ -421,16 +526,33 @@ func DiscardTileAction(ctx context.Context, gameID string, playerID int, tile mo
 	}
 
 	// 1. 從玩家手牌中移除該牌
-	if err := RemoveTileFromPlayerHand(ctx, gameID, playerID, tile); err != nil {
+	// 客戶端可能只送出牌的 ID，以手牌中的完整牌面為準
+	tile, err = RemoveTileFromPlayerHand(ctx, gameID, playerID, tile)
+	if err != nil {
 		return nil, fmt.Errorf("failed to discard tile: %w", err)
 	}
 
+	// 記入河牌 (被吃碰槓時會再移出)
+	tileJSON, _ := json.Marshal(tile)
+	if err := service.RedisClient.RPush(ctx, PlayerDiscardsKey(gameID, playerID), string(tileJSON)).Err(); err != nil {
+		return nil, fmt.Errorf("failed to record discard: %w", err)
+	}
+
 	// 2. 更新狀態機
 	state.LastDiscardTile = &tile
 	state.LastDiscardPlayerID = playerID
 	state.ActionDeclarations = make(map[int]string) // 重置各家宣告
+	state.ClaimTiles = nil
 	state.Stage = models.StageWaitAction
 	state.IsAfterKong = false // 一旦出牌，取消「剛槓牌」狀態
+	state.IsRobbingKong = false
+	state.LastDrawTile = nil
+	state.DiscardCount++
+
+	// 四風連打檢查 (成立時本局直接流局)
+	if err := recordFirstDiscard(ctx, gameID, state, tile); err != nil {
+		return nil, err
+	}
 
 	if err := SaveGameState(ctx, state); err != nil {
 		return nil, err
@@ -438,9 +560,60 @@ func DiscardTileAction(ctx context.Context, gameID string, playerID int, tile mo
 	return state, nil
 }
 
+// drawReplacementTile 從嶺上 (LPop) 補一張牌，牌堆已空時回傳 nil
+func drawReplacementTile(ctx context.Context, gameID string) (*models.Tile, error) {
+	data, err := service.RedisClient.LPop(ctx, DeckRedisKey(gameID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to LPop replacement tile: %w", err)
+	}
+
+	var tile models.Tile
+	if err := json.Unmarshal([]byte(data), &tile); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replacement tile: %w", err)
+	}
+	return &tile, nil
+}
+
+// replaceDrawnFlowers 行牌中摸到花牌時放入花牌區並從嶺上補牌，直到摸到非花牌
+// 回傳 nil 代表本局已直接結束 (補花成立八仙過海/七搶一，或已無牌可補的荒莊流局)
+func replaceDrawnFlowers(ctx context.Context, gameID string, state *models.GameState, playerID int, drawn *models.Tile) (*models.Tile, error) {
+	for drawn.Type == models.Flower {
+		utils.Info("[Flower] player%d draws a flower: %s during normal play, auto-replacing", playerID, models.NotationOf(*drawn))
+
+		// 將花牌放入專屬 Redis List
+		dtJSON, _ := json.Marshal(drawn)
+		if err := service.RedisClient.RPush(ctx, PlayerFlowersKey(gameID, playerID), string(dtJSON)).Err(); err != nil {
+			return nil, fmt.Errorf("failed to save drawn flower: %w", err)
+		}
+
+		// 摸到花牌後檢查八仙過海/七搶一，成立時本局直接結束
+		won, err := resolveFlowerWin(ctx, gameID, state)
+		if err != nil {
+			return nil, err
+		}
+		if won {
+			return nil, nil
+		}
+
+		// 補進的牌在迴圈中繼續判定
+		if drawn, err = drawReplacementTile(ctx, gameID); err != nil {
+			return nil, err
+		}
+		if drawn == nil {
+			// 最後一張是花牌，已無牌可補：荒莊流局
+			return nil, abortRound(ctx, gameID, state, models.DrawExhaustive)
+		}
+	}
+	return drawn, nil
+}
+
 // DrawTileAction 玩家摸牌 (Stage: PLAYER_DRAW → PLAYER_DISCARD)
+// 回傳的牌為 nil 代表本局已直接結束 (荒莊流局，或補花成立八仙過海/七搶一)
 func DrawTileAction(ctx context.Context, gameID string, playerID int) (*models.GameState, *models.Tile, error) {
-	state, err := LoadGameState(ctx, gameID)
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -460,53 +633,28 @@ func DrawTileAction(ctx context.Context, gameID string, playerID int) (*models.G
 	}
 	if deckCount == 0 {
 		// 荒莊流局：牌堆已空
-		state.Stage = models.StageRoundOver
+		if err := abortRound(ctx, gameID, state, models.DrawExhaustive); err != nil {
+			return nil, nil, err
+		}
 		if err := SaveGameState(ctx, state); err != nil {
 			return nil, nil, err
 		}
 		return state, nil, nil
 	}
 
-	// 從牌堆摸一張牌 (循環直到摸到非花牌)
-	var drawnTile *models.Tile
-	rdb := service.RedisClient
-	for {
-		dt, err := DrawTile(ctx, gameID)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to draw tile: %w", err)
-		}
-
-		if dt.Type == models.Flower {
-			utils.Info("[Flower] player%d draws a flower: %v during normal play, auto-replacing", playerID, dt)
-
-			// 將花牌放入專屬 Redis List
-			flowersKey := PlayerFlowersKey(gameID, playerID)
-			dtJSON, _ := json.Marshal(dt)
-			if err := rdb.RPush(ctx, flowersKey, string(dtJSON)).Err(); err != nil {
-				return nil, nil, fmt.Errorf("failed to save drawn flower: %w", err)
-			}
-
-			// 從嶺上 (LPop) 補牌，並繼續檢查是否為花牌
-			deckKey := DeckRedisKey(gameID)
-			replacementJSON, err := rdb.LPop(ctx, deckKey).Result()
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to LPop for flower replacement: %w", err)
-			}
-
-			// 我們需要將拿到的字串反序列化，用作下一輪的 dt
-			var rt models.Tile
-			if err := json.Unmarshal([]byte(replacementJSON), &rt); err != nil {
-				return nil, nil, fmt.Errorf("failed to unmarshal replacement tile: %w", err)
-			}
-
-			// 為了使用 DrawTile 的統一格式（DrawTile 本身呼叫 RPop），
-			// 這裡直接將 rt 當作新摸到的牌在迴圈中繼續判定
-			drawnTile = &rt
-			continue
+	// 從牌堆摸一張牌 (摸到花牌時從嶺上補牌，直到摸到非花牌)
+	drawnTile, err := DrawTile(ctx, gameID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to draw tile: %w", err)
+	}
+	if drawnTile, err = replaceDrawnFlowers(ctx, gameID, state, playerID, drawnTile); err != nil {
+		return nil, nil, err
+	}
+	if drawnTile == nil {
+		if err := SaveGameState(ctx, state); err != nil {
+			return nil, nil, err
 		}
-
-		drawnTile = dt
-		break
+		return state, nil, nil
 	}
 
 	// 將最終摸到的(非花)牌加入玩家手牌 (透過 Redis LPUSH)
@@ -518,6 +666,7 @@ func DrawTileAction(ctx context.Context, gameID string, playerID int) (*models.G
 
 	// 更新狀態機：進入出牌階段
 	state.Stage = models.StagePlayerDiscard
+	state.LastDrawTile = drawnTile
 	// CurrentPlayerID 維持不變（摸牌者接著出牌）
 
 	if err := SaveGameState(ctx, state); err != nil {
@@ -526,9 +675,15 @@ func DrawTileAction(ctx context.Context, gameID string, playerID int) (*models.G
 	return state, drawnTile, nil
 }
 
-// PlayerDeclareAction 玩家宣告 (吃/碰/槓/胡/放棄)
+// PlayerDeclareAction 玩家宣告 (吃/碰/槓/胡/放棄)，吃牌時自動選擇第一種吃法
 func PlayerDeclareAction(ctx context.Context, gameID string, playerID int, action string) (*models.GameState, error) {
-	state, err := LoadGameState(ctx, gameID)
+	return PlayerDeclareClaim(ctx, gameID, playerID, action, nil)
+}
+
+// PlayerDeclareClaim 玩家宣告 (吃/碰/槓/胡/放棄)
+// tiles: 吃牌時要用的手牌 (可只給一張或省略，依序選第一種符合的吃法)
+func PlayerDeclareClaim(ctx context.Context, gameID string, playerID int, action string, tiles []models.Tile) (*models.GameState, error) {
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
@@ -541,24 +696,28 @@ func PlayerDeclareAction(ctx context.Context, gameID string, playerID int, actio
 		return nil, fmt.Errorf("cannot declare action on your own discard")
 	}
 
+	// 吃碰槓需手牌符合 (搶槓時只能胡或過)；胡牌於宣告當下驗證
+	claimErr := validateClaim(ctx, gameID, state, playerID, action, tiles)
+	if claimErr != nil {
+		if !errors.Is(claimErr, ErrFalseWin) && !errors.Is(claimErr, ErrDeadHand) {
+			return nil, claimErr
+		}
+		// 詐胡 (已依規則處罰) 或相公記為過，不影響其他人的宣告機會
+		action = "pass"
+	}
+
 	// 紀錄宣告
 	if state.ActionDeclarations == nil {
 		state.ActionDeclarations = make(map[int]string)
 	}
 	state.ActionDeclarations[playerID] = action
 
-	// 檢查是否所有其他玩家都宣告了，或者有人直接胡了 (胡最大)
-	allDeclared := len(state.ActionDeclarations) == 3
-	hasHu := false
-	for _, a := range state.ActionDeclarations {
-		if a == "hu" {
-			hasHu = true
-			break
-		}
+	// 三家都表態，或已有人胡且尚未表態的玩家都不可能胡這張牌時，進行結算
+	closed, err := claimWindowClosed(ctx, gameID, state)
+	if err != nil {
+		return nil, err
 	}
-
-	// 如果有人宣告 "hu" 或者所有人都表態了，進行結算
-	if hasHu || allDeclared {
+	if closed {
 		state, err = ResolveActions(ctx, gameID, state)
 		if err != nil {
 			return nil, err
@@ -568,7 +727,41 @@ func PlayerDeclareAction(ctx context.Context, gameID string, playerID int, actio
 	if err := SaveGameState(ctx, state); err != nil {
 		return nil, err
 	}
-	return state, nil
+	return state, claimErr
+}
+
+// claimWindowClosed 是否可以結算宣告：三家都已表態，或已有人胡牌且尚未表態的玩家都不可能胡這張牌
+// (胡牌優先於吃碰槓，尚未表態者只剩胡牌能改變結果)
+func claimWindowClosed(ctx context.Context, gameID string, state *models.GameState) (bool, error) {
+	hasHu := false
+	var pending []int
+	for pID := 1; pID <= 4; pID++ {
+		if pID == state.LastDiscardPlayerID {
+			continue
+		}
+		action, declared := state.ActionDeclarations[pID]
+		if !declared {
+			pending = append(pending, pID)
+		}
+		hasHu = hasHu || action == "hu"
+	}
+	if len(pending) == 0 {
+		return true, nil
+	}
+	if !hasHu {
+		return false, nil
+	}
+
+	for _, pID := range pending {
+		_, err := checkWinClaim(ctx, gameID, state, pID, *state.LastDiscardTile, false)
+		if err == nil {
+			return false, nil
+		}
+		if !errors.Is(err, ErrFalseWin) && !errors.Is(err, ErrDeadHand) {
+			return false, err
+		}
+	}
+	return true, nil
 }
 
 // ResolveActions 結算吃碰槓胡優先權
@@ -577,6 +770,7 @@ func ResolveActions(ctx context.Context, gameID string, state *models.GameState)
 	// 1. 胡牌順位優先：下家 > 對家 > 上家
 	// 2. 一炮三響：三人同時胡牌，各自獨立結算
 	// 3. 胡大於碰/槓：有人宣告胡，即使其他人宣告碰或槓，一律以胡牌優先。
+	// 4. 詐胡 (未成胡或未達起胡台數) 已於宣告時處罰並記為 pass
 
 	var huPlayers []int
 	var highestPriority int = -1
@@ -635,81 +829,60 @@ func ResolveActions(ctx context.Context, gameID string, state *models.GameState)
 			}
 		}
 
+		// 截胡：只由最近的一家胡牌
+		if state.Rules.MultiWin == models.MultiWinFirst {
+			huPlayers = huPlayers[:1]
+		}
+
+		// 搶槓：加槓的牌被胡走，副露恢復為碰
+		if state.IsRobbingKong {
+			if err := revertAddedKong(ctx, gameID, discarderID, *state.LastDiscardTile); err != nil {
+				return nil, err
+			}
+		}
+
 		// 記錄所有的贏家與計算台數
 		state.WinnerIDs = huPlayers
 		state.CurrentPlayerID = huPlayers[0] // 向下相容，把第一順位放在 CurrentPlayerID
 
-		rdb := service.RedisClient
 		var winningTile models.Tile
 		if discarderID == state.CurrentPlayerID {
-			// 自摸，拿手牌最後一張作為WinningTile (實務上需更精確判定，此處簡化為最後摸的一張)
-			// TODO: 自摸WinningTile的精確取得，我們這裡暫時給個預設，或需要從PlayerLastDraw取得
+			// 自摸，以最後摸進的牌作為 WinningTile
+			if state.LastDrawTile != nil {
+				winningTile = *(state.LastDrawTile)
+			}
 		} else {
 			if state.LastDiscardTile != nil {
 				winningTile = *(state.LastDiscardTile)
 			}
 		}
 
+		state.ScoreResults = make(map[int]models.ScoreResult)
 		for _, wid := range huPlayers {
-			// 取得手牌
-			hk := PlayerHandKey(gameID, wid)
-			handJSONs, _ := rdb.LRange(ctx, hk, 0, -1).Result()
-			var closedHand []models.Tile
-			for _, hj := range handJSONs {
-				var t models.Tile
-				if json.Unmarshal([]byte(hj), &t) == nil {
-					closedHand = append(closedHand, t)
-				}
-			}
-
-			// 取得副露
-			mk := PlayerMeldsKey(gameID, wid)
-			meldJSONs, _ := rdb.LRange(ctx, mk, 0, -1).Result()
-			var melds []models.Meld
-			for _, mj := range meldJSONs {
-				var m models.Meld
-				if json.Unmarshal([]byte(mj), &m) == nil {
-					melds = append(melds, m)
-				}
-			}
-
-			// 取得花牌
-			fk := PlayerFlowersKey(gameID, wid)
-			flowerJSONs, _ := rdb.LRange(ctx, fk, 0, -1).Result()
-			var flowers []models.Tile
-			for _, fj := range flowerJSONs {
-				var t models.Tile
-				if json.Unmarshal([]byte(fj), &t) == nil {
-					flowers = append(flowers, t)
-				}
-			}
-
-			// 建構計分上下文
-			scoreCtx := models.ScoringContext{
-				ClosedHand:  closedHand,
-				Melds:       melds,
-				WinningTile: winningTile,
-				IsSelfDrawn: discarderID == wid, // 如果出牌者是自己，代表是自摸
-				IsDealer:    state.DealerPlayerID == wid,
-				Flowers:     flowers,
+			// 建構計分上下文 (如果出牌者是自己，代表是自摸)
+			scoreCtx, err := loadScoringContext(ctx, gameID, state, wid, winningTile, discarderID == wid)
+			if err != nil {
+				return nil, err
 			}
 
 			scoreResult := models.CalculateScore(scoreCtx)
 
 			// 寫入結算結果
-			if state.ScoreResults == nil {
-				state.ScoreResults = make(map[int]models.ScoreResult)
-			}
 			state.ScoreResults[wid] = scoreResult
-
-			// 將結果存入玩家狀態 (需要擴充 GameState 結構，此處暫存至 log 或新欄位)
 			utils.Info("[Scoring] Player %d Hu! TotalTai: %d, Patterns: %v", wid, scoreResult.TotalTai, scoreResult.Patterns)
 		}
 
+		settleRound(state, discarderID)
+
+		if err := finishRound(ctx, gameID, state, discarderID, &winningTile); err != nil {
+			return nil, err
+		}
+
 		return state, nil
 	}
 
 	if winningAction == "kong" || winningAction == "pong" || winningAction == "chow" {
+		state.IsInterrupted = true // 第一巡被吃碰槓打斷
 		rdb := service.RedisClient
 		meldsKey := PlayerMeldsKey(gameID, winnerID)
 		targetTile := *(state.LastDiscardTile)
@@ -735,40 +908,56 @@ func ResolveActions(ctx context.Context, gameID string, state *models.GameState)
 				}
 			}
 		case "chow":
-			// TODO 吃牌邏輯：目前假設需要特定 API 來知道玩家要用哪兩張牌吃，暫時留空或簡單處理
-			utils.Info("Chow is not fully implemented yet for outdesk")
+			// 吃: 拿桌上一張，自己手上扣掉宣告時指定的兩張
+			var removed []models.Tile
+			for _, id := range state.ClaimTiles[winnerID] {
+				t, err := RemoveTileFromPlayerHand(ctx, gameID, winnerID, models.Tile{ID: id})
+				if err != nil {
+					return nil, fmt.Errorf("failed to chow: %w", err)
+				}
+				removed = append(removed, t)
+			}
+			if len(removed) == 2 {
+				tiles := append(removed, targetTile)
+				sort.Slice(tiles, func(i, j int) bool { return tiles[i].Value < tiles[j].Value })
+				meld = models.Meld{Type: models.MeldTypeChow, Tiles: tiles}
+			}
 		}
 
 		if len(meld.Tiles) > 0 {
 			meldJSON, _ := json.Marshal(meld)
 			rdb.RPush(ctx, meldsKey, string(meldJSON))
+			// 被吃碰槓的牌從出牌者的河牌移出
+			rdb.RPop(ctx, PlayerDiscardsKey(gameID, state.LastDiscardPlayerID))
 		}
 
-		// 若為槓牌，標記剛槓牌狀態 (供槓上開花判斷)，且需要從嶺上補一張牌
-		if winningAction == "kong" {
-			state.IsAfterKong = true
+		state.CurrentPlayerID = winnerID
+		state.LastDiscardTile = nil
+		state.ClaimTiles = nil
 
-			deckKey := DeckRedisKey(gameID)
-			replacementJSON, err := rdb.LPop(ctx, deckKey).Result()
-			if err != nil {
-				return nil, fmt.Errorf("failed to LPop for kong replacement: %w", err)
-			}
-			var rt models.Tile
-			if err := json.Unmarshal([]byte(replacementJSON), &rt); err == nil {
-				// 新牌加入手牌
-				playerKey := PlayerHandKey(gameID, winnerID)
-				rtJSON, _ := json.Marshal(rt)
-				rdb.LPush(ctx, playerKey, string(rtJSON))
-				utils.Info("Player%d Kong auto draw from tail: %v", winnerID, rt)
+		// 若為槓牌，從嶺上補一張牌 (供槓上開花判斷)
+		if winningAction == "kong" {
+			if aborted, err := drawKongReplacement(ctx, gameID, state, winnerID); err != nil || aborted {
+				return state, err
 			}
-
 		} else {
 			state.IsAfterKong = false
 		}
 
 		state.Stage = models.StagePlayerDiscard // 碰/吃/槓完要打一張牌
-		state.CurrentPlayerID = winnerID
+		return state, nil
+	}
+
+	// 搶槓無人胡：完成加槓並補牌，由開槓者繼續出牌
+	if state.IsRobbingKong {
+		kongPlayerID := state.LastDiscardPlayerID
+		state.IsRobbingKong = false
 		state.LastDiscardTile = nil
+		state.CurrentPlayerID = kongPlayerID
+		if aborted, err := drawKongReplacement(ctx, gameID, state, kongPlayerID); err != nil || aborted {
+			return state, err
+		}
+		state.Stage = models.StagePlayerDiscard
 		return state, nil
 	}
 
@@ -781,39 +970,207 @@ func ResolveActions(ctx context.Context, gameID string, state *models.GameState)
 	return state, nil
 }
 
+// DeclareSelfDrawnHu 玩家在自己的出牌階段宣告自摸 (Stage: PLAYER_DISCARD → ROUND_OVER)
+// 詐胡時依房間規則處罰並維持出牌階段，回傳處罰後的狀態與包裝 ErrFalseWin 的錯誤
+func DeclareSelfDrawnHu(ctx context.Context, gameID string, playerID int) (*models.GameState, error) {
+	state, err := loadActiveGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Stage != models.StagePlayerDiscard {
+		return nil, fmt.Errorf("action not allowed in current stage: %s", state.Stage)
+	}
+
+	if state.CurrentPlayerID != playerID {
+		return nil, fmt.Errorf("not your turn to declare self-drawn hu, current player is %d", state.CurrentPlayerID)
+	}
+
+	hand, err := GetPlayerHand(ctx, gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(hand) == 0 {
+		return nil, fmt.Errorf("player %d has no tiles", playerID)
+	}
+
+	// 胡牌張為最後摸進的牌；莊家開門即胡 (尚未摸牌) 時取手牌最後一張
+	winningTile := hand[len(hand)-1]
+	if state.LastDrawTile != nil {
+		winningTile = *(state.LastDrawTile)
+	}
+
+	scoreResult, err := checkWinClaim(ctx, gameID, state, playerID, winningTile, true)
+	if errors.Is(err, ErrFalseWin) {
+		applyFalseWin(state, playerID, err)
+		if saveErr := SaveGameState(ctx, state); saveErr != nil {
+			return nil, saveErr
+		}
+		return state, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	utils.Info("[Scoring] Player %d self-drawn Hu! TotalTai: %d, Patterns: %v", playerID, scoreResult.TotalTai, scoreResult.Patterns)
+
+	state.Stage = models.StageRoundOver
+	state.CurrentPlayerID = playerID
+	state.WinnerIDs = []int{playerID}
+	state.ScoreResults = map[int]models.ScoreResult{playerID: scoreResult}
+	settleRound(state, 0)
+
+	if err := finishRound(ctx, gameID, state, 0, &winningTile); err != nil {
+		return nil, err
+	}
+
+	if err := SaveGameState(ctx, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// loadScoringContext 從 Redis 讀取玩家的手牌、副露與花牌，組成計分上下文
+// 自摸時手牌中已包含胡牌張，會先從 ClosedHand 中移除一張
+func loadScoringContext(ctx context.Context, gameID string, state *models.GameState, playerID int, winningTile models.Tile, isSelfDrawn bool) (models.ScoringContext, error) {
+	// 取得手牌
+	closedHand, err := GetPlayerHand(ctx, gameID, playerID)
+	if err != nil {
+		return models.ScoringContext{}, err
+	}
+	if isSelfDrawn {
+		for i, t := range closedHand {
+			if t.ID == winningTile.ID && t.Type == winningTile.Type && t.Value == winningTile.Value {
+				closedHand = append(closedHand[:i], closedHand[i+1:]...)
+				break
+			}
+		}
+	}
+
+	// 取得副露
+	melds, err := GetPlayerMelds(ctx, gameID, playerID)
+	if err != nil {
+		return models.ScoringContext{}, err
+	}
+
+	// 取得花牌
+	flowers, err := GetPlayerFlowers(ctx, gameID, playerID)
+	if err != nil {
+		return models.ScoringContext{}, err
+	}
+
+	// 牌堆已無牌時：自摸為海底撈月，胡他人的牌為河底撈魚
+	deckCount, err := GetDeckCount(ctx, gameID)
+	if err != nil {
+		return models.ScoringContext{}, err
+	}
+
+	return models.ScoringContext{
+		ClosedHand:  closedHand,
+		Melds:       melds,
+		WinningTile: winningTile,
+		IsSelfDrawn: isSelfDrawn,
+		IsDealer:    state.DealerPlayerID == playerID,
+		Flowers:     flowers,
+
+		PrevailingWind: state.Round.PrevailingWind,
+		SeatWind:       models.SeatWind(playerID, state.DealerPlayerID),
+
+		IsLastTile:        isSelfDrawn && deckCount == 0,
+		IsLastDiscard:     !isSelfDrawn && deckCount == 0,
+		IsKongReplacement: isSelfDrawn && state.IsAfterKong,
+		IsRobbingKong:     !isSelfDrawn && state.IsRobbingKong,
+		IsFirstGoAround:   state.IsFirstGoAround(isSelfDrawn),
+
+		SpecialShapes: state.SpecialShapes(),
+		TaiTable:      state.TaiTable(),
+	}, nil
+}
+
+// settleRound 依各贏家台數換算點數轉移，並累加到 GameState.Scores (本局先前的詐胡罰款保留在 RoundTransfers)
+// discarderID 為放槍者，自摸時傳入 0
+func settleRound(state *models.GameState, discarderID int) {
+	if state.Scores == nil {
+		state.Scores = make(map[int]int)
+	}
+	if state.Stats == nil {
+		state.Stats = make(map[int]models.SeatStats)
+	}
+	rule := state.Rules.PointRule
+	if rule == (models.PointRule{}) {
+		rule = models.DefaultPointRule
+	}
+
+	for _, wid := range state.WinnerIDs {
+		transfers := models.SettleWin(rule, models.WinSettlement{
+			WinnerID:    wid,
+			DiscarderID: discarderID,
+			DealerID:    state.DealerPlayerID,
+			TotalTai:    state.ScoreResults[wid].TotalTai,
+		})
+		models.ApplyTransfers(state.Scores, transfers)
+		models.RecordWin(state.Stats, wid, discarderID, state.ScoreResults[wid])
+		state.RoundTransfers = append(state.RoundTransfers, transfers...)
+		utils.Info("[Settlement] Player %d transfers: %v, scores: %v", wid, transfers, state.Scores)
+	}
+
+	// 放槍 (一砲多響只記一次)
+	if discarderID != 0 && len(state.WinnerIDs) > 0 && state.WinnerIDs[0] != discarderID {
+		models.RecordDealIn(state.Stats, discarderID)
+	}
+}
+
 // NextRound 進入下一局
 func NextRound(ctx context.Context, gameID string) (*models.GameState, bool, error) {
-	state, err := LoadGameState(ctx, gameID)
+	state, err := loadActiveGameState(ctx, gameID)
 	if err != nil {
 		return nil, false, err
 	}
 
-	nextRound, isComplete := state.Round.NextRound()
+	if state.Stage != models.StageRoundOver {
+		return nil, false, fmt.Errorf("action not allowed in current stage: %s", state.Stage)
+	}
+	state.RoundsPlayed++
+
+	// 依遊戲長度計算下一局 (中途流局連莊時局號不前進，由同一莊家重打)
+	length := state.Rules.GameLength.Normalize()
+	nextRound, isComplete := length.NextRound(state.Round, state.RoundsPlayed, isDealerRetained(state))
+	reason := models.GameEndCompleted
+	if length.IsBust(state.Scores) {
+		isComplete = true
+		reason = models.GameEndBust
+	}
 	if isComplete {
-		state.IsFinished = true
-		state.Stage = models.StageGameOver
-		if err := SaveGameState(ctx, state); err != nil {
+		if err := finishGame(ctx, state, reason); err != nil {
 			return nil, true, err
 		}
+
+		if status, err := LoadGameStatus(ctx, gameID); err == nil {
+			status.Finished = true
+			_ = SaveGameStatus(ctx, gameID, status)
+		}
 		return state, true, nil // 一將結束
 	}
 
-	// 更新局號，莊家順轉（下家做莊）
+	// 更新局號，莊家順轉（下家做莊，連莊時不變）
 	state.Round = nextRound
-	state.DealerPlayerID = (state.DealerPlayerID % 4) + 1
+	state.DealerPlayerID = NextDealerID(state)
 	state.Stage = models.StageDealing // 下一局回到洗牌/發牌階段
 	state.CurrentPlayerID = 0
+	state.WinnerIDs = nil
+	state.ScoreResults = nil
+	state.RoundTransfers = nil
+	state.RoundResult = nil
+	state.LastDrawTile = nil
+	state.DeadHands = nil
+	state.DrawReason = models.DrawNone
+	state.FirstDiscards = nil
 
 	if err := SaveGameState(ctx, state); err != nil {
 		return nil, false, err
 	}
 
 	// 更新遊戲狀況紀錄中的進度和莊家
-	if status, err := LoadGameStatus(ctx, gameID); err == nil {
-		status.Progress = nextRound.RoundCode()
-		status.Dealer = fmt.Sprintf("player%d", state.DealerPlayerID)
-		_ = SaveGameStatus(ctx, gameID, status)
-	}
+	_ = UpdateGameStatusProgress(ctx, gameID, state)
 
 	return state, false, nil
 }
@@ -2,8 +2,10 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"webmajiang/models"
@@ -12,13 +14,64 @@ import (
 )
 
 // startGameRequest 開始遊戲請求
+// 可直接傳入完整的 rules 物件，或使用下列個別欄位 (rules 優先)
 type startGameRequest struct {
-	GameType int `json:"game_type"` // 13 或 16，預設 16
+	Rules json.RawMessage `json:"rules"` // 房間規則 (models.RoomRules)，未指定的欄位採遊戲類型預設
+
+	GameType int   `json:"game_type"` // 13 或 16，預設 16
+	Flowers  *bool `json:"flowers"`   // 是否加入花牌，預設 16 張含花、13 張不含
+	Dice     int   `json:"dice"`      // 擲骰數量 2 或 3，預設 16 張擲 3 顆、13 張擲 2 顆
+	Base     int   `json:"base"`      // 底，預設 100
+	PerTai   int   `json:"per_tai"`   // 每台點數，預設 20
+
+	SpecialShapes []string `json:"special_shapes"` // 啟用的特殊胡牌型，省略時採遊戲類型預設
+	TaiTable      string   `json:"tai_table"`      // 台數表名稱，省略時使用 standard
+
+	MinTai          int    `json:"min_tai"`           // 起胡台數，預設 0 (不限)
+	FalseWinPenalty string `json:"false_win_penalty"` // 詐胡處罰："pay_all" (賠三家，預設) 或 "dead_hand" (相公)
+	FalseWinTai     int    `json:"false_win_tai"`     // 賠三家時每家賠付的台數，預設 3
+
+	AbortiveDraws models.AbortiveDrawRule   `json:"abortive_draws"` // 中途流局規則，預設全部關閉
+	GameLength    models.GameLength         `json:"game_length"`    // 遊戲長度，預設一將 (full)
+	Timers        models.TimerRule          `json:"timers"`         // 宣告/出牌時限與 AI 思考時間，預設不限時
+	MultiWin      string                    `json:"multi_win"`      // "all" (一砲多響，預設) 或 "first" (截胡)
+	Audit         models.AuditRule          `json:"audit"`          // 牌桌守恆稽核：每次動作後稽核、違規時凍結
+	AIStrategy    string                    `json:"ai_strategy"`    // AI 策略："beginner" (新手)、"basic" (預設) 或 "efficiency" (牌效)
+	BotStrategies map[int]models.AIStrategy `json:"bot_strategies"` // 各 AI 座位的策略，未指定的座位採 ai_strategy
 }
 
-// StartGameHandler 開始新的一將（第一局）
+// roomRules 由請求組出房間規則
+func (req startGameRequest) roomRules() (models.RoomRules, error) {
+	if len(req.Rules) > 0 && string(req.Rules) != "null" {
+		return models.ParseRoomRules(req.Rules)
+	}
+
+	rules := models.DefaultRoomRules(models.GameType(req.GameType))
+	if req.Flowers != nil {
+		rules.Flowers = *req.Flowers
+	}
+	rules.Dice = req.Dice
+	rules.PointRule = models.PointRule{Base: req.Base, PerTai: req.PerTai}
+	rules.WinRule = models.WinRule{
+		MinTai:     req.MinTai,
+		Penalty:    models.FalseWinPenalty(req.FalseWinPenalty),
+		PenaltyTai: req.FalseWinTai,
+	}
+	rules.GameLength = req.GameLength
+	rules.Timers = req.Timers
+	rules.MultiWin = models.MultiWinMode(req.MultiWin)
+	rules.TaiTable = req.TaiTable
+	rules.SpecialShapes = req.SpecialShapes
+	rules.AbortiveDraws = req.AbortiveDraws
+	rules.Audit = req.Audit
+	rules.AIStrategy = models.AIStrategy(req.AIStrategy)
+	rules.BotStrategies = req.BotStrategies
+	return rules.Normalize(), nil
+}
+
+// StartGameHandler 開始新的一將（第一局）；帶登入 token 時記錄為開房者
 // POST /api/game/start
-// Body: {"game_type": 13} 或 {"game_type": 16}
+// Body: {"game_type": 13} 或 {"game_type": 16, "base": 100, "per_tai": 20} 或 {"rules": {...}}
 func StartGameHandler(c *hypcontext.Context) {
 	gameID := fmt.Sprintf("majiang_%d", time.Now().UnixNano())
 	ctx := context.Background()
@@ -27,16 +80,23 @@ func StartGameHandler(c *hypcontext.Context) {
 	var req startGameRequest
 	if err := c.BindJSON(&req); err != nil {
 		// 解析失敗時使用預設值
-		req.GameType = 16
+		req = startGameRequest{GameType: 16}
 	}
 
-	// 轉換遊戲類型
-	gameType := models.GameType(req.GameType)
-	if gameType != models.GameType13 && gameType != models.GameType16 {
-		gameType = models.GameType16
+	rules, err := req.roomRules()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid room rules",
+			"message": err.Error(),
+		})
+		return
 	}
 
-	state, err := StartNewGame(ctx, gameID, gameType)
+	state, err := StartNewGame(ctx, gameID, rules)
+	if err == nil && c.GetInt64("userID") != 0 {
+		state.OwnerID = c.GetInt64("userID")
+		err = SaveGameState(ctx, state)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"error":   "failed to start game",
@@ -46,12 +106,244 @@ func StartGameHandler(c *hypcontext.Context) {
 	}
 
 	c.JSON(http.StatusOK, map[string]interface{}{
-		"message":      "遊戲初始化完成，等待進入 WebSocket 進行後續階段",
-		"game_id":      gameID,
-		"game_type":    int(gameType),
-		"stage":        state.Stage,
-		"round":        state.Round.RoundLabel(),
-		"round_code":   state.Round.RoundCode(),
-		"round_number": state.Round.RoundNumber(),
+		"message":        "遊戲初始化完成，等待進入 WebSocket 進行後續階段",
+		"game_id":        gameID,
+		"game_type":      int(state.Rules.GameType),
+		"rules":          state.Rules,
+		"point_rule":     state.Rules.PointRule,
+		"special_shapes": specialShapeIDs(state.SpecialShapes()),
+		"tai_table":      state.TaiTable().Name,
+		"win_rule":       state.Rules.WinRule,
+		"abortive_draws": state.Rules.AbortiveDraws,
+		"game_length":    state.Rules.GameLength,
+		"progress":       state.Progress(),
+		"stage":          state.Stage,
+		"round":          state.Round.RoundLabel(),
+		"round_code":     state.Round.RoundCode(),
+		"round_number":   state.Round.RoundNumber(),
+	})
+}
+
+// GetGameResultHandler 查詢已結束對局的最終排名與統計
+// GET /api/game/:id/result
+func GetGameResultHandler(c *hypcontext.Context) {
+	gameID := c.Param("id")
+
+	result, err := LoadGameResult(context.Background(), gameID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "game result not found",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetGameAuditHandler 立即稽核牌桌守恆與狀態一致性，只回傳違規項目
+// 完整快照含四家暗牌，違規時寫入日誌，或由管理者透過 GetGameAuditSnapshotHandler 取得
+// GET /api/game/:id/audit
+func GetGameAuditHandler(c *hypcontext.Context) {
+	respondAudit(c, false)
+}
+
+// GetGameAuditSnapshotHandler 稽核並附上完整牌桌快照 (僅限管理者)
+// GET /api/game/:id/audit/snapshot
+func GetGameAuditSnapshotHandler(c *hypcontext.Context) {
+	respondAudit(c, true)
+}
+
+func respondAudit(c *hypcontext.Context, withSnapshot bool) {
+	report, err := AuditGame(context.Background(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "audit failed",
+			"message": err.Error(),
+		})
+		return
+	}
+	if !withSnapshot {
+		report.Snapshot = nil
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"ok":     report.OK(),
+		"report": report,
 	})
 }
+
+// GetGameDangerHandler 評估座位手牌每種牌的放槍危險度、對手威脅度與是否建議棄胡 (教學提示用)
+// 報告含該座位的暗牌，只限入座該座位的帳號或教練、管理員查看 (須接在 AuthRequired 之後)
+// GET /api/game/:id/danger/:seat
+func GetGameDangerHandler(c *hypcontext.Context) {
+	seat, err := strconv.Atoi(c.Param("seat"))
+	if err != nil || seat < 1 || seat > 4 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid seat",
+			"message": "seat must be 1-4",
+		})
+		return
+	}
+
+	state, err := LoadGameState(context.Background(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "failed to assess danger",
+			"message": err.Error(),
+		})
+		return
+	}
+	if !canViewSeat(c, state, seat) {
+		c.JSON(http.StatusForbidden, map[string]interface{}{
+			"error":   "forbidden",
+			"message": "only the seat owner, a coach or an admin may view this seat",
+		})
+		return
+	}
+
+	report, err := AssessSeatDanger(context.Background(), c.Param("id"), seat)
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "failed to assess danger",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"report": report,
+	})
+}
+
+// attachBotRequest 座位改由外部 AI 接手的請求
+type attachBotRequest struct {
+	Transport string `json:"transport"` // "websocket" (發給 bot token) 或 "process" (本機子程序)
+	Name      string `json:"name"`      // process：設定檔 bots.processes 中的名稱
+}
+
+// AttachBotHandler 座位改由外部 AI 接手 (僅限等待玩家階段)；只有開房者或管理員可指派 (須接在 AuthRequired 之後)
+// POST /api/game/:id/bots/:seat
+// Body: {"transport": "websocket"} 或 {"transport": "process", "name": "sample"}
+func AttachBotHandler(c *hypcontext.Context) {
+	seat, err := strconv.Atoi(c.Param("seat"))
+	if err != nil || seat < 1 || seat > 4 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid seat",
+			"message": "seat must be 1-4",
+		})
+		return
+	}
+
+	state, err := LoadGameState(context.Background(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "failed to attach bot",
+			"message": err.Error(),
+		})
+		return
+	}
+	role, _ := c.Get("role")
+	if role != models.RoleAdmin && (state.OwnerID == 0 || state.OwnerID != c.GetInt64("userID")) {
+		c.JSON(http.StatusForbidden, map[string]interface{}{
+			"error":   "forbidden",
+			"message": "only the room owner or an admin may attach bots",
+		})
+		return
+	}
+
+	var req attachBotRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	token, err := AttachExternalBot(context.Background(), c.Param("id"), seat, req.Transport, req.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "failed to attach bot",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	res := map[string]interface{}{
+		"seat":      seat,
+		"transport": req.Transport,
+	}
+	if token != "" {
+		res["token"] = token
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetTileRegistryHandler 取得牌 ID 登錄表 (ID ↔ 類型、數值、第幾張)
+// GET /api/tiles
+func GetTileRegistryHandler(c *hypcontext.Context) {
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"tiles": models.TileRegistry(),
+	})
+}
+
+// GetGameHandsHandler 以牌譜記法列出四家目前的牌面 (除錯用)
+// GET /api/game/:id/hands
+func GetGameHandsHandler(c *hypcontext.Context) {
+	gameID := c.Param("id")
+	ctx := context.Background()
+
+	hands := make(map[int]string, 4)
+	for seat := 1; seat <= 4; seat++ {
+		hand, err := loadHandNotation(ctx, gameID, seat)
+		if err != nil {
+			c.JSON(http.StatusNotFound, map[string]interface{}{
+				"error":   "failed to load hands",
+				"message": err.Error(),
+			})
+			return
+		}
+		hands[seat] = hand.String()
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"game_id": gameID,
+		"hands":   hands,
+	})
+}
+
+// loadHandNotation 從 Redis 取得座位的手牌、副露與花牌
+func loadHandNotation(ctx context.Context, gameID string, seat int) (models.HandNotation, error) {
+	closed, err := GetPlayerHand(ctx, gameID, seat)
+	if err != nil {
+		return models.HandNotation{}, err
+	}
+	melds, err := GetPlayerMelds(ctx, gameID, seat)
+	if err != nil {
+		return models.HandNotation{}, err
+	}
+	flowers, err := GetPlayerFlowers(ctx, gameID, seat)
+	if err != nil {
+		return models.HandNotation{}, err
+	}
+	return models.HandNotation{Closed: closed, Melds: melds, Flowers: flowers}, nil
+}
+
+// specialShapeIDs 取出特殊牌型的 ID 列表 (供 API 回應使用)
+func specialShapeIDs(shapes []models.SpecialShape) []string {
+	ids := make([]string, 0, len(shapes))
+	for _, shape := range shapes {
+		ids = append(ids, shape.ID)
+	}
+	return ids
+}
+
+// canViewSeat 目前帳號是否可查看座位的暗牌：入座該座位的帳號，或教練、管理員
+func canViewSeat(c *hypcontext.Context, state *models.GameState, seat int) bool {
+	if role, _ := c.Get("role"); role == models.RoleCoach || role == models.RoleAdmin {
+		return true
+	}
+	owner := state.Players[seat].UserID
+	return owner != 0 && owner == c.GetInt64("userID")
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import "sync"
+
+// 同一場遊戲的狀態變更 (WebSocket 動作、計時器逾時、AI 推進、外部 AI 接手) 都是
+// 「載入狀態 → 檢查 → 寫回」，須以每場遊戲一把鎖串行化，避免交錯執行覆寫彼此的結果
+// 鎖只在外層入口取得 (引擎函式之間會互相呼叫，sync.Mutex 不可重入)；單一伺服器程序內有效
+var (
+	gameLocksMu sync.Mutex
+	gameLocks   = make(map[string]*gameLock)
+)
+
+type gameLock struct {
+	mu   sync.Mutex
+	refs int // 持有或等待中的數量，歸零時移除
+}
+
+// lockGame 取得遊戲的鎖，回傳解鎖函式 (用法: defer lockGame(gameID)())
+func lockGame(gameID string) func() {
+	gameLocksMu.Lock()
+	l, ok := gameLocks[gameID]
+	if !ok {
+		l = &gameLock{}
+		gameLocks[gameID] = l
+	}
+	l.refs++
+	gameLocksMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		gameLocksMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(gameLocks, gameID)
+		}
+		gameLocksMu.Unlock()
+	}
+}
@@ -2,233 +2,287 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/maoxiaoyue/hypgo/pkg/websocket"
+
 	"webmajiang/models"
 	"webmajiang/utils"
 )
 
-// RunPostDiscard 出牌後觸發的遊戲推進邏輯
-// 1. 收集 AI 玩家的宣告 (pass/pong/hu)
-// 2. 若三家都表態完畢（或有人胡），自動結算
-// 3. 結算後推進到下一階段
-func RunPostDiscard(ctx context.Context, gameID string) (*models.GameState, error) {
-	state, err := LoadGameState(ctx, gameID)
-	if err != nil {
-		return nil, err
-	}
+// AI 推進：一次一步，每一步分三段
+//  1. 持遊戲鎖載入狀態，決定輪到哪個 AI 座位做什麼 (回應他家出牌、摸牌或自己的出牌階段) 並記下狀態快照
+//  2. 放開鎖等待思考時間並詢問座位的 AI (外部 AI 需經連線往返)
+//  3. 重新持鎖比對快照，狀態未推進才套用並廣播；已被真人動作或計時器推進時作廢，依新狀態重新決定
+//
+// 推進到輪到真人玩家或本局結束為止
 
-	if state.Stage != models.StageWaitAction {
-		return nil, fmt.Errorf("RunPostDiscard: expected WAIT_ACTION stage, got %s", state.Stage)
-	}
+// aiStepKind AI 這一步要做的動作
+type aiStepKind int
 
-	// 收集所有 AI 玩家的自動宣告
-	for pID := 1; pID <= 4; pID++ {
-		if pID == state.LastDiscardPlayerID {
-			continue // 出牌者不參與宣告
-		}
+const (
+	aiStepClaim aiStepKind = iota + 1 // 回應他家出牌 (吃、碰、槓、胡或過)
+	aiStepDraw                        // 摸牌
+	aiStepTurn                        // 自己的出牌階段 (自摸、暗槓/加槓或出牌)
+)
 
-		player, ok := state.Players[pID]
-		if !ok {
-			continue
-		}
+// aiStep 持鎖時決定的一步 AI 動作，以及放開鎖後 AI 決策所需的視野
+type aiStep struct {
+	kind       aiStepKind
+	seat       int
+	checkpoint timerCheckpoint
+	delay      time.Duration
+	bot        models.Bot
+	claim      models.ClaimView
+	self       models.SelfView
+}
 
-		if !player.IsBot {
-			continue // 真人玩家需透過 WebSocket 手動宣告
-		}
+// aiMove AI 對這一步做出的決定
+type aiMove struct {
+	claim   models.ClaimDecision
+	self    models.SelfAction
+	discard models.Tile
+}
 
-		// AI 自動判斷要宣告什麼
-		aiAction, err := ProcessAIResponse(ctx, gameID, pID, state.LastDiscardTile)
-		if err != nil {
-			utils.Error("[GameLoop] AI 玩家 %d 宣告失敗: %v", pID, err)
-			aiAction = "pass"
-		}
+// 每場遊戲同時只有一個 AI 推進；推進中再次排程時記為待處理，推進結束前重新檢查一次
+var (
+	aiDriversMu sync.Mutex
+	aiDrivers   = make(map[string]bool) // gameID → 推進中是否又有新的排程
+)
 
-		utils.Info("[GameLoop] AI 玩家 %d 宣告: %s", pID, aiAction)
+// scheduleAI 在背景推進 AI 座位，每一步後廣播最新狀態
+// 呼叫端不需持有遊戲鎖 (持有時也不會阻塞，推進會在鎖放開後才開始)
+func scheduleAI(hub *websocket.Hub, gameID string) {
+	aiDriversMu.Lock()
+	if _, running := aiDrivers[gameID]; running {
+		aiDrivers[gameID] = true
+		aiDriversMu.Unlock()
+		return
+	}
+	aiDrivers[gameID] = false
+	aiDriversMu.Unlock()
+
+	go func() {
+		for {
+			if err := driveAI(context.Background(), hub, gameID); err != nil {
+				utils.Error("[GameLoop] room %s AI step failed: %v", gameID, err)
+			}
+
+			aiDriversMu.Lock()
+			if !aiDrivers[gameID] {
+				delete(aiDrivers, gameID)
+				aiDriversMu.Unlock()
+				return
+			}
+			aiDrivers[gameID] = false
+			aiDriversMu.Unlock()
+		}
+	}()
+}
 
-		// 透過 PlayerDeclareAction 記錄宣告
-		state, err = PlayerDeclareAction(ctx, gameID, pID, aiAction)
-		if err != nil {
-			return nil, fmt.Errorf("AI player %d declare failed: %w", pID, err)
+// driveAI 逐步推進 AI 座位直到沒有 AI 需要動作 (hub 為 nil 時不廣播，供模擬使用)
+// 呼叫端不可持有遊戲鎖
+func driveAI(ctx context.Context, hub *websocket.Hub, gameID string) error {
+	for {
+		step, err := nextAIStep(ctx, gameID)
+		if err != nil || step == nil {
+			return err
 		}
 
-		// 若有人胡了，PlayerDeclareAction 會自動觸發 ResolveActions
-		if state.Stage == models.StageRoundOver {
-			return state, nil
+		// 放開鎖後思考並詢問 AI，期間真人玩家與計時器可以照常動作
+		if step.delay > 0 {
+			time.Sleep(step.delay)
 		}
-	}
+		move := step.decide()
 
-	// 檢查是否還需要等待真人玩家宣告
-	if state.Stage == models.StageWaitAction {
-		// 計算已宣告人數（排除出牌者的其他 3 家）
-		declared := len(state.ActionDeclarations)
-		if declared < 3 {
-			// 還有真人玩家尚未宣告，需要等待
-			return state, nil
+		if err := applyAIStep(ctx, hub, gameID, step, move); err != nil {
+			return err
 		}
 	}
-
-	// 所有人都已表態，結算已在 PlayerDeclareAction 中自動觸發
-	// 接著推進到下一階段
-	return RunPostResolve(ctx, gameID)
 }
 
-// RunPostResolve 結算完畢後推進下一階段
-// 根據結算結果：
-//   - 全 pass → PLAYER_DRAW: 若下家是 AI 則自動摸牌+出牌
-//   - 碰/吃 → PLAYER_DISCARD: 若得標者是 AI 則自動出牌
-//   - 胡 → ROUND_OVER: 不做任何事
-func RunPostResolve(ctx context.Context, gameID string) (*models.GameState, error) {
+// nextAIStep 持鎖決定下一步 AI 動作，輪到真人玩家或本局已結束時回傳 nil
+func nextAIStep(ctx context.Context, gameID string) (*aiStep, error) {
+	defer lockGame(gameID)()
+
 	state, err := LoadGameState(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
+	thinkTime := state.Rules.Timers.Normalize().AIThinkTime()
+	step := &aiStep{checkpoint: checkpointOf(state)}
 
 	switch state.Stage {
-	case models.StageRoundOver:
-		utils.Info("[GameLoop] 遊戲結束 (ROUND_OVER)")
-		return state, nil
-
-	case models.StagePlayerDraw:
-		// 下家需要摸牌
-		nextPlayer, ok := state.Players[state.CurrentPlayerID]
-		if !ok {
-			return nil, fmt.Errorf("player %d not found", state.CurrentPlayerID)
+	case models.StageWaitAction:
+		step.kind, step.seat = aiStepClaim, pendingBotClaim(state)
+		if step.seat == 0 {
+			return nil, nil // 等待真人玩家宣告
 		}
-
-		if nextPlayer.IsBot {
-			utils.Info("[GameLoop] 下家是 AI 玩家 %d，自動摸牌+出牌...", nextPlayer.ID)
-			return runAIDrawAndDiscard(ctx, gameID, nextPlayer)
+		if step.claim, err = loadClaimView(ctx, state, step.seat); err != nil {
+			return nil, err
 		}
 
-		// 真人玩家需透過 WebSocket 手動摸牌
-		utils.Info("[GameLoop] 輪到真人玩家 %d 摸牌，等待 WebSocket draw_tile...", nextPlayer.ID)
-		return state, nil
+	case models.StagePlayerDraw:
+		if !state.Players[state.CurrentPlayerID].IsBot {
+			return nil, nil // 真人玩家需透過 WebSocket 手動摸牌
+		}
+		step.kind, step.seat, step.delay = aiStepDraw, state.CurrentPlayerID, thinkTime
 
 	case models.StagePlayerDiscard:
-		// 碰/吃得標者需要出牌
-		winner, ok := state.Players[state.CurrentPlayerID]
-		if !ok {
-			return nil, fmt.Errorf("player %d not found", state.CurrentPlayerID)
+		if !state.Players[state.CurrentPlayerID].IsBot {
+			return nil, nil // 真人玩家需透過 WebSocket 手動出牌
 		}
-
-		if winner.IsBot {
-			utils.Info("[GameLoop] 碰/吃得標者是 AI 玩家 %d，自動出牌...", winner.ID)
-			return runAIDiscard(ctx, gameID, winner)
+		step.kind, step.seat, step.delay = aiStepTurn, state.CurrentPlayerID, thinkTime/2
+		// 本回合摸進或槓後補進的牌才檢查自摸 (吃碰後與莊家開門時為 nil)
+		if step.self, err = loadSelfView(ctx, state, step.seat, state.LastDrawTile); err != nil {
+			return nil, err
 		}
 
-		// 真人玩家需透過 WebSocket 手動出牌
-		utils.Info("[GameLoop] 碰/吃得標者是真人玩家 %d，等待 WebSocket discard_tile...", winner.ID)
-		return state, nil
-
 	default:
-		return state, nil
+		return nil, nil
 	}
+
+	step.bot = botFor(state, step.seat)
+	return step, nil
 }
 
-// ProcessAIResponse AI 對「他人出牌」的自動回應
-// 目前邏輯：
-//   - 如果 AI 能胡，宣告 "hu"
-//   - 如果 AI 有對子（能碰），宣告 "pong"
-//   - 否則 "pass"
-func ProcessAIResponse(ctx context.Context, gameID string, playerID int, discardedTile *models.Tile) (string, error) {
-	if discardedTile == nil {
-		return "pass", nil
+// pendingBotClaim 尚未宣告的 AI 座位 (出牌者除外)，沒有時回傳 0
+func pendingBotClaim(state *models.GameState) int {
+	for pID := 1; pID <= 4; pID++ {
+		if pID == state.LastDiscardPlayerID || !state.Players[pID].IsBot {
+			continue
+		}
+		if _, declared := state.ActionDeclarations[pID]; !declared {
+			return pID
+		}
 	}
+	return 0
+}
 
-	// 從 Redis 取得 AI 手牌
-	hand, err := GetPlayerHand(ctx, gameID, playerID)
-	if err != nil {
-		return "pass", fmt.Errorf("failed to get AI hand: %w", err)
+// loadClaimView AI 回應他家出牌的視野
+// 判斷胡牌是否成立 (含特殊牌型、相公與起胡台數)，交由座位的 AI 決定吃、碰、槓、胡或過
+func loadClaimView(ctx context.Context, state *models.GameState, playerID int) (models.ClaimView, error) {
+	if state.LastDiscardTile == nil {
+		return models.ClaimView{}, fmt.Errorf("no discard to claim")
 	}
+	discardedTile := *state.LastDiscardTile
 
-	// 1. 檢查是否能胡 (將被打出的牌加入手牌判斷)
-	testHand := append([]models.Tile{}, hand...)
-	testHand = append(testHand, *discardedTile)
-	if models.CanHu(testHand) {
-		return "hu", nil
+	canHu := false
+	if _, err := checkWinClaim(ctx, state.GameID, state, playerID, discardedTile, false); err == nil {
+		canHu = true
+	} else if !errors.Is(err, ErrFalseWin) && !errors.Is(err, ErrDeadHand) {
+		return models.ClaimView{}, err
 	}
 
-	// 2. 檢查是否能碰 (手牌中有兩張同樣的牌)
-	matchCount := 0
-	for _, t := range hand {
-		if t.Type == discardedTile.Type && t.Value == discardedTile.Value {
-			matchCount++
-		}
-	}
-	if matchCount >= 2 {
-		return "pong", nil
+	view, err := LoadDangerView(ctx, state.GameID, state, playerID)
+	if err != nil {
+		return models.ClaimView{}, fmt.Errorf("failed to load AI view: %w", err)
 	}
 
-	// 3. 其他情況 pass
-	return "pass", nil
+	return models.ClaimView{
+		DangerView:  view,
+		Discard:     discardedTile,
+		CanChow:     playerID == state.LastDiscardPlayerID%4+1,
+		CanHu:       canHu,
+		RobbingKong: state.IsRobbingKong,
+	}, nil
 }
 
-// runAIDrawAndDiscard AI 玩家的完整摸牌+出牌流程
-func runAIDrawAndDiscard(ctx context.Context, gameID string, player models.Player) (*models.GameState, error) {
-	// 模擬 AI 思考時間
-	time.Sleep(1 * time.Second)
+// loadSelfView AI 在自己出牌階段的視野，drawn 為剛摸進或補進的牌 (nil 時不檢查自摸)
+func loadSelfView(ctx context.Context, state *models.GameState, playerID int, drawn *models.Tile) (models.SelfView, error) {
+	// 檢查自摸是否成立 (含相公與起胡台數)
+	canHu := false
+	if drawn != nil {
+		_, err := checkWinClaim(ctx, state.GameID, state, playerID, *drawn, true)
+		canHu = err == nil
+	}
 
-	// 1. 摸牌
-	state, drawnTile, err := DrawTileAction(ctx, gameID, player.ID)
+	view, err := LoadDangerView(ctx, state.GameID, state, playerID)
 	if err != nil {
-		return nil, fmt.Errorf("AI 摸牌失敗: %w", err)
+		return models.SelfView{}, fmt.Errorf("取得 AI 視野失敗: %w", err)
 	}
+	return models.SelfView{DangerView: view, Drawn: drawn, CanHu: canHu}, nil
+}
 
-	// 荒莊流局
-	if drawnTile == nil {
-		utils.Info("[AI Turn] 牌堆已空，荒莊流局")
-		return state, nil
+// decide 詢問座位的 AI (不持有遊戲鎖)
+func (s *aiStep) decide() aiMove {
+	var move aiMove
+	switch s.kind {
+	case aiStepClaim:
+		move.claim = s.bot.RespondToDiscard(s.claim)
+	case aiStepTurn:
+		move.self = s.bot.ChooseSelfAction(s.self)
+		if move.self.Action != models.ClaimHu && move.self.Action != models.ClaimKong {
+			move.discard = s.bot.ChooseDiscard(s.self.DangerView)
+		}
 	}
+	return move
+}
 
-	utils.Info("[AI Turn] 玩家 %d 摸到了 %s", player.ID, drawnTile.String())
+// applyAIStep 持鎖比對快照後套用 AI 的決定並廣播，狀態已推進時不動作
+func applyAIStep(ctx context.Context, hub *websocket.Hub, gameID string, step *aiStep, move aiMove) error {
+	defer lockGame(gameID)()
 
-	// 2. 取得最新手牌
-	hand, err := GetPlayerHand(ctx, gameID, player.ID)
+	state, err := LoadGameState(ctx, gameID)
 	if err != nil {
-		return nil, fmt.Errorf("取得 AI 手牌失敗: %w", err)
+		return err
+	}
+	if checkpointOf(state) != step.checkpoint {
+		return nil
 	}
 
-	// 3. 檢查是否自摸
-	if models.CanHu(hand) {
-		utils.Info("[AI Turn] 🌟 玩家 %d 自摸了！", player.ID)
-		state, err = LoadGameState(ctx, gameID)
-		if err != nil {
-			return nil, err
+	switch step.kind {
+	case aiStepClaim:
+		if _, declared := state.ActionDeclarations[step.seat]; declared {
+			return nil
 		}
-		state.Stage = models.StageRoundOver
-		state.CurrentPlayerID = player.ID
-		if err := SaveGameState(ctx, state); err != nil {
-			return nil, err
+		utils.Info("[GameLoop] AI 玩家 %d 宣告: %s %s", step.seat, move.claim.Action, models.FormatTiles(move.claim.Tiles))
+		state, err = PlayerDeclareClaim(ctx, gameID, step.seat, move.claim.Action, move.claim.Tiles)
+		if err != nil && (state == nil || (!errors.Is(err, ErrFalseWin) && !errors.Is(err, ErrDeadHand))) {
+			return fmt.Errorf("AI player %d declare failed: %w", step.seat, err)
 		}
-		return state, nil
-	}
-
-	time.Sleep(500 * time.Millisecond)
 
-	// 4. 選擇最佳出牌
-	return runAIDiscard(ctx, gameID, models.Player{ID: player.ID, Name: player.Name, IsBot: true, Hand: hand})
-}
+	case aiStepDraw:
+		var drawnTile *models.Tile
+		if state, drawnTile, err = DrawTileAction(ctx, gameID, step.seat); err != nil {
+			return fmt.Errorf("AI 摸牌失敗: %w", err)
+		}
+		switch {
+		case drawnTile != nil:
+			utils.Info("[AI Turn] 玩家 %d 摸到了 %s", step.seat, models.NotationOf(*drawnTile))
+		case len(state.WinnerIDs) > 0:
+			utils.Info("[AI Turn] 補花成立花牌胡牌，本局結束")
+		default:
+			utils.Info("[AI Turn] 牌堆已空，荒莊流局")
+		}
 
-// runAIDiscard AI 玩家出牌並觸發後續流程
-func runAIDiscard(ctx context.Context, gameID string, player models.Player) (*models.GameState, error) {
-	// 取得最新手牌（如果 Hand 為空）
-	if len(player.Hand) == 0 {
-		hand, err := GetPlayerHand(ctx, gameID, player.ID)
-		if err != nil {
-			return nil, fmt.Errorf("取得 AI 手牌失敗: %w", err)
+	case aiStepTurn:
+		switch move.self.Action {
+		case models.ClaimHu:
+			utils.Info("[AI Turn] 🌟 玩家 %d 自摸了！", step.seat)
+			state, err = DeclareSelfDrawnHu(ctx, gameID, step.seat)
+			if err != nil && (state == nil || !errors.Is(err, ErrFalseWin)) {
+				return fmt.Errorf("AI 自摸失敗: %w", err)
+			}
+		case models.ClaimKong:
+			// 暗槓補牌後繼續出牌；加槓進入 WAIT_ACTION 等待他家搶槓
+			utils.Info("[AI Turn] 玩家 %d 開槓 %s", step.seat, models.NotationOf(move.self.Tile))
+			if state, err = DeclareOwnKong(ctx, gameID, step.seat, move.self.Tile); err != nil {
+				return fmt.Errorf("AI 開槓失敗: %w", err)
+			}
+		default:
+			utils.Info("[AI Turn] 玩家 %d 決定丟出 %s (策略 %s，手牌 %s)", step.seat, models.NotationOf(move.discard), step.bot.Strategy(), models.FormatTiles(step.self.Hand))
+			if state, err = DiscardTileAction(ctx, gameID, step.seat, move.discard); err != nil {
+				return fmt.Errorf("AI 丟牌失敗: %w", err)
+			}
 		}
-		player.Hand = hand
 	}
 
-	discardTile := models.GetBestDiscard(player.Hand)
-	utils.Info("[AI Turn] 玩家 %d 決定丟出 %s", player.ID, discardTile.String())
-
-	if _, err := DiscardTileAction(ctx, gameID, player.ID, discardTile); err != nil {
-		return nil, fmt.Errorf("AI 丟牌失敗: %w", err)
+	if hub != nil {
+		broadcastState(hub, gameID, state)
 	}
-
-	// 出牌後自動推進（收集其他 AI 宣告等）
-	return RunPostDiscard(ctx, gameID)
+	return nil
 }
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"webmajiang/models"
+	"webmajiang/service"
+	"webmajiang/utils"
+)
+
+// ErrGameFinished 一將已結束，遊戲狀態為唯讀
+var ErrGameFinished = errors.New("game is finished and read-only")
+
+// ErrGameFrozen 稽核發現牌桌違規，遊戲已凍結
+var ErrGameFrozen = errors.New("game is frozen by the table auditor")
+
+// GameResultKey 終局結果在 Redis 中的 key
+func GameResultKey(gameID string) string {
+	return fmt.Sprintf("mjgame:%s:result", gameID)
+}
+
+// MatchHistoryKey 所有已結束對局的 game ID 列表 (最新的在最前面)
+const MatchHistoryKey = "mjgame:history"
+
+// UserMatchesKey 帳號參與過的對局 game ID 列表 (以 join_room 入座的座位)
+func UserMatchesKey(userID int64) string {
+	return fmt.Sprintf("user:matches:%d", userID)
+}
+
+// loadActiveGameState 讀取遊戲狀態，若一將已結束或已被稽核凍結則拒絕任何變更
+func loadActiveGameState(ctx context.Context, gameID string) (*models.GameState, error) {
+	state, err := LoadGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if state.IsFinished {
+		return nil, ErrGameFinished
+	}
+	if state.IsFrozen {
+		return nil, fmt.Errorf("%w: %s", ErrGameFrozen, state.FrozenReason)
+	}
+	return state, nil
+}
+
+// finishGame 一將結束：計算最終排名、標記唯讀，並寫入對戰紀錄
+func finishGame(ctx context.Context, state *models.GameState, reason models.GameEndReason) error {
+	result := &models.FinalResult{
+		GameID:       state.GameID,
+		GameType:     state.Rules.GameType,
+		GameLength:   state.Rules.GameLength.Normalize(),
+		EndReason:    reason,
+		RoundsPlayed: state.RoundsPlayed,
+		FinishedAt:   time.Now().Unix(),
+		Standings:    models.BuildStandings(state),
+	}
+
+	state.IsFinished = true
+	state.Stage = models.StageGameOver
+	state.FinalResult = result
+	releaseBots(state.GameID)
+
+	if err := SaveGameState(ctx, state); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal final result: %w", err)
+	}
+
+	rdb := service.RedisClient
+	if err := rdb.Set(ctx, GameResultKey(state.GameID), string(data), 0).Err(); err != nil {
+		return fmt.Errorf("failed to save final result: %w", err)
+	}
+	if err := rdb.LPush(ctx, MatchHistoryKey, state.GameID).Err(); err != nil {
+		return fmt.Errorf("failed to push match history: %w", err)
+	}
+
+	// 以帳號入座的玩家個人對戰紀錄
+	for seat := 1; seat <= 4; seat++ {
+		userID := state.Players[seat].UserID
+		if userID == 0 {
+			continue
+		}
+		if err := rdb.LPush(ctx, UserMatchesKey(userID), state.GameID).Err(); err != nil {
+			return fmt.Errorf("failed to push match history of user %d: %w", userID, err)
+		}
+	}
+
+	utils.Info("[GameOver] Game %s finished, standings: %v", state.GameID, result.Standings)
+	return nil
+}
+
+// LoadGameResult 從 Redis 讀取已結束對局的最終結果
+func LoadGameResult(ctx context.Context, gameID string) (*models.FinalResult, error) {
+	data, err := service.RedisClient.Get(ctx, GameResultKey(gameID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game result: %w", err)
+	}
+
+	var result models.FinalResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game result: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"webmajiang/models"
+	"webmajiang/service"
+	"webmajiang/utils"
+)
+
+// validateClaim 檢查吃碰槓胡宣告是否合法，吃牌時把選定的兩張手牌記入 state.ClaimTiles
+// 搶槓 (等待宣告的是加槓的牌) 時只能胡或過；胡牌不成立時回傳 ErrFalseWin (已處罰) 或 ErrDeadHand
+func validateClaim(ctx context.Context, gameID string, state *models.GameState, playerID int, action string, tiles []models.Tile) error {
+	switch action {
+	case "pass":
+		return nil
+	case "hu":
+		return validateHuClaim(ctx, gameID, state, playerID)
+	}
+	if state.IsRobbingKong {
+		return fmt.Errorf("only hu or pass is allowed on an added kong")
+	}
+	if state.LastDiscardTile == nil {
+		return fmt.Errorf("no discard to claim")
+	}
+	discard := *state.LastDiscardTile
+
+	hand, err := GetPlayerHand(ctx, gameID, playerID)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "pong", "kong":
+		need := 2
+		if action == "kong" {
+			need = 3
+		}
+		same := 0
+		for _, t := range hand {
+			if t.Type == discard.Type && t.Value == discard.Value {
+				same++
+			}
+		}
+		if same < need {
+			return fmt.Errorf("cannot %s %s: only %d matching tiles in hand", action, models.NotationOf(discard), same)
+		}
+		return nil
+
+	case "chow":
+		// 只能吃上家的牌
+		if playerID != state.LastDiscardPlayerID%4+1 {
+			return fmt.Errorf("can only chow from the previous seat")
+		}
+		option, ok := models.SelectChowOption(models.ChowOptions(hand, discard), tiles)
+		if !ok {
+			return fmt.Errorf("cannot chow %s with the given tiles", models.NotationOf(discard))
+		}
+		if state.ClaimTiles == nil {
+			state.ClaimTiles = make(map[int][]int)
+		}
+		state.ClaimTiles[playerID] = []int{option[0].ID, option[1].ID}
+		return nil
+	}
+	return fmt.Errorf("unknown action: %s", action)
+}
+
+// drawKongReplacement 開槓後從嶺上 (LPop) 補一張牌給開槓者，並檢查四槓散了
+// 回傳 true 代表本局已結束 (四槓散了、已無牌可補的荒莊，或補花成立八仙過海/七搶一)
+func drawKongReplacement(ctx context.Context, gameID string, state *models.GameState, playerID int) (bool, error) {
+	state.IsAfterKong = true
+
+	drawn, err := drawReplacementTile(ctx, gameID)
+	if err != nil {
+		return false, err
+	}
+	if drawn == nil {
+		return true, abortRound(ctx, gameID, state, models.DrawExhaustive)
+	}
+	// 嶺上補到花牌時同樣放入花牌區並繼續補牌
+	if drawn, err = replaceDrawnFlowers(ctx, gameID, state, playerID, drawn); err != nil {
+		return false, err
+	}
+	if drawn == nil {
+		return true, nil
+	}
+
+	// 新牌加入手牌
+	rtJSON, _ := json.Marshal(drawn)
+	if err := service.RedisClient.LPush(ctx, PlayerHandKey(gameID, playerID), string(rtJSON)).Err(); err != nil {
+		return false, fmt.Errorf("failed to add kong replacement to hand: %w", err)
+	}
+	state.LastDrawTile = drawn
+	utils.Info("Player%d Kong auto draw from tail: %s", playerID, models.NotationOf(*drawn))
+
+	// 四槓散了檢查 (成立時本局直接流局)
+	return checkFourKongsAbort(ctx, gameID, state)
+}
+
+// DeclareOwnKong 玩家在自己的出牌階段開槓 (Stage: PLAYER_DISCARD)
+//   - 手中有四張：暗槓，補牌後繼續出牌
+//   - 已碰過且手中有第四張：加槓，先進入 WAIT_ACTION 讓他家搶槓 (只能胡或過)，無人胡時補牌
+func DeclareOwnKong(ctx context.Context, gameID string, playerID int, tile models.Tile) (*models.GameState, error) {
+	state, err := loadActiveGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Stage != models.StagePlayerDiscard {
+		return nil, fmt.Errorf("action not allowed in current stage: %s", state.Stage)
+	}
+	if state.CurrentPlayerID != playerID {
+		return nil, fmt.Errorf("not your turn to kong, current player is %d", state.CurrentPlayerID)
+	}
+
+	hand, err := GetPlayerHand(ctx, gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	// 客戶端可能只送出牌的 ID，以手牌中的完整牌面為準
+	for _, t := range hand {
+		if t.ID == tile.ID {
+			tile = t
+			break
+		}
+	}
+
+	same := 0
+	for _, t := range hand {
+		if t.Type == tile.Type && t.Value == tile.Value {
+			same++
+		}
+	}
+
+	if same == 4 {
+		err = declareConcealedKong(ctx, gameID, state, playerID, tile)
+	} else {
+		err = declareAddedKong(ctx, gameID, state, playerID, tile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SaveGameState(ctx, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// declareConcealedKong 暗槓：手中四張移入副露並補牌
+func declareConcealedKong(ctx context.Context, gameID string, state *models.GameState, playerID int, tile models.Tile) error {
+	removed, err := RemoveTilesFromPlayerHand(ctx, gameID, playerID, 4, tile.Type, tile.Value)
+	if err != nil {
+		return fmt.Errorf("failed to declare concealed kong: %w", err)
+	}
+	meldJSON, _ := json.Marshal(models.Meld{Type: models.MeldTypeHiddenKong, Tiles: removed})
+	if err := service.RedisClient.RPush(ctx, PlayerMeldsKey(gameID, playerID), string(meldJSON)).Err(); err != nil {
+		return fmt.Errorf("failed to save meld: %w", err)
+	}
+
+	utils.Info("[Kong] Player %d concealed kong %s", playerID, models.NotationOf(tile))
+	state.IsInterrupted = true
+	_, err = drawKongReplacement(ctx, gameID, state, playerID)
+	return err
+}
+
+// declareAddedKong 加槓：把第四張牌加進碰的副露，等待他家搶槓
+func declareAddedKong(ctx context.Context, gameID string, state *models.GameState, playerID int, tile models.Tile) error {
+	melds, err := GetPlayerMelds(ctx, gameID, playerID)
+	if err != nil {
+		return err
+	}
+	index := -1
+	for i, m := range melds {
+		if m.Type == models.MeldTypePong && len(m.Tiles) > 0 && m.Tiles[0].Type == tile.Type && m.Tiles[0].Value == tile.Value {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("cannot kong %s: no concealed set or pong to add to", models.NotationOf(tile))
+	}
+
+	tile, err = RemoveTileFromPlayerHand(ctx, gameID, playerID, tile)
+	if err != nil {
+		return fmt.Errorf("failed to declare added kong: %w", err)
+	}
+	meld := models.Meld{Type: models.MeldTypeAddKong, Tiles: append(melds[index].Tiles, tile)}
+	meldJSON, _ := json.Marshal(meld)
+	if err := service.RedisClient.LSet(ctx, PlayerMeldsKey(gameID, playerID), int64(index), string(meldJSON)).Err(); err != nil {
+		return fmt.Errorf("failed to save meld: %w", err)
+	}
+
+	utils.Info("[Kong] Player %d added kong %s, waiting for robbing", playerID, models.NotationOf(tile))
+	state.IsInterrupted = true
+	state.IsRobbingKong = true
+	state.LastDiscardTile = &tile
+	state.LastDiscardPlayerID = playerID
+	state.LastDrawTile = nil
+	state.ActionDeclarations = make(map[int]string)
+	state.ClaimTiles = nil
+	state.Stage = models.StageWaitAction
+	return nil
+}
+
+// revertAddedKong 加槓的牌被搶槓胡走：副露恢復為碰，被搶的牌如同放槍的牌留在開槓者的河牌
+func revertAddedKong(ctx context.Context, gameID string, playerID int, tile models.Tile) error {
+	melds, err := GetPlayerMelds(ctx, gameID, playerID)
+	if err != nil {
+		return err
+	}
+	for i, m := range melds {
+		if m.Type != models.MeldTypeAddKong || len(m.Tiles) == 0 || m.Tiles[0].Type != tile.Type || m.Tiles[0].Value != tile.Value {
+			continue
+		}
+		var tiles []models.Tile
+		for _, t := range m.Tiles {
+			if t.ID != tile.ID {
+				tiles = append(tiles, t)
+			}
+		}
+		meldJSON, _ := json.Marshal(models.Meld{Type: models.MeldTypePong, Tiles: tiles})
+		if err := service.RedisClient.LSet(ctx, PlayerMeldsKey(gameID, playerID), int64(i), string(meldJSON)).Err(); err != nil {
+			return fmt.Errorf("failed to revert added kong: %w", err)
+		}
+		tileJSON, _ := json.Marshal(tile)
+		if err := service.RedisClient.RPush(ctx, PlayerDiscardsKey(gameID, playerID), string(tileJSON)).Err(); err != nil {
+			return fmt.Errorf("failed to save robbed kong tile: %w", err)
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"webmajiang/models"
+	"webmajiang/service"
+)
+
+// RoundResultAnnouncedKey 紀錄某局結算結果是否已廣播 (避免多個 goroutine 重複送出)
+// 帶入一將的開始時間，同一 game ID 重新開始後不會沿用上一將的紀錄
+func RoundResultAnnouncedKey(gameID string, startedAt int64, roundIndex int) string {
+	return fmt.Sprintf("game:%s:%d:round:%d:announced", gameID, startedAt, roundIndex)
+}
+
+// NextDealerID 計算下一局的莊家 (下家做莊，中途流局時連莊)
+func NextDealerID(state *models.GameState) int {
+	if isDealerRetained(state) {
+		return state.DealerPlayerID
+	}
+	return (state.DealerPlayerID % 4) + 1
+}
+
+// isDealerRetained 本局結束後是否連莊
+func isDealerRetained(state *models.GameState) bool {
+	return len(state.WinnerIDs) == 0 && state.DrawReason.RetainsDealer()
+}
+
+// finishRound 局結束時彙整攤牌、牌型、點數轉移與下一局莊家，存入 state.RoundResult
+// discarderID 為放槍者 (自摸或流局傳 0)，winningTile 於流局時傳 nil
+func finishRound(ctx context.Context, gameID string, state *models.GameState, discarderID int, winningTile *models.Tile) error {
+	result := &models.RoundResult{
+		WinnerIDs:      state.WinnerIDs,
+		DiscarderID:    discarderID,
+		WinningTile:    winningTile,
+		IsDraw:         len(state.WinnerIDs) == 0,
+		DrawReason:     state.DrawReason,
+		ScoreResults:   state.ScoreResults,
+		Transfers:      state.RoundTransfers,
+		Scores:         state.Scores,
+		NextDealerID:   NextDealerID(state),
+		DealerRetained: isDealerRetained(state),
+	}
+
+	isWinner := make(map[int]bool, len(state.WinnerIDs))
+	for _, id := range state.WinnerIDs {
+		isWinner[id] = true
+	}
+
+	for seat := 1; seat <= 4; seat++ {
+		scoreCtx, err := loadScoringContext(ctx, gameID, state, seat, models.Tile{}, false)
+		if err != nil {
+			return fmt.Errorf("failed to load player%d hand for round result: %w", seat, err)
+		}
+		result.Hands = append(result.Hands, models.RoundHand{
+			Seat:       seat,
+			ClosedHand: scoreCtx.ClosedHand,
+			Melds:      scoreCtx.Melds,
+			Flowers:    scoreCtx.Flowers,
+			Notation:   roundHandNotation(scoreCtx, winningTile, isWinner[seat]).String(),
+		})
+	}
+
+	state.RoundResult = result
+	return nil
+}
+
+// roundHandNotation 攤牌的記法；贏家以 + 標記胡牌張 (自摸時胡牌張在暗手牌中，需先取出)
+// 花牌胡牌的胡牌張已在花牌區，不另外標記
+func roundHandNotation(scoreCtx models.ScoringContext, winningTile *models.Tile, isWinner bool) models.HandNotation {
+	h := models.HandNotation{
+		Closed:  scoreCtx.ClosedHand,
+		Melds:   scoreCtx.Melds,
+		Flowers: scoreCtx.Flowers,
+	}
+	if !isWinner || winningTile == nil || winningTile.Type == models.Flower {
+		return h
+	}
+
+	for i, t := range h.Closed {
+		if t.ID == winningTile.ID {
+			h.Closed = append(append([]models.Tile{}, h.Closed[:i]...), h.Closed[i+1:]...)
+			break
+		}
+	}
+	h.WinningTile = winningTile
+	return h
+}
+
+// markRoundResultAnnounced 標記本局結算已廣播，回傳 true 代表此次呼叫取得廣播權
+func markRoundResultAnnounced(ctx context.Context, state *models.GameState) bool {
+	key := RoundResultAnnouncedKey(state.GameID, state.StartedAt, state.RoundsPlayed)
+	ok, err := service.RedisClient.SetNX(ctx, key, 1, 24*time.Hour).Result()
+	return err == nil && ok
+}
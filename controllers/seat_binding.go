@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// 座位綁定：真人玩家以登入的 JWT 透過 join_room 入座後，連線綁定到該座位
+// 只給單一座位看的資訊 (聽牌提示) 一律以綁定的座位為準，不採信請求中的座位
+
+// ErrSeatTaken 座位已由其他帳號入座
+var ErrSeatTaken = errors.New("seat is taken by another user")
+
+// seatBinding 連線綁定的遊戲與座位
+type seatBinding struct {
+	gameID string
+	seat   int
+}
+
+// WebSocket client ID → 綁定的座位
+var (
+	seatClientsMu sync.Mutex
+	seatClients   = make(map[string]seatBinding)
+)
+
+// ClaimSeat 以帳號入座：座位尚無人入座時記錄為該帳號，已由同一帳號入座時視為重新連線
+// AI 座位 (含外部 AI) 不可入座
+func ClaimSeat(ctx context.Context, gameID string, seat int, userID int64) error {
+	defer lockGame(gameID)()
+
+	state, err := loadActiveGameState(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	player, ok := state.Players[seat]
+	if !ok {
+		return fmt.Errorf("invalid seat: %d", seat)
+	}
+	if player.IsBot {
+		return fmt.Errorf("seat %d is played by a bot", seat)
+	}
+	switch player.UserID {
+	case userID:
+		return nil
+	case 0:
+		player.UserID = userID
+		state.Players[seat] = player
+		return SaveGameState(ctx, state)
+	default:
+		return ErrSeatTaken
+	}
+}
+
+// bindClientSeat 記錄連線綁定的座位，同一連線只綁定一個座位
+func bindClientSeat(clientID, gameID string, seat int) {
+	seatClientsMu.Lock()
+	old, ok := seatClients[clientID]
+	seatClients[clientID] = seatBinding{gameID: gameID, seat: seat}
+	seatClientsMu.Unlock()
+
+	if ok && old != (seatBinding{gameID: gameID, seat: seat}) {
+		SubscribeTenpaiHints(old.gameID, old.seat, clientID, false)
+	}
+}
+
+// boundSeat 取得連線在該遊戲綁定的座位
+func boundSeat(clientID, gameID string) (int, bool) {
+	seatClientsMu.Lock()
+	defer seatClientsMu.Unlock()
+	b, ok := seatClients[clientID]
+	if !ok || b.gameID != gameID {
+		return 0, false
+	}
+	return b.seat, true
+}
+
+// HandleSeatDisconnect WebSocket 斷線時解除座位綁定並取消聽牌提示 (座位仍保留給原帳號重新入座)
+func HandleSeatDisconnect(clientID string) {
+	seatClientsMu.Lock()
+	b, ok := seatClients[clientID]
+	delete(seatClients, clientID)
+	seatClientsMu.Unlock()
+
+	if ok {
+		SubscribeTenpaiHints(b.gameID, b.seat, clientID, false)
+	}
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"webmajiang/models"
+)
+
+// SimulationConfig 自我對戰模擬設定
+type SimulationConfig struct {
+	Games int              // 要打的將數
+	Seed  uint64           // 洗牌與擲骰的種子；AI 的種子由 gameID 衍生，相同設定會得到相同結果
+	Rules models.RoomRules // 房間規則，四個座位的策略取自 BotStrategies (未指定採 AIStrategy)
+}
+
+// SimulationGameID 模擬對局的 gameID (由種子與將數決定，讓 AI 的隨機選擇可重現)
+func SimulationGameID(seed uint64, game int) string {
+	return fmt.Sprintf("sim_%d_%d", seed, game)
+}
+
+// Simulate 以四家內建 AI 連續打完多將，彙整各策略的胡牌率、放槍率、流局率、平均台數與牌型頻率
+// 遊戲狀態存放在 service.RedisClient，模擬時應先以 service.InitMemoryRedis 換成記憶體儲存；
+// AI 思考時間固定為不等待
+func Simulate(ctx context.Context, cfg SimulationConfig) (*models.SimulationReport, error) {
+	rules := cfg.Rules.Normalize()
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	rules.Timers.AIThinkMillis = -1
+
+	seats := make(map[int]models.AIStrategy, 4)
+	for seat := 1; seat <= 4; seat++ {
+		seats[seat] = rules.BotStrategy(seat)
+		if seats[seat] == models.AIStrategyExternal {
+			return nil, fmt.Errorf("seat %d: external bots cannot be simulated", seat)
+		}
+	}
+
+	SetRandomSeed(cfg.Seed)
+	report := &models.SimulationReport{Seed: cfg.Seed}
+	for n := 1; n <= cfg.Games; n++ {
+		if err := simulateGame(ctx, SimulationGameID(cfg.Seed, n), rules, seats, report); err != nil {
+			return report, fmt.Errorf("game %d: %w", n, err)
+		}
+		report.Games++
+	}
+	return report, nil
+}
+
+// simulateGame 打完一將並把每局結算記錄到 report
+func simulateGame(ctx context.Context, gameID string, rules models.RoomRules, seats map[int]models.AIStrategy, report *models.SimulationReport) error {
+	defer releaseBots(gameID)
+
+	state, err := StartNewGame(ctx, gameID, rules)
+	if err != nil {
+		return err
+	}
+	for seat := 1; seat <= 4; seat++ {
+		p := state.Players[seat]
+		p.Name = fmt.Sprintf("AI %d (%s)", seat, seats[seat])
+		p.IsBot = true
+		p.Strategy = seats[seat]
+		state.Players[seat] = p
+	}
+	if err := SaveGameState(ctx, state); err != nil {
+		return err
+	}
+
+	if _, err := RollPositions(ctx, gameID); err != nil {
+		return err
+	}
+	if _, err := RollDealer(ctx, gameID); err != nil {
+		return err
+	}
+
+	for {
+		state, err := DealTilesAction(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		// 莊家開門後由 AI 推進整局 (出牌 → 各家宣告 → 下家摸牌…) 直到本局結束
+		if state.Stage != models.StageRoundOver {
+			if err := driveAI(ctx, nil, gameID); err != nil {
+				return err
+			}
+			if state, err = LoadGameState(ctx, gameID); err != nil {
+				return err
+			}
+		}
+		if state.Stage != models.StageRoundOver || state.RoundResult == nil {
+			return fmt.Errorf("round %s did not finish (stage %s)", state.Progress(), state.Stage)
+		}
+		report.RecordRound(*state.RoundResult, seats)
+
+		_, isComplete, err := NextRound(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if isComplete {
+			return nil
+		}
+	}
+}
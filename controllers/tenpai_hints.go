@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/maoxiaoyue/hypgo/pkg/websocket"
+	"google.golang.org/protobuf/proto"
+
+	"webmajiang/models"
+	"webmajiang/models/pb"
+	"webmajiang/utils"
+)
+
+// 聽牌提示訂閱：gameID → 座位 → 訂閱的 WebSocket client ID
+var (
+	hintSubscriptionsMu sync.Mutex
+	hintSubscriptions   = make(map[string]map[int]string)
+)
+
+// SubscribeTenpaiHints 開啟或關閉座位的聽牌提示 (關閉時只取消同一連線的訂閱)
+func SubscribeTenpaiHints(gameID string, seat int, clientID string, enabled bool) {
+	hintSubscriptionsMu.Lock()
+	defer hintSubscriptionsMu.Unlock()
+
+	seats, ok := hintSubscriptions[gameID]
+	if !enabled {
+		if ok && seats[seat] == clientID {
+			delete(seats, seat)
+			if len(seats) == 0 {
+				delete(hintSubscriptions, gameID)
+			}
+		}
+		return
+	}
+	if !ok {
+		seats = make(map[int]string)
+		hintSubscriptions[gameID] = seats
+	}
+	seats[seat] = clientID
+}
+
+// hintSubscriber 取得座位訂閱的 client ID
+func hintSubscriber(gameID string, seat int) (string, bool) {
+	hintSubscriptionsMu.Lock()
+	defer hintSubscriptionsMu.Unlock()
+	clientID, ok := hintSubscriptions[gameID][seat]
+	return clientID, ok
+}
+
+// BuildTenpaiHints 計算座位目前手牌每種打法的聽牌提示
+// 剩餘張數只扣除該座位看得到的牌，他家的暗槓不計入
+func BuildTenpaiHints(ctx context.Context, gameID string, state *models.GameState, seat int) ([]models.DiscardHint, error) {
+	base, err := loadScoringContext(ctx, gameID, state, seat, models.Tile{}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := GetVisibleTiles(ctx, gameID, seat)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.TenpaiHints(base.ClosedHand, visible, base), nil
+}
+
+// sendTenpaiHints 輪到已訂閱的真人玩家出牌時，私下送出聽牌提示
+func sendTenpaiHints(hub *websocket.Hub, gameID string, state *models.GameState) {
+	if state.Stage != models.StagePlayerDiscard {
+		return
+	}
+	seat := state.CurrentPlayerID
+	if player, ok := state.Players[seat]; !ok || player.IsBot || state.DeadHands[seat] {
+		return
+	}
+	clientID, ok := hintSubscriber(gameID, seat)
+	if !ok {
+		return
+	}
+
+	hints, err := BuildTenpaiHints(context.Background(), gameID, state, seat)
+	if err != nil {
+		utils.Error("[Hints] game %s seat %d: %v", gameID, seat, err)
+		return
+	}
+
+	b, _ := proto.Marshal(buildTenpaiHintsData(gameID, seat, hints))
+	outBytes, _ := proto.Marshal(&pb.WSMessage{Action: "tenpai_hints", Data: b})
+	if err := hub.SendToClient(clientID, outBytes); err != nil {
+		// 連線已中斷，取消訂閱
+		utils.Info("[Hints] drop subscription of game %s seat %d: %v", gameID, seat, err)
+		SubscribeTenpaiHints(gameID, seat, clientID, false)
+	}
+}
+
+// 幫助函數：將聽牌提示轉換為 protobuf 定義的 TenpaiHintsData
+func buildTenpaiHintsData(gameID string, seat int, hints []models.DiscardHint) *pb.TenpaiHintsData {
+	data := &pb.TenpaiHintsData{RoomId: gameID, Seat: int32(seat)}
+	for _, h := range hints {
+		hint := &pb.DiscardHintData{DiscardTileId: int32(h.Discard.ID)}
+		for _, w := range h.Waits {
+			hint.Waits = append(hint.Waits, &pb.WaitHintData{
+				TileId:    int32(w.Tile.ID),
+				Notation:  models.NotationOf(w.Tile),
+				Remaining: int32(w.Remaining),
+				Tai:       int32(w.Tai),
+			})
+		}
+		data.Hints = append(data.Hints, hint)
+	}
+	return data
+}
+
+// handleTenpaiHints 訂閱/取消聽牌提示；訂閱時若正輪到該座位出牌，立即送出一次
+// 座位取自連線以 join_room 綁定的座位，不可訂閱其他座位
+func handleTenpaiHints(ctx context.Context, client *websocket.Client, action string, data []byte) {
+	var req pb.TenpaiHintsReq
+	if err := proto.Unmarshal(data, &req); err != nil {
+		sendWSError(client, action, "invalid TenpaiHintsReq data")
+		return
+	}
+	gameID := req.RoomId
+	if gameID == "" {
+		gameID = "default_room"
+	}
+	seat, ok := boundSeat(client.ID, gameID)
+	if !ok {
+		sendWSError(client, action, "join_room with a login token before subscribing")
+		return
+	}
+	if req.Seat != 0 && int(req.Seat) != seat {
+		sendWSError(client, action, "seat does not match the joined seat")
+		return
+	}
+
+	SubscribeTenpaiHints(gameID, seat, client.ID, req.Enabled)
+	sendProtoResponse(client, action+"_res", &pb.PlayerActionRes{Success: true, Message: "聽牌提示設定完成"})
+
+	if req.Enabled {
+		if state, err := LoadGameState(ctx, gameID); err == nil && state.CurrentPlayerID == seat {
+			sendTenpaiHints(client.Hub, gameID, state)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maoxiaoyue/hypgo/pkg/websocket"
+
+	"webmajiang/models"
+	"webmajiang/utils"
+)
+
+// 每個房間同時只有一個計時器，狀態推進後重新排程會取代舊的計時器
+var (
+	roomTimersMu sync.Mutex
+	roomTimers   = make(map[string]*time.Timer)
+)
+
+// timerCheckpoint 排程時的狀態快照，計時器觸發時若狀態已推進則不動作
+type timerCheckpoint struct {
+	Stage           models.GameStage
+	CurrentPlayerID int
+	DiscardCount    int
+	RoundsPlayed    int
+}
+
+func checkpointOf(state *models.GameState) timerCheckpoint {
+	return timerCheckpoint{
+		Stage:           state.Stage,
+		CurrentPlayerID: state.CurrentPlayerID,
+		DiscardCount:    state.DiscardCount,
+		RoundsPlayed:    state.RoundsPlayed,
+	}
+}
+
+// scheduleRoomTimer 依房間規則的時限排程逾時處理
+//   - WAIT_ACTION: 真人玩家逾時未宣告視為過
+//   - PLAYER_DRAW / PLAYER_DISCARD: 輪到的真人玩家逾時自動摸牌並打出摸進的牌
+func scheduleRoomTimer(hub *websocket.Hub, gameID string, state *models.GameState) {
+	roomTimersMu.Lock()
+	defer roomTimersMu.Unlock()
+
+	if old, ok := roomTimers[gameID]; ok {
+		old.Stop()
+		delete(roomTimers, gameID)
+	}
+
+	timeout := roomTimeout(state)
+	if timeout <= 0 {
+		return
+	}
+
+	checkpoint := checkpointOf(state)
+	roomTimers[gameID] = time.AfterFunc(timeout, func() {
+		roomTimersMu.Lock()
+		delete(roomTimers, gameID)
+		roomTimersMu.Unlock()
+
+		newState, err := handleRoomTimeout(context.Background(), gameID, checkpoint)
+		if err != nil {
+			utils.Error("[Timer] room %s timeout handling failed: %v", gameID, err)
+			return
+		}
+		if newState != nil {
+			broadcastState(hub, gameID, newState)
+			scheduleAI(hub, gameID)
+		}
+	})
+}
+
+// roomTimeout 目前階段適用的時限 (不需等待真人玩家時回傳 0)
+func roomTimeout(state *models.GameState) time.Duration {
+	timers := state.Rules.Timers
+
+	switch state.Stage {
+	case models.StageWaitAction:
+		for pID, player := range state.Players {
+			if pID == state.LastDiscardPlayerID || player.IsBot {
+				continue
+			}
+			if _, declared := state.ActionDeclarations[pID]; !declared {
+				return timers.ActionTimeout()
+			}
+		}
+		return 0
+
+	case models.StagePlayerDraw, models.StagePlayerDiscard:
+		if player, ok := state.Players[state.CurrentPlayerID]; ok && !player.IsBot {
+			return timers.TurnTimeout()
+		}
+		return 0
+
+	default:
+		return 0
+	}
+}
+
+// handleRoomTimeout 執行逾時處理，狀態已推進時回傳 nil
+// 持有遊戲鎖比對快照並執行，避免與同時到達的玩家動作交錯；之後的 AI 動作由 scheduleAI 另行推進
+func handleRoomTimeout(ctx context.Context, gameID string, checkpoint timerCheckpoint) (*models.GameState, error) {
+	defer lockGame(gameID)()
+
+	state, err := loadActiveGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpointOf(state) != checkpoint {
+		return nil, nil
+	}
+
+	switch state.Stage {
+	case models.StageWaitAction:
+		return timeoutPendingDeclarations(ctx, gameID, state)
+	case models.StagePlayerDraw, models.StagePlayerDiscard:
+		return timeoutTurn(ctx, gameID, state)
+	default:
+		return nil, nil
+	}
+}
+
+// timeoutPendingDeclarations 尚未宣告的玩家一律視為過並結算
+func timeoutPendingDeclarations(ctx context.Context, gameID string, state *models.GameState) (*models.GameState, error) {
+	for pID := 1; pID <= 4; pID++ {
+		if pID == state.LastDiscardPlayerID {
+			continue
+		}
+		if _, declared := state.ActionDeclarations[pID]; declared {
+			continue
+		}
+
+		utils.Info("[Timer] 玩家 %d 宣告逾時，視為過", pID)
+		var err error
+		if state, err = PlayerDeclareAction(ctx, gameID, pID, "pass"); err != nil {
+			return nil, err
+		}
+		if state.Stage != models.StageWaitAction {
+			break
+		}
+	}
+	return state, nil
+}
+
+// timeoutTurn 輪到的玩家逾時：需摸牌時先摸牌，再打出摸進的牌 (無摸進的牌時依 AI 選牌)
+func timeoutTurn(ctx context.Context, gameID string, state *models.GameState) (*models.GameState, error) {
+	playerID := state.CurrentPlayerID
+	utils.Info("[Timer] 玩家 %d 出牌逾時，自動出牌", playerID)
+
+	if state.Stage == models.StagePlayerDraw {
+		newState, drawnTile, err := DrawTileAction(ctx, gameID, playerID)
+		if err != nil {
+			return nil, err
+		}
+		if drawnTile == nil {
+			return newState, nil // 荒莊或花牌胡牌
+		}
+		state = newState
+	}
+
+	hand, err := GetPlayerHand(ctx, gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	discard := models.GetBestDiscard(hand)
+	if state.LastDrawTile != nil {
+		for _, t := range hand {
+			if t.ID == state.LastDrawTile.ID {
+				discard = t
+				break
+			}
+		}
+	}
+
+	return DiscardTileAction(ctx, gameID, playerID, discard)
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"webmajiang/models"
 	"webmajiang/models/pb"
@@ -92,6 +93,20 @@ func HandleWebSocketMessage(client *websocket.Client, msg *websocket.Message) {
 	case "get_deck_count":
 		handleGetDeckCount(ctx, client, action, req.Data)
 
+	// === 取得牌 ID 登錄表 ===
+	case "get_tile_registry":
+		SendTileRegistry(client)
+
+	// === 訂閱/取消聽牌提示 ===
+	case "tenpai_hints":
+		handleTenpaiHints(ctx, client, action, req.Data)
+
+	// === 外部 AI 入座與回應 ===
+	case "bot_join":
+		handleBotJoin(ctx, client, action, req.Data)
+	case "bot_response":
+		handleBotResponse(client, action, req.Data)
+
 	default:
 		utils.Info("Unhandled websocket action type: %s", action)
 	}
@@ -110,6 +125,21 @@ func handleJoinRoom(ctx context.Context, client *websocket.Client, action string
 
 	gameID := joinReq.RoomId
 
+	// 帶入登入 token 時以帳號入座，連線綁定到該座位
+	if joinReq.Token != "" {
+		claims, err := utils.ParseJWT(joinReq.Token)
+		if err != nil {
+			sendWSError(client, action, "invalid token")
+			return
+		}
+		seat := int(joinReq.Seat)
+		if err := ClaimSeat(ctx, gameID, seat, claims.UserID); err != nil {
+			sendWSError(client, action, err.Error())
+			return
+		}
+		bindClientSeat(client.ID, gameID, seat)
+	}
+
 	go keepOnline(joinReq.PlayerId)
 
 	// 假設加入成功，回覆
@@ -124,6 +154,11 @@ func handleJoinRoom(ctx context.Context, client *websocket.Client, action string
 	if err == nil {
 		syncData := buildSyncStateData(gameID, state)
 		sendProtoResponse(client, "sync_state", syncData)
+
+		// 已結束的對局補送終局結果
+		if state.FinalResult != nil {
+			sendProtoResponse(client, "game_over", buildGameOverData(gameID, state.FinalResult))
+		}
 	}
 }
 
@@ -140,6 +175,7 @@ func handleRollPositions(ctx context.Context, client *websocket.Client, action s
 
 	go keepOnline(req.PlayerId)
 
+	defer lockGame(gameID)()
 	state, err := RollPositions(ctx, gameID)
 	if err != nil {
 		sendWSError(client, action, err.Error())
@@ -153,8 +189,7 @@ func handleRollPositions(ctx context.Context, client *websocket.Client, action s
 	})
 
 	// 廣播最新狀態
-	syncData := buildSyncStateData(gameID, state)
-	sendProtoBroadcast(client.Hub, "sync_state", syncData)
+	broadcastState(client.Hub, gameID, state)
 }
 
 func handleRollDealer(ctx context.Context, client *websocket.Client, action string, data []byte) {
@@ -170,6 +205,7 @@ func handleRollDealer(ctx context.Context, client *websocket.Client, action stri
 
 	go keepOnline(req.PlayerId)
 
+	defer lockGame(gameID)()
 	state, err := RollDealer(ctx, gameID)
 	if err != nil {
 		sendWSError(client, action, err.Error())
@@ -181,8 +217,7 @@ func handleRollDealer(ctx context.Context, client *websocket.Client, action stri
 		Message: fmt.Sprintf("莊家決定: 玩家 %d (骰子: %d)", state.DealerPlayerID, state.Dice.Total),
 	})
 
-	syncData := buildSyncStateData(gameID, state)
-	sendProtoBroadcast(client.Hub, "sync_state", syncData)
+	broadcastState(client.Hub, gameID, state)
 }
 
 func handleDealTiles(ctx context.Context, client *websocket.Client, action string, data []byte) {
@@ -203,6 +238,7 @@ func handleDealTiles(ctx context.Context, client *websocket.Client, action strin
 	}
 
 	// 執行發牌
+	defer lockGame(gameID)()
 	state, err := DealTilesAction(ctx, gameID)
 	if err != nil {
 		sendWSError(client, action, err.Error())
@@ -227,23 +263,10 @@ func handleDealTiles(ctx context.Context, client *websocket.Client, action strin
 	sendProtoResponse(client, "deal_tiles_res", dealRes)
 
 	// 廣播最新狀態
-	syncData := buildSyncStateData(gameID, state)
-	sendProtoBroadcast(client.Hub, "sync_state", syncData)
+	broadcastState(client.Hub, gameID, state)
 
 	// 如果莊家是 AI，自動觸發莊家出牌
-	dealer, ok := state.Players[state.CurrentPlayerID]
-	if ok && dealer.IsBot {
-		go func() {
-			if err := ProcessAITurn(context.Background(), gameID, dealer); err != nil {
-				utils.Error("[WS] AI 莊家自動出牌失敗: %v", err)
-			}
-			// 出牌後廣播最新狀態
-			if newState, err := LoadGameState(context.Background(), gameID); err == nil {
-				syncData := buildSyncStateData(gameID, newState)
-				sendProtoBroadcast(client.Hub, "sync_state", syncData)
-			}
-		}()
-	}
+	scheduleAI(client.Hub, gameID)
 }
 
 func handleSortHand(ctx context.Context, client *websocket.Client, action string, data []byte) {
@@ -262,6 +285,7 @@ func handleSortHand(ctx context.Context, client *websocket.Client, action string
 		go keepOnline(req.PlayerId)
 	}
 
+	defer lockGame(gameID)()
 	if err := SortPlayerHand(ctx, gameID, playerID); err != nil {
 		sendWSError(client, action, err.Error())
 		return
@@ -299,20 +323,24 @@ func handleDrawTile(ctx context.Context, client *websocket.Client, action string
 		go keepOnline(req.PlayerId)
 	}
 
+	defer lockGame(gameID)()
 	state, drawnTile, err := DrawTileAction(ctx, gameID, playerID)
 	if err != nil {
 		sendWSError(client, action, err.Error())
 		return
 	}
 
-	// 荒莊流局
+	// 荒莊流局或花牌胡牌
 	if drawnTile == nil {
+		msg := "荒莊流局，牌堆已空"
+		if len(state.WinnerIDs) > 0 {
+			msg = "花牌胡牌，本局結束"
+		}
 		sendProtoResponse(client, action+"_res", &pb.PlayerActionRes{
 			Success: true,
-			Message: "荒莊流局，牌堆已空",
+			Message: msg,
 		})
-		syncData := buildSyncStateData(gameID, state)
-		sendProtoBroadcast(client.Hub, "sync_state", syncData)
+		broadcastState(client.Hub, gameID, state)
 		return
 	}
 
@@ -322,8 +350,7 @@ func handleDrawTile(ctx context.Context, client *websocket.Client, action string
 	})
 
 	// 廣播最新狀態
-	syncData := buildSyncStateData(gameID, state)
-	sendProtoBroadcast(client.Hub, "sync_state", syncData)
+	broadcastState(client.Hub, gameID, state)
 }
 
 func handleDiscardTile(ctx context.Context, client *websocket.Client, action string, data []byte) {
@@ -338,7 +365,12 @@ func handleDiscardTile(ctx context.Context, client *websocket.Client, action str
 	gameID := "default_room"
 	playerID := 1
 
-	tile := models.Tile{ID: int(actionReq.TileId)}
+	tile, err := models.TileByID(int(actionReq.TileId))
+	if err != nil {
+		sendWSError(client, action, err.Error())
+		return
+	}
+	defer lockGame(gameID)()
 	state, err := DiscardTileAction(ctx, gameID, playerID, tile)
 	if err != nil {
 		sendProtoResponse(client, action+"_res", &pb.PlayerActionRes{
@@ -354,21 +386,10 @@ func handleDiscardTile(ctx context.Context, client *websocket.Client, action str
 	})
 
 	// 廣播最新狀態
-	syncData := buildSyncStateData(gameID, state)
-	sendProtoBroadcast(client.Hub, "sync_state", syncData)
+	broadcastState(client.Hub, gameID, state)
 
 	// 出牌後自動推進遊戲循環 (收集 AI 宣告等)
-	go func() {
-		newState, err := RunPostDiscard(context.Background(), gameID)
-		if err != nil {
-			utils.Error("[WS] RunPostDiscard failed: %v", err)
-			return
-		}
-		if newState != nil {
-			syncData := buildSyncStateData(gameID, newState)
-			sendProtoBroadcast(client.Hub, "sync_state", syncData)
-		}
-	}()
+	scheduleAI(client.Hub, gameID)
 }
 
 func handlePlayerAction(ctx context.Context, client *websocket.Client, action string, data []byte) {
@@ -382,14 +403,33 @@ func handlePlayerAction(ctx context.Context, client *websocket.Client, action st
 	gameID := "default_room"
 	playerID := 1
 
+	// tile_id 為 0 代表未指定 (宣告動作不需要)，其餘必須在登錄表中
+	if actionReq.ActionType == 1 || actionReq.TileId != 0 {
+		if _, err := models.TileByID(int(actionReq.TileId)); err != nil {
+			sendWSError(client, action, err.Error())
+			return
+		}
+	}
+
+	defer lockGame(gameID)()
 	var state *models.GameState
 	var err error
 
-	// action_type: 1=Discard, 2=Chow, 3=Pong, 4=Kong, 5=Hu, 6=Pass
+	// action_type: 1=Discard, 2=Chow, 3=Pong, 4=Kong, 5=Hu, 6=Pass, 7=九種九牌
 	if actionReq.ActionType == 1 {
 		// Discard (出牌) — 建議使用 discard_tile 路由
-		tile := models.Tile{ID: int(actionReq.TileId)}
+		tile, _ := models.TileByID(int(actionReq.TileId))
 		state, err = DiscardTileAction(ctx, gameID, playerID, tile)
+	} else if actionReq.ActionType == 5 && isSelfDrawTurn(ctx, gameID, playerID) {
+		// 自己的出牌階段宣告胡 → 自摸
+		state, err = DeclareSelfDrawnHu(ctx, gameID, playerID)
+	} else if actionReq.ActionType == 4 && isSelfDrawTurn(ctx, gameID, playerID) {
+		// 自己的出牌階段開槓 → 暗槓或加槓
+		tile, _ := models.TileByID(int(actionReq.TileId))
+		state, err = DeclareOwnKong(ctx, gameID, playerID, tile)
+	} else if actionReq.ActionType == 7 {
+		// 第一巡宣告九種九牌流局
+		state, err = DeclareNineTerminals(ctx, gameID, playerID)
 	} else {
 		// Declare (Chow/Pong/Kong/Hu/Pass)
 		actionStr := "pass"
@@ -405,7 +445,13 @@ func handlePlayerAction(ctx context.Context, client *websocket.Client, action st
 		case 6:
 			actionStr = "pass"
 		}
-		state, err = PlayerDeclareAction(ctx, gameID, playerID, actionStr)
+		// 吃牌時 tile_id 指定要用的一張手牌 (有多種吃法時)
+		var tiles []models.Tile
+		if actionReq.ActionType == 2 && actionReq.TileId != 0 {
+			tile, _ := models.TileByID(int(actionReq.TileId))
+			tiles = []models.Tile{tile}
+		}
+		state, err = PlayerDeclareClaim(ctx, gameID, playerID, actionStr, tiles)
 	}
 
 	if err != nil {
@@ -413,47 +459,22 @@ func handlePlayerAction(ctx context.Context, client *websocket.Client, action st
 			Success: false,
 			Message: err.Error(),
 		})
-		return
+		// 詐胡 (已依規則處罰) 或相公：胡牌記為過，本局繼續，照常廣播並推進
+		if state == nil || (!errors.Is(err, ErrFalseWin) && !errors.Is(err, ErrDeadHand)) {
+			return
+		}
+	} else {
+		sendProtoResponse(client, action+"_res", &pb.PlayerActionRes{
+			Success: true,
+			Message: "動作成功",
+		})
 	}
 
-	sendProtoResponse(client, action+"_res", &pb.PlayerActionRes{
-		Success: true,
-		Message: "動作成功",
-	})
-
 	// 廣播最新狀態
-	syncData := buildSyncStateData(gameID, state)
-	sendProtoBroadcast(client.Hub, "sync_state", syncData)
+	broadcastState(client.Hub, gameID, state)
 
-	// 如果是出牌動作，觸發遊戲循環
-	if actionReq.ActionType == 1 {
-		go func() {
-			newState, err := RunPostDiscard(context.Background(), gameID)
-			if err != nil {
-				utils.Error("[WS] RunPostDiscard failed: %v", err)
-				return
-			}
-			if newState != nil {
-				syncData := buildSyncStateData(gameID, newState)
-				sendProtoBroadcast(client.Hub, "sync_state", syncData)
-			}
-		}()
-	}
-
-	// 如果是宣告動作且結算完畢，觸發後續推進
-	if actionReq.ActionType >= 2 && state.Stage != models.StageWaitAction {
-		go func() {
-			newState, err := RunPostResolve(context.Background(), gameID)
-			if err != nil {
-				utils.Error("[WS] RunPostResolve failed: %v", err)
-				return
-			}
-			if newState != nil {
-				syncData := buildSyncStateData(gameID, newState)
-				sendProtoBroadcast(client.Hub, "sync_state", syncData)
-			}
-		}()
-	}
+	// 推進後續的 AI 動作 (收集 AI 宣告、AI 摸牌出牌等)
+	scheduleAI(client.Hub, gameID)
 }
 
 func handleNextRound(ctx context.Context, client *websocket.Client, action string, data []byte) {
@@ -469,6 +490,7 @@ func handleNextRound(ctx context.Context, client *websocket.Client, action strin
 
 	go keepOnline(req.PlayerId)
 
+	defer lockGame(gameID)()
 	state, isComplete, err := NextRound(ctx, gameID)
 	if err != nil {
 		sendWSError(client, action, err.Error())
@@ -485,8 +507,11 @@ func handleNextRound(ctx context.Context, client *websocket.Client, action strin
 		Message: msg,
 	})
 
-	syncData := buildSyncStateData(gameID, state)
-	sendProtoBroadcast(client.Hub, "sync_state", syncData)
+	broadcastState(client.Hub, gameID, state)
+
+	if isComplete && state.FinalResult != nil {
+		sendProtoBroadcast(client.Hub, "game_over", buildGameOverData(gameID, state.FinalResult))
+	}
 }
 
 func handleGetState(ctx context.Context, client *websocket.Client, action string, data []byte) {
@@ -611,33 +636,168 @@ func sendWSError(client *websocket.Client, action string, errorMsg string) {
 	})
 }
 
-// 幫助函數：將 GameState 轉換為 protobuf 定義的 SyncStateData
-func buildSyncStateData(gameID string, state *models.GameState) *pb.SyncStateData {
-	// 轉換 GameState 狀態名稱
-	gameStateStr := string(state.Stage)
-
-	// 如果處於結算階段且有結果，將台數資訊合併進 GameState string 中 (使用 JSON)
-	if state.Stage == models.StageRoundOver && state.ScoreResults != nil {
-		type RoundOverPayload struct {
-			Stage        string                     `json:"stage"`
-			ScoreResults map[int]models.ScoreResult `json:"score_results"`
+// 幫助函數：(依房間規則稽核後) 廣播最新狀態，若本局剛結束則再廣播一次 round_result，並排程下一個時限
+func broadcastState(hub *websocket.Hub, gameID string, state *models.GameState) {
+	auditAfterAction(context.Background(), gameID, state)
+
+	syncData := buildSyncStateData(gameID, state)
+	sendProtoBroadcast(hub, "sync_state", syncData)
+
+	if state.Stage == models.StageRoundOver && state.RoundResult != nil &&
+		markRoundResultAnnounced(context.Background(), state) {
+		sendProtoBroadcast(hub, "round_result", buildRoundResultData(gameID, state.RoundResult))
+	}
+
+	// 輪到已訂閱的真人玩家出牌時私下送出聽牌提示
+	sendTenpaiHints(hub, gameID, state)
+
+	// 依房間規則重新排程宣告/出牌時限
+	scheduleRoomTimer(hub, gameID, state)
+}
+
+// SendTileRegistry 下發牌 ID 登錄表 (連線時與 get_tile_registry 使用)
+func SendTileRegistry(client *websocket.Client) {
+	sendProtoResponse(client, "tile_registry", buildTileRegistryData())
+}
+
+// 幫助函數：將牌 ID 登錄表轉換為 protobuf 定義的 TileRegistryData
+func buildTileRegistryData() *pb.TileRegistryData {
+	defs := models.TileRegistry()
+	data := &pb.TileRegistryData{Tiles: make([]*pb.TileDefData, 0, len(defs))}
+	for _, def := range defs {
+		data.Tiles = append(data.Tiles, &pb.TileDefData{
+			Id:       int32(def.ID),
+			Type:     int32(def.Type),
+			Value:    int32(def.Value),
+			Copy:     int32(def.Copy),
+			Notation: def.Notation,
+			Name:     def.Name,
+		})
+	}
+	return data
+}
+
+// 幫助函數：將 Tile 切片轉換為 tile ID 列表
+func tileIDs(tiles []models.Tile) []int32 {
+	ids := make([]int32, len(tiles))
+	for i, t := range tiles {
+		ids[i] = int32(t.ID)
+	}
+	return ids
+}
+
+// 幫助函數：將副露轉換為 protobuf 定義的 MeldData
+func buildMeldData(meld models.Meld) *pb.MeldData {
+	return &pb.MeldData{
+		Type:  int32(meld.Type),
+		Tiles: tileIDs(meld.Tiles),
+	}
+}
+
+// 幫助函數：將單局結算結果轉換為 protobuf 定義的 RoundResultData
+func buildRoundResultData(gameID string, result *models.RoundResult) *pb.RoundResultData {
+	data := &pb.RoundResultData{
+		RoomId:         gameID,
+		DiscarderSeat:  int32(result.DiscarderID),
+		IsDraw:         result.IsDraw,
+		NextDealerSeat: int32(result.NextDealerID),
+		TotalScores:    make(map[int32]int32),
+		DrawReason:     string(result.DrawReason),
+		DealerRetained: result.DealerRetained,
+	}
+	if result.WinningTile != nil {
+		data.WinningTile = int32(result.WinningTile.ID)
+	}
+
+	for _, wid := range result.WinnerIDs {
+		data.WinnerSeats = append(data.WinnerSeats, int32(wid))
+
+		res := result.ScoreResults[wid]
+		score := &pb.WinnerScoreData{
+			Seat:     int32(wid),
+			TotalTai: int32(res.TotalTai),
 		}
+		for _, pt := range res.SortedPatterns() {
+			score.Patterns = append(score.Patterns, &pb.PatternData{Name: pt.Name, Tai: int32(pt.Tai)})
+		}
+		data.Scores = append(data.Scores, score)
+	}
 
-		payload := RoundOverPayload{
-			Stage:        gameStateStr,
-			ScoreResults: state.ScoreResults,
+	for _, h := range result.Hands {
+		hand := &pb.RevealedHandData{
+			Seat:     int32(h.Seat),
+			Tiles:    tileIDs(h.ClosedHand),
+			Flowers:  tileIDs(h.Flowers),
+			Notation: h.Notation,
 		}
+		for _, m := range h.Melds {
+			hand.Melds = append(hand.Melds, buildMeldData(m))
+		}
+		data.Hands = append(data.Hands, hand)
+	}
+
+	for _, t := range result.Transfers {
+		data.Transfers = append(data.Transfers, &pb.PointTransferData{
+			From:   int32(t.From),
+			To:     int32(t.To),
+			Tai:    int32(t.Tai),
+			Points: int32(t.Points),
+		})
+	}
+
+	for seat, score := range result.Scores {
+		data.TotalScores[int32(seat)] = int32(score)
+	}
 
-		if b, err := json.Marshal(payload); err == nil {
-			gameStateStr = string(b)
+	return data
+}
+
+// 幫助函數：將終局結果轉換為 protobuf 定義的 GameOverData
+func buildGameOverData(gameID string, result *models.FinalResult) *pb.GameOverData {
+	data := &pb.GameOverData{
+		RoomId:       gameID,
+		RoundsPlayed: int32(result.RoundsPlayed),
+		EndReason:    string(result.EndReason),
+	}
+
+	for _, s := range result.Standings {
+		standing := &pb.FinalStandingData{
+			Seat:            int32(s.Seat),
+			Name:            s.Name,
+			Placement:       int32(s.Placement),
+			NetPoints:       int32(s.NetPoints),
+			Wins:            int32(s.Stats.Wins),
+			DealIns:         int32(s.Stats.DealIns),
+			SelfDrawns:      int32(s.Stats.SelfDrawns),
+			BiggestTai:      int32(s.Stats.BiggestTai),
+			BiggestPatterns: make(map[string]int32),
+		}
+		for name, tai := range s.Stats.BiggestPatterns {
+			standing.BiggestPatterns[name] = int32(tai)
 		}
+		data.Standings = append(data.Standings, standing)
 	}
 
+	return data
+}
+
+// 幫助函數：判斷玩家目前是否處於自己的出牌階段 (可宣告自摸)
+func isSelfDrawTurn(ctx context.Context, gameID string, playerID int) bool {
+	state, err := LoadGameState(ctx, gameID)
+	if err != nil {
+		return false
+	}
+	return state.Stage == models.StagePlayerDiscard && state.CurrentPlayerID == playerID
+}
+
+// 幫助函數：將 GameState 轉換為 protobuf 定義的 SyncStateData
+func buildSyncStateData(gameID string, state *models.GameState) *pb.SyncStateData {
+	// 牌型與點數明細改由 round_result 訊息送出
 	syncData := &pb.SyncStateData{
 		RoomId:              gameID,
 		CurrentWind:         int32(state.Round.PrevailingWind),
 		CurrentTurnPlayerId: fmt.Sprintf("%d", state.CurrentPlayerID),
-		GameState:           gameStateStr,
+		GameState:           string(state.Stage),
 	}
 
 	// 處理多位贏家的資料傳遞
@@ -661,6 +821,21 @@ func buildSyncStateData(gameID string, state *models.GameState) *pb.SyncStateDat
 			pInfo.Name = state.Players[p].Name
 		}
 
+		// 累計分數
+		pInfo.Score = int32(state.Scores[p])
+		pInfo.IsDeadHand = state.DeadHands[p]
+
+		// 結算專用：贏家的台數與牌型
+		if state.Stage == models.StageRoundOver {
+			if res, ok := state.ScoreResults[p]; ok {
+				pInfo.TotalTai = int32(res.TotalTai)
+				pInfo.Patterns = make(map[string]int32)
+				for name, tai := range res.Patterns {
+					pInfo.Patterns[name] = int32(tai)
+				}
+			}
+		}
+
 		// (若需要，這裡可以加上棄牌或手牌數量等)
 
 		// 讀取副露 (Melds)
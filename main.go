@@ -11,6 +11,7 @@ import (
 	"github.com/maoxiaoyue/hypgo/pkg/websocket"
 
 	"webmajiang/controllers"
+	"webmajiang/models"
 	"webmajiang/routers"
 	"webmajiang/service"
 	"webmajiang/utils"
@@ -18,14 +19,25 @@ import (
 
 // AppConfig 應用程式配置（包含 Redis）
 type AppConfig struct {
-	Redis service.RedisConfig `yaml:"redis"`
-	SMTP  utils.SMTPConfig    `yaml:"smtp"`
+	Redis service.RedisConfig   `yaml:"redis"`
+	SMTP  utils.SMTPConfig      `yaml:"smtp"`
+	Bots  controllers.BotConfig `yaml:"bots"`
 	JWT   struct {
 		Secret string `yaml:"secret"`
 	} `yaml:"jwt"`
+	Roles map[string]models.Role `yaml:"roles"`
 }
 
 func main() {
+	// 子指令：webmajiang simulate (自我對戰模擬，不需設定檔與 Redis)
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		if err := runSimulate(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Simulation failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// 載入配置
 	cfg := &config.Config{}
 	loader := config.NewConfigLoader("config")
@@ -60,12 +72,24 @@ func main() {
 	defer service.CloseRedis()
 	log.Info("Redis connected at %s", appCfg.Redis.Addr)
 
+	// 載入台數表
+	if err := models.LoadTaiTables("config/tai_rules.yaml"); err != nil {
+		log.Fatal("Failed to load tai rules: %v", err)
+	}
+	log.Info("Tai tables loaded: %v", models.TaiTableNames())
+
+	// 外部 AI 設定
+	controllers.InitBots(appCfg.Bots)
+
 	// 初始化 Utils
 	utils.InitEmail(&appCfg.SMTP)
 	if appCfg.JWT.Secret == "" {
 		appCfg.JWT.Secret = "default_secret_key_change_me_in_prod"
 	}
 	utils.InitJWT(appCfg.JWT.Secret)
+	if err := models.InitRoles(appCfg.Roles); err != nil {
+		log.Fatal("Failed to load roles: %v", err)
+	}
 
 	// 建立伺服器
 	srv := server.New(cfg, log)
@@ -81,9 +105,12 @@ func main() {
 	wsHub.SetCallbacks(
 		func(client *websocket.Client) {
 			log.Info("Player connected: %s", client.ID)
+			controllers.SendTileRegistry(client)
 		},
 		func(client *websocket.Client) {
 			log.Info("Player disconnected: %s", client.ID)
+			controllers.HandleBotDisconnect(client.ID)
+			controllers.HandleSeatDisconnect(client.ID)
 		},
 		func(client *websocket.Client, msg *websocket.Message) {
 			log.Debug("Message from %s: type=%s", client.ID, msg.Type)
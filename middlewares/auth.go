@@ -30,14 +30,59 @@ func AuthRequired() hypcontext.HandlerFunc {
 			return
 		}
 
-		// Keep user online based on JWT claims
-		ctx := context.Background()
-		_ = models.KeepUserOnline(ctx, claims.UserID, claims.Username)
+		setClaims(c, claims)
+		c.Next()
+	}
+}
+
+// AuthOptional 有帶 token 時同 AuthRequired 驗證並寫入帳號資料，未帶 token 時以匿名身分繼續
+func AuthOptional() hypcontext.HandlerFunc {
+	return func(c *hypcontext.Context) {
+		authHeader := c.Request.Header.Get("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			c.JSON(http.StatusUnauthorized, map[string]interface{}{"error": "invalid authorization header"})
+			c.Abort()
+			return
+		}
 
-		// Set user data in context for subsequent handlers
-		c.Set("userID", claims.UserID)
-		c.Set("username", claims.Username)
+		claims, err := utils.ParseJWT(strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, map[string]interface{}{"error": "invalid or expired token"})
+			c.Abort()
+			return
+		}
 
+		setClaims(c, claims)
 		c.Next()
 	}
 }
+
+// setClaims 延長登入狀態，並將帳號資料寫入 context 供後續 handler 使用
+func setClaims(c *hypcontext.Context, claims *utils.Claims) {
+	// Keep user online based on JWT claims
+	ctx := context.Background()
+	_ = models.KeepUserOnline(ctx, claims.UserID, claims.Username)
+
+	c.Set("userID", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Set("role", models.Role(claims.Role))
+}
+
+// RoleRequired 限定具有指定角色的帳號，須接在 AuthRequired 之後
+func RoleRequired(roles ...models.Role) hypcontext.HandlerFunc {
+	return func(c *hypcontext.Context) {
+		role, _ := c.Get("role")
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, map[string]interface{}{"error": "insufficient role"})
+		c.Abort()
+	}
+}
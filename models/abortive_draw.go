@@ -0,0 +1,84 @@
+package models
+
+// DrawReason 流局原因 (無人胡牌結束本局)
+type DrawReason string
+
+const (
+	DrawNone          DrawReason = ""               // 未流局
+	DrawExhaustive    DrawReason = "exhaustive"     // 荒莊：牌堆摸完
+	DrawFourKongs     DrawReason = "four_kongs"     // 四槓散了：兩家以上合計開出四槓
+	DrawFourWinds     DrawReason = "four_winds"     // 四風連打：第一巡四家打出同一張風牌
+	DrawNineTerminals DrawReason = "nine_terminals" // 九種九牌：第一巡手牌有九種以上么九字牌 (13 張)
+)
+
+// String 回傳流局原因名稱
+func (d DrawReason) String() string {
+	switch d {
+	case DrawExhaustive:
+		return "荒莊"
+	case DrawFourKongs:
+		return "四槓散了"
+	case DrawFourWinds:
+		return "四風連打"
+	case DrawNineTerminals:
+		return "九種九牌"
+	default:
+		return ""
+	}
+}
+
+// RetainsDealer 流局後是否連莊 (局號不前進)
+// 荒莊照常輪莊；四槓散了、四風連打、九種九牌為中途流局，莊家重打本局
+func (d DrawReason) RetainsDealer() bool {
+	switch d {
+	case DrawFourKongs, DrawFourWinds, DrawNineTerminals:
+		return true
+	default:
+		return false
+	}
+}
+
+// AbortiveDrawRule 中途流局規則開關 (預設全部關閉)
+type AbortiveDrawRule struct {
+	FourKongs     bool `json:"four_kongs"`     // 四槓散了
+	FourWinds     bool `json:"four_winds"`     // 四風連打
+	NineTerminals bool `json:"nine_terminals"` // 九種九牌 (僅 13 張玩法)
+}
+
+// IsFourKongsAbort 是否成立四槓散了：場上合計四槓，且不是同一家開出
+// kongCounts 為各座位已開出的槓數 (明槓、暗槓、加槓)
+func IsFourKongsAbort(kongCounts map[int]int) bool {
+	total, players := 0, 0
+	for _, n := range kongCounts {
+		if n > 0 {
+			total += n
+			players++
+		}
+	}
+	return total >= 4 && players > 1
+}
+
+// IsFourWindsAbort 是否成立四風連打：第一巡四家打出的牌為同一張風牌
+func IsFourWindsAbort(firstDiscards []Tile) bool {
+	if len(firstDiscards) != 4 || firstDiscards[0].Type != Wind {
+		return false
+	}
+	for _, t := range firstDiscards[1:] {
+		if t.Type != Wind || t.Value != firstDiscards[0].Value {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNineTerminals 是否成立九種九牌：手牌 (含摸進的牌) 有九種以上不同的么九字牌
+func IsNineTerminals(hand []Tile) bool {
+	counts, _ := tileCounts(hand)
+	kinds := 0
+	for _, idx := range thirteenOrphanIndexes {
+		if counts[idx] > 0 {
+			kinds++
+		}
+	}
+	return kinds >= 9
+}
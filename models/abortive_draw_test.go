@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestIsFourKongsAbort(t *testing.T) {
+	if IsFourKongsAbort(map[int]int{1: 4}) {
+		t.Errorf("Four kongs by one player should not abort the round")
+	}
+	if !IsFourKongsAbort(map[int]int{1: 2, 3: 1, 4: 1}) {
+		t.Errorf("Four kongs by different players should abort the round")
+	}
+	if IsFourKongsAbort(map[int]int{1: 2, 2: 1}) {
+		t.Errorf("Three kongs should not abort the round")
+	}
+}
+
+func TestIsFourWindsAbort(t *testing.T) {
+	east := Tile{Type: Wind, Value: 1}
+	if !IsFourWindsAbort([]Tile{east, east, east, east}) {
+		t.Errorf("Four east winds should abort the round")
+	}
+	if IsFourWindsAbort([]Tile{east, east, east, {Type: Wind, Value: 2}}) {
+		t.Errorf("Different winds should not abort the round")
+	}
+	if IsFourWindsAbort([]Tile{east, east, east}) {
+		t.Errorf("Fewer than four discards should not abort the round")
+	}
+}
+
+func TestIsNineTerminals(t *testing.T) {
+	hand := []Tile{
+		{Type: Wan, Value: 1}, {Type: Wan, Value: 9}, {Type: Tong, Value: 1}, {Type: Tong, Value: 9},
+		{Type: Tiao, Value: 1}, {Type: Wind, Value: 1}, {Type: Wind, Value: 2}, {Type: Dragon, Value: 1},
+		{Type: Wan, Value: 2}, {Type: Wan, Value: 3}, {Type: Wan, Value: 4}, {Type: Tong, Value: 5},
+		{Type: Tong, Value: 6}, {Type: Wan, Value: 1},
+	}
+	if IsNineTerminals(hand) {
+		t.Errorf("Eight kinds should not qualify, got %v", hand)
+	}
+
+	hand[8] = Tile{Type: Dragon, Value: 3}
+	if !IsNineTerminals(hand) {
+		t.Errorf("Nine kinds should qualify, got %v", hand)
+	}
+}
+
+func TestDrawReason_RetainsDealer(t *testing.T) {
+	if DrawExhaustive.RetainsDealer() {
+		t.Errorf("Exhaustive draw should rotate the dealer")
+	}
+	for _, d := range []DrawReason{DrawFourKongs, DrawFourWinds, DrawNineTerminals} {
+		if !d.RetainsDealer() {
+			t.Errorf("%s should retain the dealer", d)
+		}
+	}
+}
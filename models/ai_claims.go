@@ -0,0 +1,200 @@
+package models
+
+// AI 吃碰槓判斷：比較宣告前後的向聽數與手牌價值，只有收益大於「保持門清」時才宣告
+//
+//	收益 = 2 × 向聽數減少 + 手牌價值增加 - 門清成本 (門清時 2) + 槓牌補牌 (1)
+//
+// 例如門清手牌一般的碰牌只前進一向聽 (2 - 2 = 0) 不碰；役牌對子碰成刻子 (2 + 1 - 2 = 1) 才碰
+
+// 宣告動作
+const (
+	ClaimPass = "pass"
+	ClaimChow = "chow"
+	ClaimPong = "pong"
+	ClaimKong = "kong"
+	ClaimHu   = "hu"
+)
+
+// ClaimDecision AI 對他人出牌的宣告決定
+type ClaimDecision struct {
+	Action string `json:"action"`          // pass / chow / pong / kong
+	Tiles  []Tile `json:"tiles,omitempty"` // 宣告使用的手牌 (吃牌時為組成順子的兩張)
+	Gain   int    `json:"gain"`            // 宣告收益 (pass 為 0)
+}
+
+// KongDecision AI 在自己回合的開槓決定
+type KongDecision struct {
+	Type MeldType `json:"type"` // MeldTypeHiddenKong (暗槓) 或 MeldTypeAddKong (加槓)
+	Tile Tile     `json:"tile"` // 開槓的牌 (手牌中的實際那張)
+}
+
+// IsConcealed 副露是否只有暗槓 (門清)
+func IsConcealed(melds []Meld) bool {
+	for _, m := range melds {
+		if m.Type != MeldTypeHiddenKong {
+			return false
+		}
+	}
+	return true
+}
+
+// ChowOptions 手牌能與 discard 組成順子的所有吃法 (每種吃法為手牌中的兩張，同牌種只列一次)
+func ChowOptions(hand []Tile, discard Tile) [][]Tile {
+	if discard.Type != Wan && discard.Type != Tong && discard.Type != Tiao {
+		return nil
+	}
+
+	find := func(value int) (Tile, bool) {
+		for _, t := range hand {
+			if t.Type == discard.Type && t.Value == value {
+				return t, true
+			}
+		}
+		return Tile{}, false
+	}
+
+	var options [][]Tile
+	for _, pair := range [][2]int{{-2, -1}, {-1, 1}, {1, 2}} {
+		a, b := discard.Value+pair[0], discard.Value+pair[1]
+		if a < 1 || b > 9 {
+			continue
+		}
+		ta, okA := find(a)
+		tb, okB := find(b)
+		if okA && okB {
+			options = append(options, []Tile{ta, tb})
+		}
+	}
+	return options
+}
+
+// SelectChowOption 依指定的手牌 (以牌種比對，可只給一張或省略) 選出第一種符合的吃法
+func SelectChowOption(options [][]Tile, wanted []Tile) ([]Tile, bool) {
+	for _, option := range options {
+		ok := true
+		for _, w := range wanted {
+			if !(w.Type == option[0].Type && w.Value == option[0].Value) && !(w.Type == option[1].Type && w.Value == option[1].Value) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return option, true
+		}
+	}
+	return nil, false
+}
+
+// matchingTiles 手牌中與 t 同牌種的牌 (最多 n 張)
+func matchingTiles(hand []Tile, t Tile, n int) []Tile {
+	var matched []Tile
+	for _, h := range hand {
+		if h.Type == t.Type && h.Value == t.Value {
+			matched = append(matched, h)
+			if len(matched) == n {
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// withoutTiles 移除指定的牌後的手牌 (以 ID 與牌種比對，每張只移除一次)
+func withoutTiles(hand []Tile, remove []Tile) []Tile {
+	removed := make([]bool, len(remove))
+	rest := make([]Tile, 0, len(hand))
+	for _, t := range hand {
+		matched := false
+		for i, r := range remove {
+			if !removed[i] && r.ID == t.ID && r.Type == t.Type && r.Value == t.Value {
+				removed[i], matched = true, true
+				break
+			}
+		}
+		if !matched {
+			rest = append(rest, t)
+		}
+	}
+	return rest
+}
+
+// claimValue 手牌 (暗牌 + 副露) 的價值估計
+func claimValue(closed []Tile, melds []Meld, view DiscardView) int {
+	counts := make([]int, 34)
+	for _, t := range closed {
+		if idx := t.ToIndex(); idx >= 0 {
+			counts[idx]++
+		}
+	}
+	view.Melds = melds
+	return handValue(counts, view)
+}
+
+// DecideClaim 決定對他人打出的牌要吃、碰、槓或過 (胡牌另行判斷)
+// view.Hand 為宣告前的暗牌 (3N+1 張)；canChow 為出牌者是否為上家
+func DecideClaim(view DiscardView, discard Tile, canChow bool) ClaimDecision {
+	before := Shanten(view.Hand, view.Melds, view.Shapes)
+	valueBefore := claimValue(view.Hand, view.Melds, view)
+	concealCost := 0
+	if IsConcealed(view.Melds) {
+		concealCost = 2
+	}
+
+	best := ClaimDecision{Action: ClaimPass}
+	consider := func(action string, used []Tile, meldType MeldType, bonus int) {
+		closed := withoutTiles(view.Hand, used)
+		melds := append(append([]Meld(nil), view.Melds...), Meld{Type: meldType, Tiles: append(append([]Tile(nil), used...), discard)})
+		after := Shanten(closed, melds, view.Shapes)
+		gain := 2*(before-after) + claimValue(closed, melds, view) - valueBefore - concealCost + bonus
+		// 收益相同時依 槓 > 碰 > 吃 (先考慮的優先)
+		if gain > 0 && gain > best.Gain {
+			best = ClaimDecision{Action: action, Tiles: used, Gain: gain}
+		}
+	}
+
+	if same := matchingTiles(view.Hand, discard, 3); len(same) == 3 {
+		consider(ClaimKong, same, MeldTypeKong, 1)
+	}
+	if same := matchingTiles(view.Hand, discard, 2); len(same) == 2 {
+		consider(ClaimPong, same, MeldTypePong, 0)
+	}
+	if canChow {
+		for _, used := range ChowOptions(view.Hand, discard) {
+			consider(ClaimChow, used, MeldTypeChow, 0)
+		}
+	}
+	return best
+}
+
+// DecideOwnKong 自己回合 (暗牌 3N+2 張) 是否暗槓或加槓：開槓後向聽數不變差才槓 (多一次補牌)
+func DecideOwnKong(view DiscardView) (KongDecision, bool) {
+	before := Shanten(view.Hand, view.Melds, view.Shapes)
+
+	tried := make(map[int]bool)
+	for _, t := range view.Hand {
+		idx := t.ToIndex()
+		if idx < 0 || tried[idx] {
+			continue
+		}
+		tried[idx] = true
+
+		// 暗槓：手中四張
+		if same := matchingTiles(view.Hand, t, 4); len(same) == 4 {
+			melds := append(append([]Meld(nil), view.Melds...), Meld{Type: MeldTypeHiddenKong, Tiles: same})
+			if Shanten(withoutTiles(view.Hand, same), melds, view.Shapes) <= before {
+				return KongDecision{Type: MeldTypeHiddenKong, Tile: t}, true
+			}
+		}
+
+		// 加槓：已碰的牌再摸到第四張
+		for _, m := range view.Melds {
+			if m.Type != MeldTypePong || len(m.Tiles) == 0 || m.Tiles[0].ToIndex() != idx {
+				continue
+			}
+			if Shanten(withoutTiles(view.Hand, []Tile{t}), view.Melds, view.Shapes) <= before {
+				return KongDecision{Type: MeldTypeAddKong, Tile: t}, true
+			}
+		}
+	}
+	return KongDecision{}, false
+}
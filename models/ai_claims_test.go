@@ -0,0 +1,81 @@
+package models
+
+import "testing"
+
+func TestChowOptions(t *testing.T) {
+	hand := MustParseTiles("12346m5z")
+	if got := ChowOptions(hand, MustParseTiles("5m")[0]); len(got) != 2 {
+		t.Errorf("Expected 2 ways to chow 5m (34m, 46m), got %d", len(got))
+	}
+	if got := ChowOptions(hand, MustParseTiles("3m")[0]); len(got) != 2 {
+		t.Errorf("Expected 2 ways to chow 3m (12m, 24m), got %d", len(got))
+	}
+	if got := ChowOptions(hand, MustParseTiles("5z")[0]); got != nil {
+		t.Errorf("Honors cannot be chowed, got %v", got)
+	}
+}
+
+func TestDecideClaim_Concealed(t *testing.T) {
+	view := DiscardView{Hand: MustParseTiles("159m46p18s55p55z9s")}
+
+	// 門清時碰一般的對子只前進一向聽，不值得放棄門清
+	if d := DecideClaim(view, MustParseTiles("5p")[0], false); d.Action != ClaimPass {
+		t.Errorf("Expected to keep the hand concealed, got %s", d.Action)
+	}
+	// 三元牌對子碰成刻子有台
+	if d := DecideClaim(view, MustParseTiles("5z")[0], false); d.Action != ClaimPong {
+		t.Errorf("Expected to pong the dragon pair, got %s", d.Action)
+	}
+
+	// 風牌只有門風或圈風才有台
+	view = DiscardView{Hand: MustParseTiles("159m46p18s55p11z9s"), SeatWind: South}
+	if d := DecideClaim(view, MustParseTiles("1z")[0], false); d.Action != ClaimPass {
+		t.Errorf("Expected to pass a non-value wind, got %s", d.Action)
+	}
+	view.SeatWind = East
+	if d := DecideClaim(view, MustParseTiles("1z")[0], false); d.Action != ClaimPong {
+		t.Errorf("Expected to pong the seat wind, got %s", d.Action)
+	}
+}
+
+func TestDecideClaim_Open(t *testing.T) {
+	// 已經副露時，吃碰能前進向聽就宣告
+	view := DiscardView{
+		Hand:  MustParseTiles("13m468p1s55p9s"),
+		Melds: []Meld{{Type: MeldTypePong, Tiles: MustParseTiles("777s")}},
+	}
+
+	d := DecideClaim(view, MustParseTiles("2m")[0], true)
+	if d.Action != ClaimChow || FormatTiles(d.Tiles) != "13m" {
+		t.Errorf("Expected to chow 2m with 13m, got %s %s", d.Action, FormatTiles(d.Tiles))
+	}
+	// 不是上家打出的牌不能吃
+	if d := DecideClaim(view, MustParseTiles("2m")[0], false); d.Action != ClaimPass {
+		t.Errorf("Expected to pass when chow is not allowed, got %s", d.Action)
+	}
+	if d := DecideClaim(view, MustParseTiles("5p")[0], false); d.Action != ClaimPong {
+		t.Errorf("Expected to pong 5p, got %s", d.Action)
+	}
+}
+
+func TestDecideOwnKong(t *testing.T) {
+	// 手中四張：暗槓
+	k, ok := DecideOwnKong(DiscardView{Hand: MustParseTiles("1111m456p789s23s55z")})
+	if !ok || k.Type != MeldTypeHiddenKong || NotationOf(k.Tile) != "1m" {
+		t.Errorf("Expected a concealed kong of 1m, got %v %v", k, ok)
+	}
+
+	// 已碰 5萬又摸到第四張：加槓
+	k, ok = DecideOwnKong(DiscardView{
+		Hand:  MustParseTiles("123m456p23s55z5m"),
+		Melds: []Meld{{Type: MeldTypePong, Tiles: MustParseTiles("555m")}},
+	})
+	if !ok || k.Type != MeldTypeAddKong || NotationOf(k.Tile) != "5m" {
+		t.Errorf("Expected an added kong of 5m, got %v %v", k, ok)
+	}
+
+	// 11113m 可拆成 111m + 13m 聽嵌張 2萬，槓了 3萬只剩單張會退向聽
+	if _, ok := DecideOwnKong(DiscardView{Hand: MustParseTiles("11113m456p789s55z")}); ok {
+		t.Error("Expected not to kong when it breaks the hand")
+	}
+}
@@ -0,0 +1,143 @@
+package models
+
+import "sort"
+
+// AIStrategy AI 策略 (難度等級，由弱到強)
+type AIStrategy string
+
+const (
+	AIStrategyBeginner   AIStrategy = "beginner"   // 新手：在最孤立的幾張中隨機出牌，有對子就碰
+	AIStrategyBasic      AIStrategy = "basic"      // 相鄰牌評分 (GetBestDiscard)
+	AIStrategyEfficiency AIStrategy = "efficiency" // 牌效：向聽數 + 有效牌張數 + 手牌價值 (GetEfficientDiscard)
+)
+
+// AIStrategies 所有內建的 AI 策略 (由弱到強)
+var AIStrategies = []AIStrategy{AIStrategyBeginner, AIStrategyBasic, AIStrategyEfficiency}
+
+// Valid 是否為內建的 AI 策略
+func (s AIStrategy) Valid() bool {
+	for _, known := range AIStrategies {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscardView AI 出牌時能看到的資訊
+type DiscardView struct {
+	Hand           []Tile         // 出牌前的暗牌 (3N+2 張)
+	Melds          []Meld         // 自己的副露
+	Visible        []Tile         // 場上已現身的牌 (四家河牌與所有副露)
+	Shapes         []SpecialShape // 本局啟用的特殊牌型
+	SeatWind       WindPosition   // 門風
+	PrevailingWind WindPosition   // 圈風
+}
+
+// GetEfficientDiscard 以牌效選出要打的牌
+//  1. 打出後向聽數最小
+//  2. 有效牌尚未現身的張數最多 (扣除河牌與副露)
+//  3. 保留手牌價值 (役牌對子、清一色/混一色傾向)
+//  4. 仍相同時依 evaluateTile 的評分，打出最孤立的牌
+func GetEfficientDiscard(view DiscardView) Tile {
+	if len(view.Hand) == 0 {
+		return Tile{}
+	}
+	for _, t := range view.Hand {
+		if t.ToIndex() == -1 {
+			return t // 花牌應已補花，保險起見直接打出
+		}
+	}
+
+	counts := make([]int, 34)
+	for _, t := range view.Hand {
+		counts[t.ToIndex()]++
+	}
+
+	type candidate struct {
+		DiscardAnalysis
+		value int
+		score int
+	}
+	var candidates []candidate
+	for _, a := range AnalyzeDiscards(view.Hand, view.Melds, view.Shapes, view.Visible) {
+		idx := a.Discard.ToIndex()
+		counts[idx]--
+		value := handValue(counts, view)
+		counts[idx]++
+		candidates = append(candidates, candidate{
+			DiscardAnalysis: a,
+			value:           value,
+			score:           evaluateTile(idx, counts),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch {
+		case a.Shanten != b.Shanten:
+			return a.Shanten < b.Shanten
+		case a.Ukeire != b.Ukeire:
+			return a.Ukeire > b.Ukeire
+		case a.value != b.value:
+			return a.value > b.value
+		default:
+			return a.score < b.score
+		}
+	})
+	return candidates[0].Discard
+}
+
+// handValue 打出後剩餘手牌的價值估計 (越高越值得保留)
+// 役牌 (三元牌、門風、圈風) 對子 +1、刻子或副露 +2；數牌只有一種花色時 +1 (混一色傾向)，且無字牌時再 +1 (清一色傾向)
+func handValue(counts []int, view DiscardView) int {
+	value := 0
+	for idx := 27; idx < 34; idx++ {
+		if counts[idx] >= 2 && isValueHonor(idx, view) {
+			value += min(counts[idx], 3) - 1
+		}
+	}
+
+	suits := make(map[int]bool)
+	honors := false
+	for idx, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if idx >= 27 {
+			honors = true
+		} else {
+			suits[idx/9] = true
+		}
+	}
+	for _, m := range view.Melds {
+		if len(m.Tiles) > 0 {
+			if idx := m.Tiles[0].ToIndex(); idx >= 27 && isValueHonor(idx, view) {
+				value += 2
+			}
+		}
+		for _, t := range m.Tiles {
+			if idx := t.ToIndex(); idx >= 27 {
+				honors = true
+			} else if idx >= 0 {
+				suits[idx/9] = true
+			}
+		}
+	}
+	if len(suits) == 1 {
+		value++
+		if !honors {
+			value++
+		}
+	}
+	return value
+}
+
+// isValueHonor 是否為有台的字牌：三元牌、門風、圈風
+func isValueHonor(idx int, view DiscardView) bool {
+	if idx >= 31 {
+		return true
+	}
+	wind := WindPosition(idx - 27 + 1)
+	return wind == view.SeatWind || wind == view.PrevailingWind
+}
@@ -56,3 +56,31 @@ func TestGetBestDiscard_EdgeTile(t *testing.T) {
 		t.Errorf("Expected to discard isolated edge tile (1 Tiao), got: %s", best.String())
 	}
 }
+
+func TestGetEfficientDiscard_KeepsShanten(t *testing.T) {
+	// 打出 5z 即聽 1萬/4萬，其他打法都會退向聽
+	view := DiscardView{Hand: MustParseTiles("23m567p123456s99p5z")}
+	if best := GetEfficientDiscard(view); NotationOf(best) != "5z" {
+		t.Errorf("Expected to discard 5z, got %s", NotationOf(best))
+	}
+}
+
+func TestGetEfficientDiscard_CountsVisibleTiles(t *testing.T) {
+	// 打 5筒 或 東 聽 2萬/5萬 (8 張)，打 3萬/4萬 單吊 (3 張)
+	// 2萬與5萬全部現身後，兩面聽已無牌可胡，應改為單吊
+	view := DiscardView{
+		Hand:    MustParseTiles("34m555p123789s111z"),
+		Visible: MustParseTiles("22225555m"),
+	}
+	if best := GetEfficientDiscard(view); best.Type != Wan {
+		t.Errorf("Expected to break the dead 34m wait, got %s", NotationOf(best))
+	}
+}
+
+func TestGetEfficientDiscard_KeepsHandValue(t *testing.T) {
+	// 打 5筒 或 東 有效牌相同；門風為東時東風刻子有台，應打 5筒
+	view := DiscardView{Hand: MustParseTiles("34m555p123789s111z"), SeatWind: East}
+	if best := GetEfficientDiscard(view); NotationOf(best) != "5p" {
+		t.Errorf("Expected to keep the seat wind triplet, got %s", NotationOf(best))
+	}
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AuditCode 牌桌稽核違規類型
+type AuditCode string
+
+const (
+	AuditMissingTile    AuditCode = "missing_tile"    // 登錄表中的牌不在牌桌上任何位置
+	AuditDuplicateTile  AuditCode = "duplicate_tile"  // 同一張牌出現在多個位置
+	AuditUnknownTile    AuditCode = "unknown_tile"    // 牌 ID 不在本局牌組中，或類型/數值與登錄表不符
+	AuditSeatTileCount  AuditCode = "seat_tile_count" // 座位的手牌張數與目前階段不符
+	AuditCurrentPlayer  AuditCode = "current_player"  // CurrentPlayerID 與階段不一致
+	AuditLastDiscard    AuditCode = "last_discard"    // WAIT_ACTION 階段缺少出牌資訊
+	AuditDeclarationErr AuditCode = "declaration"     // 出牌者不應出現在宣告列表中
+)
+
+// AuditViolation 一筆稽核違規
+type AuditViolation struct {
+	Code    AuditCode `json:"code"`
+	Seat    int       `json:"seat,omitempty"`
+	TileID  int       `json:"tile_id,omitempty"`
+	Message string    `json:"message"`
+}
+
+// TableSnapshot 某一時刻牌桌上所有牌的位置
+type TableSnapshot struct {
+	Wall     []Tile         `json:"wall"`
+	Hands    map[int][]Tile `json:"hands"`
+	Melds    map[int][]Meld `json:"melds"`
+	Flowers  map[int][]Tile `json:"flowers"`
+	Discards map[int][]Tile `json:"discards"`
+}
+
+// AuditReport 稽核結果與當時的完整快照 (快照含四家暗牌與牌堆，僅供管理者查看)
+type AuditReport struct {
+	GameID     string           `json:"game_id"`
+	Stage      GameStage        `json:"stage"`
+	Violations []AuditViolation `json:"violations"`
+	Snapshot   *TableSnapshot   `json:"snapshot,omitempty"`
+}
+
+// OK 是否沒有任何違規
+func (r AuditReport) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// AuditRule 稽核設定
+type AuditRule struct {
+	EveryAction bool `json:"every_action"` // 每次動作後自動稽核
+	Freeze      bool `json:"freeze"`       // 發現違規時凍結遊戲 (拒絕後續變更)
+}
+
+// tilesDealt 該階段牌桌上是否已有發出的牌
+func tilesDealt(stage GameStage) bool {
+	switch stage {
+	case StageReplaceFlower, StagePlayerDraw, StagePlayerDiscard, StageWaitAction, StageRoundOver:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditTable 檢查牌桌守恆與狀態一致性
+//   - 牌堆 + 手牌 + 副露 + 花牌 + 河牌 必須恰好等於本局牌組 (RegistryTiles)
+//   - 摸牌/出牌/宣告階段各座位的手牌張數 (副露每組計 3 張) 必須符合階段
+//   - CurrentPlayerID、LastDiscardPlayerID 與階段一致
+func AuditTable(state *GameState, snap TableSnapshot) []AuditViolation {
+	if !tilesDealt(state.Stage) {
+		return nil
+	}
+
+	violations := auditConservation(state.Rules.Flowers, snap)
+	violations = append(violations, auditSeatCounts(state, snap)...)
+	violations = append(violations, auditTurn(state)...)
+	return violations
+}
+
+// auditConservation 每張牌恰好出現一次
+func auditConservation(withFlowers bool, snap TableSnapshot) []AuditViolation {
+	seen := make(map[int]string)
+	var violations []AuditViolation
+
+	place := func(where string, tiles []Tile) {
+		for _, t := range tiles {
+			if !t.MatchesRegistry() || (!withFlowers && t.Type == Flower) {
+				violations = append(violations, AuditViolation{
+					Code: AuditUnknownTile, TileID: t.ID,
+					Message: fmt.Sprintf("tile %d (%s) in %s is not part of this deck", t.ID, NotationOf(t), where),
+				})
+				continue
+			}
+			if prev, ok := seen[t.ID]; ok {
+				violations = append(violations, AuditViolation{
+					Code: AuditDuplicateTile, TileID: t.ID,
+					Message: fmt.Sprintf("tile %d (%s) found in both %s and %s", t.ID, NotationOf(t), prev, where),
+				})
+				continue
+			}
+			seen[t.ID] = where
+		}
+	}
+
+	place("wall", snap.Wall)
+	for _, seat := range sortedSeats(snap) {
+		place(fmt.Sprintf("player%d hand", seat), snap.Hands[seat])
+		for _, m := range snap.Melds[seat] {
+			place(fmt.Sprintf("player%d melds", seat), m.Tiles)
+		}
+		place(fmt.Sprintf("player%d flowers", seat), snap.Flowers[seat])
+		place(fmt.Sprintf("player%d discards", seat), snap.Discards[seat])
+	}
+
+	for _, t := range RegistryTiles(withFlowers) {
+		if _, ok := seen[t.ID]; !ok {
+			violations = append(violations, AuditViolation{
+				Code: AuditMissingTile, TileID: t.ID,
+				Message: fmt.Sprintf("tile %d (%s) is missing from the table", t.ID, NotationOf(t)),
+			})
+		}
+	}
+	return violations
+}
+
+// auditSeatCounts 各座位手牌張數 (出牌者多一張)
+func auditSeatCounts(state *GameState, snap TableSnapshot) []AuditViolation {
+	switch state.Stage {
+	case StagePlayerDraw, StagePlayerDiscard, StageWaitAction:
+	default:
+		return nil
+	}
+
+	var violations []AuditViolation
+	for seat := 1; seat <= 4; seat++ {
+		want := state.Rules.GameType.HandSize()
+		if state.Stage == StagePlayerDiscard && seat == state.CurrentPlayerID {
+			want++
+		}
+		if got := SeatTileCount(snap.Hands[seat], snap.Melds[seat]); got != want {
+			violations = append(violations, AuditViolation{
+				Code: AuditSeatTileCount, Seat: seat,
+				Message: fmt.Sprintf("player%d holds %d tiles in %s, expected %d", seat, got, state.Stage, want),
+			})
+		}
+	}
+	return violations
+}
+
+// SeatTileCount 座位的有效張數：手牌 + 每組副露 3 張 (槓不多算)
+func SeatTileCount(hand []Tile, melds []Meld) int {
+	return len(hand) + 3*len(melds)
+}
+
+// auditTurn CurrentPlayerID 與出牌資訊是否符合階段
+func auditTurn(state *GameState) []AuditViolation {
+	var violations []AuditViolation
+
+	switch state.Stage {
+	case StagePlayerDraw, StagePlayerDiscard:
+		if state.CurrentPlayerID < 1 || state.CurrentPlayerID > 4 {
+			violations = append(violations, AuditViolation{
+				Code:    AuditCurrentPlayer,
+				Message: fmt.Sprintf("current player %d is not a seat in %s", state.CurrentPlayerID, state.Stage),
+			})
+		}
+
+	case StageWaitAction:
+		if state.LastDiscardTile == nil || state.LastDiscardPlayerID < 1 || state.LastDiscardPlayerID > 4 {
+			violations = append(violations, AuditViolation{
+				Code:    AuditLastDiscard,
+				Message: fmt.Sprintf("WAIT_ACTION without a discard (player %d, tile %v)", state.LastDiscardPlayerID, state.LastDiscardTile),
+			})
+		} else if state.CurrentPlayerID != state.LastDiscardPlayerID {
+			violations = append(violations, AuditViolation{
+				Code:    AuditCurrentPlayer,
+				Message: fmt.Sprintf("current player %d differs from discarder %d in WAIT_ACTION", state.CurrentPlayerID, state.LastDiscardPlayerID),
+			})
+		}
+		if _, ok := state.ActionDeclarations[state.LastDiscardPlayerID]; ok {
+			violations = append(violations, AuditViolation{
+				Code: AuditDeclarationErr, Seat: state.LastDiscardPlayerID,
+				Message: fmt.Sprintf("discarder %d has a declaration", state.LastDiscardPlayerID),
+			})
+		}
+	}
+	return violations
+}
+
+// sortedSeats 快照中出現的座位 (依座位排序，讓違規訊息穩定)
+func sortedSeats(snap TableSnapshot) []int {
+	set := make(map[int]bool)
+	for seat := range snap.Hands {
+		set[seat] = true
+	}
+	for seat := range snap.Melds {
+		set[seat] = true
+	}
+	for seat := range snap.Flowers {
+		set[seat] = true
+	}
+	for seat := range snap.Discards {
+		set[seat] = true
+	}
+	seats := make([]int, 0, len(set))
+	for seat := range set {
+		seats = append(seats, seat)
+	}
+	sort.Ints(seats)
+	return seats
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+)
+
+// dealtSnapshot 依 ID 順序把 13 張玩法的牌發給四家 (莊家 1 號多一張)，其餘留在牌堆
+func dealtSnapshot() TableSnapshot {
+	deck := RegistryTiles(false)
+	snap := TableSnapshot{
+		Hands:    make(map[int][]Tile),
+		Melds:    make(map[int][]Meld),
+		Flowers:  make(map[int][]Tile),
+		Discards: make(map[int][]Tile),
+	}
+	idx := 0
+	for seat := 1; seat <= 4; seat++ {
+		n := 13
+		if seat == 1 {
+			n = 14
+		}
+		snap.Hands[seat] = append([]Tile(nil), deck[idx:idx+n]...)
+		idx += n
+	}
+	snap.Wall = deck[idx:]
+	return snap
+}
+
+func auditState(stage GameStage) *GameState {
+	return &GameState{
+		Rules:           DefaultRoomRules(GameType13),
+		Stage:           stage,
+		CurrentPlayerID: 1,
+	}
+}
+
+func hasViolation(violations []AuditViolation, code AuditCode) bool {
+	for _, v := range violations {
+		if v.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAuditTable_CleanDeal(t *testing.T) {
+	if v := AuditTable(auditState(StagePlayerDiscard), dealtSnapshot()); len(v) != 0 {
+		t.Errorf("Expected no violations, got %v", v)
+	}
+}
+
+func TestAuditTable_Conservation(t *testing.T) {
+	snap := dealtSnapshot()
+	dup := snap.Wall[0]
+	snap.Discards[2] = []Tile{dup}
+	snap.Wall = append(snap.Wall[:1:1], snap.Wall[2:]...) // 第二張牌消失
+
+	v := AuditTable(auditState(StagePlayerDiscard), snap)
+	if !hasViolation(v, AuditDuplicateTile) || !hasViolation(v, AuditMissingTile) {
+		t.Errorf("Expected duplicate and missing tiles, got %v", v)
+	}
+
+	snap = dealtSnapshot()
+	snap.Wall = append(snap.Wall, Tile{ID: 137, Type: Flower, Value: 1})
+	if v := AuditTable(auditState(StagePlayerDiscard), snap); !hasViolation(v, AuditUnknownTile) {
+		t.Errorf("Expected flower to be rejected in a no-flower deck, got %v", v)
+	}
+}
+
+func TestAuditTable_SeatCounts(t *testing.T) {
+	snap := dealtSnapshot()
+
+	// PLAYER_DRAW 時莊家不應多一張
+	if v := AuditTable(auditState(StagePlayerDraw), snap); !hasViolation(v, AuditSeatTileCount) {
+		t.Errorf("Expected seat count violation, got %v", v)
+	}
+
+	// 碰牌後：手牌少 2 張 + 一組副露，張數不變
+	hand := snap.Hands[1]
+	snap.Melds[1] = []Meld{{Type: MeldTypePong, Tiles: hand[:3]}}
+	snap.Hands[1] = hand[3:]
+	v := AuditTable(auditState(StagePlayerDiscard), snap)
+	if hasViolation(v, AuditSeatTileCount) {
+		t.Errorf("Meld tiles should count toward the seat, got %v", v)
+	}
+}
+
+func TestAuditTable_Turn(t *testing.T) {
+	state := auditState(StageWaitAction)
+	state.CurrentPlayerID = 2
+	state.LastDiscardPlayerID = 2
+	state.ActionDeclarations = map[int]string{2: "pass"}
+
+	v := AuditTable(state, dealtSnapshot())
+	if !hasViolation(v, AuditLastDiscard) || !hasViolation(v, AuditDeclarationErr) {
+		t.Errorf("Expected missing discard and discarder declaration, got %v", v)
+	}
+
+	if v := AuditTable(auditState(StageDealing), TableSnapshot{}); v != nil {
+		t.Errorf("Audit should skip stages before dealing, got %v", v)
+	}
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+// AI 玩家介面：對他人出牌的回應、出牌、自己回合出牌前的動作 (自摸、開槓)
+// 胡牌是否成立需要台數計算，由呼叫端判斷後放在 CanHu 中
+
+// ClaimView 他家打出 (或加槓) 一張牌時 AI 能看到的資訊
+type ClaimView struct {
+	DangerView
+	Discard     Tile // 打出 (或加槓) 的牌
+	CanChow     bool // 出牌者是否為上家
+	CanHu       bool // 胡這張牌是否成立 (含起胡台數與相公)
+	RobbingKong bool // 加槓等待搶槓，只能胡或過
+}
+
+// SelfView 自己的出牌階段 (暗牌 3N+2 張) AI 能看到的資訊
+type SelfView struct {
+	DangerView
+	Drawn *Tile // 剛摸進或補進的牌 (吃碰後為 nil)
+	CanHu bool  // 自摸是否成立
+}
+
+// SelfAction 自己回合出牌前的動作
+type SelfAction struct {
+	Action string `json:"action"` // ClaimHu (自摸)、ClaimKong (暗槓/加槓) 或 ClaimPass (直接出牌)
+	Tile   Tile   `json:"tile"`   // 開槓的牌
+}
+
+// Bot AI 玩家
+type Bot interface {
+	Strategy() AIStrategy                          // 使用的策略 (難度等級)
+	RespondToDiscard(view ClaimView) ClaimDecision // 對他家出牌宣告吃、碰、槓、胡或過
+	ChooseDiscard(view DangerView) Tile            // 選出要打的牌
+	ChooseSelfAction(view SelfView) SelfAction     // 出牌前決定是否自摸或開槓
+}
+
+// NewBot 依策略建立內建 AI，未知的策略視為 basic
+// seed 供新手等級隨機選牌使用，相同 seed 會做出相同的選擇
+func NewBot(strategy AIStrategy, seed uint64) Bot {
+	switch strategy {
+	case AIStrategyBeginner:
+		return beginnerBot{rng: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+	case AIStrategyEfficiency:
+		return standardBot{strategy: AIStrategyEfficiency, discard: func(view DangerView) Tile {
+			return GetEfficientDiscard(view.DiscardView)
+		}}
+	default:
+		return standardBot{strategy: AIStrategyBasic, discard: func(view DangerView) Tile {
+			return GetBestDiscard(view.Hand)
+		}}
+	}
+}
+
+// standardBot basic 與 efficiency：依收益吃碰槓，手牌離聽牌太遠且有對手威脅時棄胡
+type standardBot struct {
+	strategy AIStrategy
+	discard  func(view DangerView) Tile // 不棄胡時的出牌方式
+}
+
+func (b standardBot) Strategy() AIStrategy { return b.strategy }
+
+func (b standardBot) RespondToDiscard(view ClaimView) ClaimDecision {
+	if view.CanHu {
+		return ClaimDecision{Action: ClaimHu}
+	}
+	if view.RobbingKong || AssessDanger(view.DangerView).Fold {
+		return ClaimDecision{Action: ClaimPass}
+	}
+	return DecideClaim(view.DiscardView, view.Discard, view.CanChow)
+}
+
+func (b standardBot) ChooseDiscard(view DangerView) Tile {
+	if AssessDanger(view).Fold {
+		return GetDefensiveDiscard(view)
+	}
+	return b.discard(view)
+}
+
+func (b standardBot) ChooseSelfAction(view SelfView) SelfAction {
+	if view.CanHu {
+		return SelfAction{Action: ClaimHu}
+	}
+	if AssessDanger(view.DangerView).Fold {
+		return SelfAction{Action: ClaimPass}
+	}
+	if kong, ok := DecideOwnKong(view.DiscardView); ok {
+		return SelfAction{Action: ClaimKong, Tile: kong.Tile}
+	}
+	return SelfAction{Action: ClaimPass}
+}
+
+// beginnerCandidates 新手等級從評分最低的幾種牌中隨機打出
+const beginnerCandidates = 3
+
+// beginnerBot 新手：能胡就胡、有對子就碰，不吃不槓也不棄胡
+type beginnerBot struct {
+	rng *rand.Rand
+}
+
+func (b beginnerBot) Strategy() AIStrategy { return AIStrategyBeginner }
+
+func (b beginnerBot) RespondToDiscard(view ClaimView) ClaimDecision {
+	if view.CanHu {
+		return ClaimDecision{Action: ClaimHu}
+	}
+	if !view.RobbingKong {
+		if same := matchingTiles(view.Hand, view.Discard, 2); len(same) == 2 {
+			return ClaimDecision{Action: ClaimPong, Tiles: same}
+		}
+	}
+	return ClaimDecision{Action: ClaimPass}
+}
+
+func (b beginnerBot) ChooseDiscard(view DangerView) Tile {
+	if len(view.Hand) == 0 {
+		return Tile{}
+	}
+
+	counts := make([]int, 34)
+	for _, t := range view.Hand {
+		idx := t.ToIndex()
+		if idx == -1 {
+			return t // 花牌應已補花，保險起見直接打出
+		}
+		counts[idx]++
+	}
+
+	type candidate struct {
+		tile  Tile
+		score int
+	}
+	var candidates []candidate
+	seen := make(map[int]bool)
+	for _, t := range view.Hand {
+		idx := t.ToIndex()
+		if seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		candidates = append(candidates, candidate{tile: t, score: evaluateTile(idx, counts)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score < candidates[j].score
+	})
+
+	n := min(beginnerCandidates, len(candidates))
+	return candidates[b.rng.IntN(n)].tile
+}
+
+func (b beginnerBot) ChooseSelfAction(view SelfView) SelfAction {
+	if view.CanHu {
+		return SelfAction{Action: ClaimHu}
+	}
+	return SelfAction{Action: ClaimPass}
+}
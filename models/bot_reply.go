@@ -0,0 +1,129 @@
+package models
+
+import "fmt"
+
+// AIStrategyExternal 外部 AI (透過 WebSocket 或本機子程序連入)，逾時或動作不合法時由房間設定的內建 AI 代打
+const AIStrategyExternal AIStrategy = "external"
+
+// BotReply 外部 AI 的回應，牌以登錄表 ID 表示
+type BotReply struct {
+	Action  string // claim: pass/chow/pong/kong/hu；self: pass/kong/hu；discard: discard
+	TileIDs []int  // 吃牌用的手牌 (可省略或只給一張)、開槓或打出的牌
+}
+
+// handTile 在暗牌中找出指定 ID 的牌
+func handTile(hand []Tile, id int) (Tile, bool) {
+	for _, t := range hand {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Tile{}, false
+}
+
+// handTiles 依 ID 取出暗牌中的牌，任一張不在手中即失敗
+func handTiles(hand []Tile, ids []int) ([]Tile, error) {
+	tiles := make([]Tile, 0, len(ids))
+	for _, id := range ids {
+		t, ok := handTile(withoutTiles(hand, tiles), id)
+		if !ok {
+			return nil, fmt.Errorf("tile %d is not in hand", id)
+		}
+		tiles = append(tiles, t)
+	}
+	return tiles, nil
+}
+
+// Claim 檢查對他家出牌的宣告並轉為 ClaimDecision
+// 碰/槓未指定手牌時自動選取，吃牌依指定的手牌選出吃法
+func (r BotReply) Claim(view ClaimView) (ClaimDecision, error) {
+	switch r.Action {
+	case ClaimPass:
+		return ClaimDecision{Action: ClaimPass}, nil
+	case ClaimHu:
+		if !view.CanHu {
+			return ClaimDecision{}, fmt.Errorf("hu on %s is not a winning hand", NotationOf(view.Discard))
+		}
+		return ClaimDecision{Action: ClaimHu}, nil
+	}
+	if view.RobbingKong {
+		return ClaimDecision{}, fmt.Errorf("only hu or pass is allowed on an added kong")
+	}
+
+	wanted, err := handTiles(view.Hand, r.TileIDs)
+	if err != nil {
+		return ClaimDecision{}, err
+	}
+
+	switch r.Action {
+	case ClaimPong, ClaimKong:
+		need := 2
+		if r.Action == ClaimKong {
+			need = 3
+		}
+		for _, t := range wanted {
+			if t.Type != view.Discard.Type || t.Value != view.Discard.Value {
+				return ClaimDecision{}, fmt.Errorf("%s does not match %s", NotationOf(t), NotationOf(view.Discard))
+			}
+		}
+		same := matchingTiles(view.Hand, view.Discard, need)
+		if len(same) < need {
+			return ClaimDecision{}, fmt.Errorf("cannot %s %s: only %d matching tiles in hand", r.Action, NotationOf(view.Discard), len(same))
+		}
+		return ClaimDecision{Action: r.Action, Tiles: same}, nil
+
+	case ClaimChow:
+		if !view.CanChow {
+			return ClaimDecision{}, fmt.Errorf("can only chow from the previous seat")
+		}
+		option, ok := SelectChowOption(ChowOptions(view.Hand, view.Discard), wanted)
+		if !ok {
+			return ClaimDecision{}, fmt.Errorf("cannot chow %s with the given tiles", NotationOf(view.Discard))
+		}
+		return ClaimDecision{Action: ClaimChow, Tiles: option}, nil
+	}
+	return ClaimDecision{}, fmt.Errorf("unknown claim action: %s", r.Action)
+}
+
+// Discard 檢查要打出的牌是否在手中
+func (r BotReply) Discard(view DangerView) (Tile, error) {
+	if r.Action != "discard" || len(r.TileIDs) != 1 {
+		return Tile{}, fmt.Errorf("discard must name exactly one tile")
+	}
+	t, ok := handTile(view.Hand, r.TileIDs[0])
+	if !ok {
+		return Tile{}, fmt.Errorf("tile %d is not in hand", r.TileIDs[0])
+	}
+	return t, nil
+}
+
+// Self 檢查出牌前的自摸或開槓
+func (r BotReply) Self(view SelfView) (SelfAction, error) {
+	switch r.Action {
+	case ClaimPass:
+		return SelfAction{Action: ClaimPass}, nil
+	case ClaimHu:
+		if !view.CanHu {
+			return SelfAction{}, fmt.Errorf("self-drawn hu is not a winning hand")
+		}
+		return SelfAction{Action: ClaimHu}, nil
+	case ClaimKong:
+		if len(r.TileIDs) != 1 {
+			return SelfAction{}, fmt.Errorf("kong must name exactly one tile")
+		}
+		t, ok := handTile(view.Hand, r.TileIDs[0])
+		if !ok {
+			return SelfAction{}, fmt.Errorf("tile %d is not in hand", r.TileIDs[0])
+		}
+		if len(matchingTiles(view.Hand, t, 4)) == 4 {
+			return SelfAction{Action: ClaimKong, Tile: t}, nil
+		}
+		for _, m := range view.Melds {
+			if m.Type == MeldTypePong && len(m.Tiles) > 0 && m.Tiles[0].Type == t.Type && m.Tiles[0].Value == t.Value {
+				return SelfAction{Action: ClaimKong, Tile: t}, nil
+			}
+		}
+		return SelfAction{}, fmt.Errorf("cannot kong %s: no concealed set or pong to add to", NotationOf(t))
+	}
+	return SelfAction{}, fmt.Errorf("unknown self action: %s", r.Action)
+}
@@ -0,0 +1,80 @@
+package models
+
+import "testing"
+
+// numbered 依序給牌編上不重複的 ID (1 起)
+func numbered(tiles []Tile) []Tile {
+	for i := range tiles {
+		tiles[i].ID = i + 1
+	}
+	return tiles
+}
+
+func TestBotReply_Claim(t *testing.T) {
+	hand := numbered(MustParseTiles("2346m55p19s1z"))
+	view := ClaimView{
+		DangerView: DangerView{DiscardView: DiscardView{Hand: hand}},
+		Discard:    MustParseTiles("5p")[0],
+		CanChow:    true,
+	}
+
+	d, err := BotReply{Action: ClaimPong}.Claim(view)
+	if err != nil || d.Action != ClaimPong || FormatTiles(d.Tiles) != "55p" {
+		t.Errorf("Expected pong with 55p, got %v %v", d, err)
+	}
+	if _, err := (BotReply{Action: ClaimKong}).Claim(view); err == nil {
+		t.Error("Expected kong with only two matching tiles to be rejected")
+	}
+	if _, err := (BotReply{Action: ClaimHu}).Claim(view); err == nil {
+		t.Error("Expected hu on a non-winning hand to be rejected")
+	}
+	if _, err := (BotReply{Action: ClaimPong, TileIDs: []int{99}}).Claim(view); err == nil {
+		t.Error("Expected a tile outside the hand to be rejected")
+	}
+
+	// 吃 5萬：指定 6萬 時選 46m，只能吃上家
+	view.Discard = MustParseTiles("5m")[0]
+	d, err = BotReply{Action: ClaimChow, TileIDs: []int{4}}.Claim(view)
+	if err != nil || d.Action != ClaimChow || FormatTiles(d.Tiles) != "46m" {
+		t.Errorf("Expected chow with 46m, got %v %v", d, err)
+	}
+	view.CanChow = false
+	if _, err := (BotReply{Action: ClaimChow}).Claim(view); err == nil {
+		t.Error("Expected chow from a non-previous seat to be rejected")
+	}
+
+	// 搶槓只能胡或過
+	view.Discard, view.RobbingKong = MustParseTiles("5p")[0], true
+	if _, err := (BotReply{Action: ClaimPong}).Claim(view); err == nil {
+		t.Error("Expected pong on an added kong to be rejected")
+	}
+	if d, err := (BotReply{Action: ClaimPass}).Claim(view); err != nil || d.Action != ClaimPass {
+		t.Errorf("Expected pass to be accepted, got %v %v", d, err)
+	}
+}
+
+func TestBotReply_DiscardAndSelf(t *testing.T) {
+	hand := numbered(MustParseTiles("1111m456p789s23s55z"))
+	view := DangerView{DiscardView: DiscardView{Hand: hand}}
+
+	if tile, err := (BotReply{Action: "discard", TileIDs: []int{14}}).Discard(view); err != nil || NotationOf(tile) != "5z" {
+		t.Errorf("Expected to discard 5z, got %s %v", NotationOf(tile), err)
+	}
+	if _, err := (BotReply{Action: "discard", TileIDs: []int{99}}).Discard(view); err == nil {
+		t.Error("Expected a tile outside the hand to be rejected")
+	}
+	if _, err := (BotReply{Action: ClaimPass}).Discard(view); err == nil {
+		t.Error("Expected a discard without a tile to be rejected")
+	}
+
+	self := SelfView{DangerView: view}
+	if a, err := (BotReply{Action: ClaimKong, TileIDs: []int{2}}).Self(self); err != nil || a.Action != ClaimKong || NotationOf(a.Tile) != "1m" {
+		t.Errorf("Expected a concealed kong of 1m, got %v %v", a, err)
+	}
+	if _, err := (BotReply{Action: ClaimKong, TileIDs: []int{13}}).Self(self); err == nil {
+		t.Error("Expected kong of a pair to be rejected")
+	}
+	if _, err := (BotReply{Action: ClaimHu}).Self(self); err == nil {
+		t.Error("Expected self-drawn hu on a non-winning hand to be rejected")
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import "testing"
+
+func TestNewBot_Strategies(t *testing.T) {
+	for _, s := range AIStrategies {
+		if got := NewBot(s, 1).Strategy(); got != s {
+			t.Errorf("Expected bot with strategy %s, got %s", s, got)
+		}
+	}
+	if got := NewBot("unknown", 1).Strategy(); got != AIStrategyBasic {
+		t.Errorf("Expected unknown strategy to fall back to basic, got %s", got)
+	}
+}
+
+func TestBeginnerBot_Discard(t *testing.T) {
+	// 評分最低的三種牌為孤立的字牌，新手只會在其中隨機選
+	view := DangerView{DiscardView: DiscardView{Hand: MustParseTiles("123m456p789s55s1234z")}}
+	picked := make(map[string]bool)
+	for seed := uint64(0); seed < 32; seed++ {
+		tile := NewBot(AIStrategyBeginner, seed).ChooseDiscard(view)
+		if tile.Type != Wind {
+			t.Fatalf("Expected an isolated wind, got %s", NotationOf(tile))
+		}
+		picked[NotationOf(tile)] = true
+	}
+	if len(picked) < 2 {
+		t.Errorf("Expected beginner discards to vary with the seed, got %v", picked)
+	}
+
+	// 相同 seed 做出相同選擇
+	a := NewBot(AIStrategyBeginner, 7).ChooseDiscard(view)
+	b := NewBot(AIStrategyBeginner, 7).ChooseDiscard(view)
+	if a.ToIndex() != b.ToIndex() {
+		t.Errorf("Expected the same choice for the same seed, got %s and %s", NotationOf(a), NotationOf(b))
+	}
+}
+
+func TestBots_RespondToDiscard(t *testing.T) {
+	view := ClaimView{
+		DangerView: DangerView{DiscardView: DiscardView{Hand: MustParseTiles("159m46p18s55p55z9s")}},
+		Discard:    MustParseTiles("5p")[0],
+	}
+
+	// 新手有對子就碰，普通等級保持門清
+	if d := NewBot(AIStrategyBeginner, 1).RespondToDiscard(view); d.Action != ClaimPong {
+		t.Errorf("Expected beginner to pong, got %s", d.Action)
+	}
+	if d := NewBot(AIStrategyBasic, 1).RespondToDiscard(view); d.Action != ClaimPass {
+		t.Errorf("Expected basic bot to stay concealed, got %s", d.Action)
+	}
+
+	// 能胡一定胡；搶槓時只能胡或過
+	view.CanHu = true
+	for _, s := range AIStrategies {
+		if d := NewBot(s, 1).RespondToDiscard(view); d.Action != ClaimHu {
+			t.Errorf("Expected %s bot to hu, got %s", s, d.Action)
+		}
+	}
+	view.CanHu, view.RobbingKong = false, true
+	for _, s := range AIStrategies {
+		if d := NewBot(s, 1).RespondToDiscard(view); d.Action != ClaimPass {
+			t.Errorf("Expected %s bot to pass on an added kong, got %s", s, d.Action)
+		}
+	}
+}
+
+func TestBots_ChooseSelfAction(t *testing.T) {
+	view := SelfView{DangerView: DangerView{DiscardView: DiscardView{Hand: MustParseTiles("1111m456p789s23s55z")}}}
+
+	if a := NewBot(AIStrategyEfficiency, 1).ChooseSelfAction(view); a.Action != ClaimKong || NotationOf(a.Tile) != "1m" {
+		t.Errorf("Expected efficiency bot to kong 1m, got %s %s", a.Action, NotationOf(a.Tile))
+	}
+	if a := NewBot(AIStrategyBeginner, 1).ChooseSelfAction(view); a.Action != ClaimPass {
+		t.Errorf("Expected beginner not to kong, got %s", a.Action)
+	}
+
+	view.CanHu = true
+	if a := NewBot(AIStrategyBasic, 1).ChooseSelfAction(view); a.Action != ClaimHu {
+		t.Errorf("Expected basic bot to declare self-drawn hu, got %s", a.Action)
+	}
+}
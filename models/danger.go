@@ -0,0 +1,303 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 防守：估計每張牌對各對手的放槍危險度，手牌離聽牌太遠且對手有威脅時棄胡
+//
+//	威脅度 (0-100)：副露數、副露是否集中在單一花色 (清/混一色)、巡目
+//	危險度 (0-100)：牌本身的基礎危險 (現物、筋、字牌現身張數、壁) × 對手的威脅度
+//
+// 台灣麻將沒有振聽，現物與筋都不是絕對安全，只降低危險度
+
+// 棄胡門檻
+const (
+	FoldThreat       = 60 // 對手威脅度達此值且向聽數 ≥ 2 時棄胡
+	FoldThreatSevere = 85 // 對手威脅度達此值且向聽數 ≥ 1 時棄胡
+)
+
+// OpponentView 對手公開的資訊
+type OpponentView struct {
+	Seat     int    // 座位 (1-4)
+	Discards []Tile // 河牌 (依打出順序)
+	Melds    []Meld // 副露 (暗槓只看得到有一組，Tiles 可為空)
+}
+
+// DangerView 評估危險度時能看到的資訊 (Hand、Melds、Visible 等同出牌時的 DiscardView)
+type DangerView struct {
+	DiscardView
+	Seat      int            // 自己的座位
+	GameType  GameType       // 13 或 16 張 (決定對手還差幾組)
+	Opponents []OpponentView // 其他三家
+}
+
+// OpponentThreat 對手的威脅度
+type OpponentThreat struct {
+	Seat      int       `json:"seat"`
+	Threat    int       `json:"threat"`               // 0-100
+	FlushSuit *TileType `json:"flush_suit,omitempty"` // 副露顯示正在做一色的花色
+	Reasons   []string  `json:"reasons"`              // 威脅來源說明
+}
+
+// TileDanger 一張手牌的危險度
+type TileDanger struct {
+	Tile   Tile        `json:"tile"`
+	Danger int         `json:"danger"`  // 對各對手危險度的最大值 (0-100)
+	BySeat map[int]int `json:"by_seat"` // 對各對手的危險度
+}
+
+// DangerReport 危險度評估結果
+type DangerReport struct {
+	Seat    int              `json:"seat"`
+	Shanten int              `json:"shanten"` // 自己手牌的向聽數
+	Fold    bool             `json:"fold"`    // 是否建議棄胡
+	Threats []OpponentThreat `json:"threats"`
+	Tiles   []TileDanger     `json:"tiles"` // 依危險度由低到高排序，同一牌種只列一次
+}
+
+// AssessDanger 評估對手威脅度與每種手牌的危險度，並判斷是否棄胡
+func AssessDanger(view DangerView) DangerReport {
+	report := DangerReport{
+		Seat:    view.Seat,
+		Shanten: Shanten(view.Hand, view.Melds, view.Shapes),
+	}
+
+	// 自己看得到的張數：場上已現身 + 自己的暗牌
+	seen, _ := countTable(view.Visible)
+	own, _ := countTable(view.Hand)
+	for idx := range seen {
+		seen[idx] += own[idx]
+	}
+
+	for _, opp := range view.Opponents {
+		report.Threats = append(report.Threats, assessThreat(opp, view.GameType))
+	}
+
+	tried := make(map[int]bool)
+	for _, t := range view.Hand {
+		idx := t.ToIndex()
+		if idx < 0 || tried[idx] {
+			continue
+		}
+		tried[idx] = true
+
+		td := TileDanger{Tile: t, BySeat: make(map[int]int, len(view.Opponents))}
+		for i, opp := range view.Opponents {
+			threat := report.Threats[i]
+			d := baseDanger(idx, opp, threat, &seen) * threat.Threat / 100
+			td.BySeat[opp.Seat] = d
+			if d > td.Danger {
+				td.Danger = d
+			}
+		}
+		report.Tiles = append(report.Tiles, td)
+	}
+	sort.SliceStable(report.Tiles, func(i, j int) bool {
+		return report.Tiles[i].Danger < report.Tiles[j].Danger
+	})
+
+	report.Fold = ShouldFold(report.Shanten, report.Threats)
+	return report
+}
+
+// ShouldFold 手牌離聽牌太遠且有對手威脅夠高時棄胡
+func ShouldFold(shanten int, threats []OpponentThreat) bool {
+	maxThreat := 0
+	for _, t := range threats {
+		if t.Threat > maxThreat {
+			maxThreat = t.Threat
+		}
+	}
+	return (shanten >= 2 && maxThreat >= FoldThreat) || (shanten >= 1 && maxThreat >= FoldThreatSevere)
+}
+
+// GetDefensiveDiscard 棄胡時打出危險度最低的牌，相同時保留牌效 (打出後向聽數較小者)
+func GetDefensiveDiscard(view DangerView) Tile {
+	report := AssessDanger(view)
+	if len(report.Tiles) == 0 {
+		return GetEfficientDiscard(view.DiscardView)
+	}
+
+	shanten := make(map[int]int)
+	for _, a := range AnalyzeDiscards(view.Hand, view.Melds, view.Shapes, view.Visible) {
+		shanten[a.Discard.ToIndex()] = a.Shanten
+	}
+
+	best := report.Tiles[0]
+	for _, td := range report.Tiles[1:] {
+		if td.Danger > best.Danger {
+			break
+		}
+		if shanten[td.Tile.ToIndex()] < shanten[best.Tile.ToIndex()] {
+			best = td
+		}
+	}
+	return best.Tile
+}
+
+// assessThreat 依副露與巡目估計對手的威脅度
+func assessThreat(opp OpponentView, gameType GameType) OpponentThreat {
+	threat := OpponentThreat{Seat: opp.Seat}
+	sets := gameType.HandSize() / 3
+
+	// 副露：每組 +20，只差一組面子時再 +20
+	if n := len(opp.Melds); n > 0 {
+		threat.Threat += 20 * n
+		threat.Reasons = append(threat.Reasons, fmt.Sprintf("副露 %d 組", n))
+		if n >= sets-1 {
+			threat.Threat += 20
+			threat.Reasons = append(threat.Reasons, "只差一組面子")
+		}
+	}
+
+	// 一色：看得到的副露都是同一種數牌 (可夾字牌)，且河牌中該花色很少
+	if suit, ok := meldFlushSuit(opp.Melds); ok && len(opp.Melds) >= 2 {
+		threat.FlushSuit = &suit
+		threat.Threat += 15
+		inSuit := 0
+		for _, t := range opp.Discards {
+			if t.Type == suit {
+				inSuit++
+			}
+		}
+		if len(opp.Discards) >= 6 && inSuit*6 <= len(opp.Discards) {
+			threat.Threat += 10
+		}
+		threat.Reasons = append(threat.Reasons, "疑似一色 ("+suit.String()+")")
+	}
+
+	// 巡目：打出超過 6 張後每張 +2，最多 +20
+	if late := len(opp.Discards) - 6; late > 0 {
+		threat.Threat += min(2*late, 20)
+		threat.Reasons = append(threat.Reasons, fmt.Sprintf("已打出 %d 張", len(opp.Discards)))
+	}
+
+	threat.Threat = min(threat.Threat, 100)
+	return threat
+}
+
+// meldFlushSuit 副露中出現的數牌是否只有一種花色 (暗槓看不到的不算)
+func meldFlushSuit(melds []Meld) (TileType, bool) {
+	suit, found := TileType(0), false
+	for _, m := range melds {
+		for _, t := range m.Tiles {
+			if t.Type != Wan && t.Type != Tong && t.Type != Tiao {
+				continue
+			}
+			if found && t.Type != suit {
+				return 0, false
+			}
+			suit, found = t.Type, true
+		}
+	}
+	return suit, found
+}
+
+// baseDanger 不考慮威脅度時，打出牌種 idx 對該對手的危險度 (0-100)
+// seen: 自己看得到的各牌種張數 (場上已現身 + 自己的暗牌)
+func baseDanger(idx int, opp OpponentView, threat OpponentThreat, seen *tileCountTable) int {
+	// 現物：對手自己打過的牌
+	for _, t := range opp.Discards {
+		if t.ToIndex() == idx {
+			return 5
+		}
+	}
+
+	if idx >= 27 {
+		return honorDanger(4 - seen[idx])
+	}
+
+	danger := 0
+	switch v := idx%9 + 1; {
+	case v == 1 || v == 9:
+		danger = 40
+	case v == 2 || v == 8:
+		danger = 55
+	default:
+		danger = 70
+	}
+
+	// 壁：所有能聽這張的順子搭子都缺牌 (某張已全部現身)，只剩單吊/雙碰
+	if !sequenceWaitPossible(idx, seen) {
+		danger = min(danger, honorDanger(4-seen[idx]))
+	}
+
+	// 筋：對手打過相隔三張的牌 (例如打過 4，則 1、7 較安全)
+	danger = danger * sujiFactor(idx, opp.Discards) / 100
+
+	// 一色：同花色更危險，其他花色數牌較安全
+	if threat.FlushSuit != nil {
+		if TileFromIndex(idx).Type == *threat.FlushSuit {
+			danger = danger * 3 / 2
+		} else {
+			danger = danger * 3 / 10
+		}
+	}
+	return min(danger, 100)
+}
+
+// honorDanger 只能單吊或雙碰的牌依尚未現身的張數估計危險度
+func honorDanger(unseen int) int {
+	switch {
+	case unseen <= 0:
+		return 0
+	case unseen == 1:
+		return 5 // 只剩單吊
+	case unseen == 2:
+		return 20
+	default:
+		return 35
+	}
+}
+
+// sequenceWaitPossible 牌種 idx 是否還可能被順子搭子聽到 (搭子的牌都還有未現身的)
+func sequenceWaitPossible(idx int, seen *tileCountTable) bool {
+	v := idx%9 + 1
+	for _, pair := range [][2]int{{-2, -1}, {-1, 1}, {1, 2}} {
+		a, b := v+pair[0], v+pair[1]
+		if a < 1 || b > 9 {
+			continue
+		}
+		if seen[idx-v+a] < 4 && seen[idx-v+b] < 4 {
+			return true
+		}
+	}
+	return false
+}
+
+// sujiFactor 筋的折減 (百分比)：需要的兩側都打過為 50，兩側之一打過為 75
+func sujiFactor(idx int, discards []Tile) int {
+	v := idx%9 + 1
+	discarded := func(value int) bool {
+		for _, t := range discards {
+			if t.ToIndex() == idx-v+value {
+				return true
+			}
+		}
+		return false
+	}
+
+	var sides []bool
+	if v >= 4 {
+		sides = append(sides, discarded(v-3))
+	}
+	if v <= 6 {
+		sides = append(sides, discarded(v+3))
+	}
+	covered := 0
+	for _, s := range sides {
+		if s {
+			covered++
+		}
+	}
+	switch {
+	case covered == len(sides):
+		return 50
+	case covered > 0:
+		return 75
+	default:
+		return 100
+	}
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"testing"
+)
+
+// flushOpponent 碰了兩組萬子、河牌沒有萬子的對手
+func flushOpponent(seat int) OpponentView {
+	return OpponentView{
+		Seat:     seat,
+		Discards: MustParseTiles("19p2468s1234z5p7s"),
+		Melds: []Meld{
+			{Type: MeldTypePong, Tiles: MustParseTiles("222m")},
+			{Type: MeldTypePong, Tiles: MustParseTiles("888m")},
+		},
+	}
+}
+
+func dangerOf(report DangerReport, notation string) int {
+	for _, td := range report.Tiles {
+		if NotationOf(td.Tile) == notation {
+			return td.Danger
+		}
+	}
+	return -1
+}
+
+func TestAssessDanger_Threat(t *testing.T) {
+	report := AssessDanger(DangerView{
+		DiscardView: DiscardView{Hand: MustParseTiles("5m5s")},
+		GameType:    GameType16,
+		Opponents:   []OpponentView{flushOpponent(2), {Seat: 3}},
+	})
+
+	flush := report.Threats[0]
+	if flush.FlushSuit == nil || *flush.FlushSuit != Wan || flush.Threat < FoldThreat {
+		t.Errorf("Expected a threatening wan flush, got %+v", flush)
+	}
+	if quiet := report.Threats[1]; quiet.Threat != 0 {
+		t.Errorf("Expected no threat from a quiet opponent, got %+v", quiet)
+	}
+	if dangerOf(report, "5m") <= dangerOf(report, "5s") {
+		t.Errorf("Flush suit should be more dangerous: %+v", report.Tiles)
+	}
+}
+
+func TestAssessDanger_SafeTiles(t *testing.T) {
+	report := AssessDanger(DangerView{
+		DiscardView: DiscardView{
+			Hand:    MustParseTiles("1z5z7p4s"),
+			Visible: MustParseTiles("555z"),
+		},
+		GameType:  GameType16,
+		Opponents: []OpponentView{flushOpponent(2)},
+	})
+
+	if d := dangerOf(report, "5z"); d != 0 {
+		t.Errorf("All four 5z are visible, expected 0 danger, got %d", d)
+	}
+	// 1z 是對手打過的現物，7筒 沒有筋
+	if genbutsu, other := dangerOf(report, "1z"), dangerOf(report, "7p"); genbutsu >= other {
+		t.Errorf("Genbutsu 1z should be safer than 7p: %d vs %d", genbutsu, other)
+	}
+	if report.Tiles[0].Danger > report.Tiles[len(report.Tiles)-1].Danger {
+		t.Errorf("Tiles should be sorted by danger: %+v", report.Tiles)
+	}
+}
+
+func TestSujiAndWall(t *testing.T) {
+	discards := MustParseTiles("4s")
+	if f := sujiFactor(Tile{Type: Tiao, Value: 1}.ToIndex(), discards); f != 50 {
+		t.Errorf("1s is fully covered by 4s suji, got %d", f)
+	}
+	if f := sujiFactor(Tile{Type: Tiao, Value: 7}.ToIndex(), discards); f != 50 {
+		t.Errorf("7s is fully covered by 4s suji, got %d", f)
+	}
+	if f := sujiFactor(Tile{Type: Tiao, Value: 6}.ToIndex(), MustParseTiles("3s")); f != 75 {
+		t.Errorf("6s needs both 3s and 9s, got %d", f)
+	}
+
+	seen, _ := countTable(MustParseTiles("2222p"))
+	if sequenceWaitPossible(Tile{Type: Tong, Value: 1}.ToIndex(), &seen) {
+		t.Error("1p cannot be waited on by a sequence when all 2p are visible")
+	}
+}
+
+func TestShouldFoldAndDefensiveDiscard(t *testing.T) {
+	threats := []OpponentThreat{{Seat: 2, Threat: FoldThreat}}
+	if !ShouldFold(2, threats) || ShouldFold(1, threats) || ShouldFold(3, nil) {
+		t.Error("Unexpected fold decision")
+	}
+
+	// 手牌很散，對手做萬子一色：應棄胡並打出現物
+	view := DangerView{
+		DiscardView: DiscardView{Hand: MustParseTiles("1357m2468p1379s1z5z")},
+		GameType:    GameType16,
+		Opponents:   []OpponentView{flushOpponent(2)},
+	}
+	if report := AssessDanger(view); !report.Fold {
+		t.Fatalf("Expected to fold, got %+v", report)
+	}
+	if best := GetDefensiveDiscard(view); best.Type == Wan {
+		t.Errorf("Should not push a wan tile into a wan flush, got %s", NotationOf(best))
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+// FalseWinPenalty 詐胡 (宣告胡牌但不成立) 的處罰方式
+type FalseWinPenalty string
+
+const (
+	FalseWinPayAll   FalseWinPenalty = "pay_all"   // 賠三家：詐胡者付給其餘三家各 底 + PenaltyTai 台
+	FalseWinDeadHand FalseWinPenalty = "dead_hand" // 相公：本局繼續打，但不能再胡牌
+)
+
+// WinRule 胡牌條件與詐胡處罰設定
+type WinRule struct {
+	MinTai     int             `json:"min_tai"`           // 起胡台數 (0 代表不限)
+	Penalty    FalseWinPenalty `json:"false_win_penalty"` // 詐胡處罰方式
+	PenaltyTai int             `json:"false_win_tai"`     // 賠三家時每家賠付的台數
+}
+
+// DefaultWinRule 預設不限台數，詐胡賠三家各 3 台
+var DefaultWinRule = WinRule{MinTai: 0, Penalty: FalseWinPayAll, PenaltyTai: 3}
+
+// Normalize 補齊未設定或不合法的欄位
+func (r WinRule) Normalize() WinRule {
+	if r.MinTai < 0 {
+		r.MinTai = 0
+	}
+	if r.Penalty != FalseWinPayAll && r.Penalty != FalseWinDeadHand {
+		r.Penalty = DefaultWinRule.Penalty
+	}
+	if r.PenaltyTai <= 0 {
+		r.PenaltyTai = DefaultWinRule.PenaltyTai
+	}
+	return r
+}
+
+// MeetsMinimum 台數是否達到起胡門檻
+func (r WinRule) MeetsMinimum(tai int) bool {
+	return tai >= r.MinTai
+}
+
+// SettleFalseWin 計算詐胡賠三家的點數轉移 (相公不需支付點數，回傳 nil)
+func SettleFalseWin(rule PointRule, winRule WinRule, offenderID int) []PointTransfer {
+	if winRule.Penalty != FalseWinPayAll {
+		return nil
+	}
+
+	transfers := make([]PointTransfer, 0, 3)
+	for p := 1; p <= 4; p++ {
+		if p == offenderID {
+			continue
+		}
+		transfers = append(transfers, PointTransfer{
+			From:   offenderID,
+			To:     p,
+			Tai:    winRule.PenaltyTai,
+			Points: rule.Points(winRule.PenaltyTai),
+		})
+	}
+	return transfers
+}
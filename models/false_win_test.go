@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSettleFalseWin_PayAll(t *testing.T) {
+	rule := PointRule{Base: 100, PerTai: 20}
+	winRule := WinRule{Penalty: FalseWinPayAll, PenaltyTai: 3}
+	transfers := SettleFalseWin(rule, winRule, 2)
+
+	scores := map[int]int{1: 0, 2: 0, 3: 0, 4: 0}
+	ApplyTransfers(scores, transfers)
+
+	if scores[2] != -480 || scores[1] != 160 || scores[3] != 160 || scores[4] != 160 {
+		t.Errorf("Expected offender to pay 160 to each player, got %v", scores)
+	}
+}
+
+func TestSettleFalseWin_DeadHand(t *testing.T) {
+	transfers := SettleFalseWin(DefaultPointRule, WinRule{Penalty: FalseWinDeadHand}, 1)
+	if transfers != nil {
+		t.Errorf("Dead hand should not transfer points, got %v", transfers)
+	}
+}
+
+func TestWinRule_NormalizeAndMinimum(t *testing.T) {
+	r := WinRule{MinTai: 3, Penalty: "unknown"}.Normalize()
+	if r.Penalty != FalseWinPayAll || r.PenaltyTai != DefaultWinRule.PenaltyTai {
+		t.Errorf("Expected defaults to be filled in, got %+v", r)
+	}
+	if r.MeetsMinimum(2) || !r.MeetsMinimum(3) {
+		t.Errorf("Expected minimum of 3 tai, got %+v", r)
+	}
+}
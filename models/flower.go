@@ -0,0 +1,92 @@
+package models
+
+// FlowerWinType 花牌直接胡牌的種類 (不需手牌成型)
+type FlowerWinType int
+
+const (
+	FlowerWinNone           FlowerWinType = 0 // 無
+	FlowerWinEightImmortals FlowerWinType = 1 // 八仙過海：一人集齊八張花
+	FlowerWinSevenRobOne    FlowerWinType = 2 // 七搶一：持七張花者搶走他家的第八張
+)
+
+// IsSeatFlower 判斷花牌是否為該門風的正花
+// 梅/春 → 東、蘭/夏 → 南、竹/秋 → 西、菊/冬 → 北
+func IsSeatFlower(flower Tile, seatWind WindPosition) bool {
+	if flower.Type != Flower {
+		return false
+	}
+	return WindPosition((flower.Value-1)%4+1) == seatWind
+}
+
+// CheckFlowerWin 依四家的花牌判斷是否成立花牌直接胡牌
+// 回傳贏家、付款者 (八仙過海為 0，代表自摸由三家支付) 與胡牌種類
+func CheckFlowerWin(flowers map[int][]Tile) (winnerID int, loserID int, winType FlowerWinType) {
+	for seat := 1; seat <= 4; seat++ {
+		if len(flowers[seat]) == 8 {
+			return seat, 0, FlowerWinEightImmortals
+		}
+	}
+
+	for seat := 1; seat <= 4; seat++ {
+		if len(flowers[seat]) != 7 {
+			continue
+		}
+		for other := 1; other <= 4; other++ {
+			if other != seat && len(flowers[other]) == 1 {
+				return seat, other, FlowerWinSevenRobOne
+			}
+		}
+	}
+
+	return 0, 0, FlowerWinNone
+}
+
+// evaluateFlowers 偵測花牌條件
+//   - 正花：與門風相符的花牌張數
+//   - 花槓：集齊梅蘭竹菊或春夏秋冬的組數 (該組不另計正花)
+func evaluateFlowers(ctx ScoringContext, conds HandConditions) {
+	var sets [2]int // [0] 梅蘭竹菊，[1] 春夏秋冬
+	for _, f := range ctx.Flowers {
+		if f.Type == Flower && f.Value >= 1 && f.Value <= 8 {
+			sets[(f.Value-1)/4]++
+		}
+	}
+
+	flowerKongs := 0
+	seatFlowers := 0
+	for _, f := range ctx.Flowers {
+		if f.Type != Flower || f.Value < 1 || f.Value > 8 {
+			continue
+		}
+		if sets[(f.Value-1)/4] == 4 {
+			continue // 已成花槓
+		}
+		if IsSeatFlower(f, ctx.SeatWind) {
+			seatFlowers++
+		}
+	}
+	for _, n := range sets {
+		if n == 4 {
+			flowerKongs++
+		}
+	}
+
+	conds.Add(CondSeatFlower, seatFlowers)
+	conds.Add(CondFlowerKong, flowerKongs)
+}
+
+// scoreFlowerWin 花牌直接胡牌的台數：八仙過海 / 七搶一 各 8 台 (不另計正花、花槓)
+func scoreFlowerWin(ctx ScoringContext) ScoreResult {
+	conds := HandConditions{}
+	if ctx.IsDealer {
+		conds.Add(CondDealer, 1)
+	}
+
+	switch ctx.FlowerWin {
+	case FlowerWinEightImmortals:
+		conds.Add(CondEightImmortals, 1)
+	case FlowerWinSevenRobOne:
+		conds.Add(CondSevenRobOne, 1)
+	}
+	return ctx.taiTable().Apply(conds)
+}
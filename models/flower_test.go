@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+)
+
+func flowersOf(values ...int) []Tile {
+	tiles := make([]Tile, len(values))
+	for i, v := range values {
+		tiles[i] = Tile{Type: Flower, Value: v}
+	}
+	return tiles
+}
+
+func TestCheckFlowerWin(t *testing.T) {
+	winner, loser, winType := CheckFlowerWin(map[int][]Tile{2: flowersOf(1, 2, 3, 4, 5, 6, 7, 8)})
+	if winner != 2 || loser != 0 || winType != FlowerWinEightImmortals {
+		t.Errorf("Expected seat 2 Eight Immortals, got winner=%d loser=%d type=%d", winner, loser, winType)
+	}
+
+	winner, loser, winType = CheckFlowerWin(map[int][]Tile{1: flowersOf(8), 3: flowersOf(1, 2, 3, 4, 5, 6, 7)})
+	if winner != 3 || loser != 1 || winType != FlowerWinSevenRobOne {
+		t.Errorf("Expected seat 3 robbing seat 1, got winner=%d loser=%d type=%d", winner, loser, winType)
+	}
+
+	if _, _, winType = CheckFlowerWin(map[int][]Tile{1: flowersOf(1, 2), 4: flowersOf(3, 4, 5)}); winType != FlowerWinNone {
+		t.Errorf("Expected no flower win, got %d", winType)
+	}
+}
+
+func TestCalculateScore_FlowerTai(t *testing.T) {
+	closed := []Tile{
+		{Type: Wan, Value: 1}, {Type: Wan, Value: 2}, {Type: Wan, Value: 3},
+		{Type: Tong, Value: 4}, {Type: Tong, Value: 5}, {Type: Tong, Value: 6},
+		{Type: Tiao, Value: 7}, {Type: Tiao, Value: 8}, {Type: Tiao, Value: 9},
+		{Type: Tong, Value: 2}, {Type: Tong, Value: 3}, {Type: Tong, Value: 4},
+		{Type: Tiao, Value: 5},
+	}
+
+	// 門風南：蘭 (2) 為正花；春夏秋冬成花槓，夏 (6) 不另計正花
+	res := CalculateScore(ScoringContext{
+		ClosedHand:  closed,
+		WinningTile: Tile{Type: Tiao, Value: 5},
+		SeatWind:    South,
+		Flowers:     flowersOf(2, 5, 6, 7, 8),
+	})
+
+	if res.Patterns["正花"] != 1 || res.Patterns["花槓"] != 2 {
+		t.Errorf("Expected 1 seat flower and 1 flower kong, got %v", res.Patterns)
+	}
+
+	res = CalculateScore(ScoringContext{FlowerWin: FlowerWinSevenRobOne, Flowers: flowersOf(1, 2, 3, 4, 5, 6, 7, 8)})
+	if res.TotalTai != 8 || res.Patterns["七搶一"] != 8 {
+		t.Errorf("Expected Seven Rob One 8, got %v", res.Patterns)
+	}
+}
@@ -0,0 +1,118 @@
+package models
+
+import "fmt"
+
+// GameLengthMode 一場遊戲的長度
+type GameLengthMode string
+
+const (
+	LengthFull      GameLengthMode = "full"       // 一將：東南西北四圈 (16 局)
+	LengthEast      GameLengthMode = "east"       // 東風圈 (4 局)
+	LengthEastSouth GameLengthMode = "east_south" // 東南兩圈 (8 局)
+	LengthHands     GameLengthMode = "hands"      // 固定局數 (連莊也算一局)
+)
+
+// GameLength 遊戲長度與提前結束規則
+type GameLength struct {
+	Mode      GameLengthMode `json:"mode"`
+	Hands     int            `json:"hands"`                // 固定局數 (Mode 為 hands 時使用)
+	BustLimit *int           `json:"bust_limit,omitempty"` // 任一家累計分數低於 -BustLimit 時提前結束 (nil 為不啟用，0 為低於零分即結束)
+}
+
+// DefaultGameLength 預設打滿一將
+var DefaultGameLength = GameLength{Mode: LengthFull}
+
+// Normalize 補齊未設定或不合法的欄位
+func (l GameLength) Normalize() GameLength {
+	switch l.Mode {
+	case LengthFull, LengthEast, LengthEastSouth:
+	case LengthHands:
+		if l.Hands <= 0 {
+			l.Hands = 1
+		}
+	default:
+		l.Mode = LengthFull
+	}
+	if l.BustLimit != nil && *l.BustLimit < 0 {
+		l.BustLimit = nil
+	}
+	return l
+}
+
+// lastWind 最後一圈的圈風
+func (l GameLength) lastWind() WindPosition {
+	switch l.Mode {
+	case LengthEast:
+		return East
+	case LengthEastSouth:
+		return South
+	default:
+		return North
+	}
+}
+
+// TotalHands 預定的局數 (不含連莊)
+func (l GameLength) TotalHands() int {
+	if l.Mode == LengthHands {
+		return l.Hands
+	}
+	return int(l.lastWind()) * 4
+}
+
+// IsLastRound 目前這局是否為最後一局 (連莊可能使最後一局重打)
+// handsPlayed 為已完成的局數 (不含目前這局)
+func (l GameLength) IsLastRound(r GameRound, handsPlayed int) bool {
+	if l.Mode == LengthHands {
+		return handsPlayed+1 >= l.Hands
+	}
+	return r.PrevailingWind == l.lastWind() && r.HandWind == North
+}
+
+// NextRound 計算下一局的局號，遊戲結束時回傳 true
+// handsPlayed 為已完成的局數 (含剛結束的這局)，dealerRetained 為連莊 (局號不前進)
+func (l GameLength) NextRound(r GameRound, handsPlayed int, dealerRetained bool) (GameRound, bool) {
+	if l.Mode == LengthHands {
+		if handsPlayed >= l.Hands {
+			return GameRound{}, true
+		}
+		if dealerRetained {
+			return r, false
+		}
+		// 固定局數超過一將時從東風東重新開始
+		next, wrapped := r.NextRound()
+		if wrapped {
+			return NewFirstRound(), false
+		}
+		return next, false
+	}
+
+	if dealerRetained {
+		return r, false
+	}
+	if l.IsLastRound(r, handsPlayed) {
+		return GameRound{}, true
+	}
+	return r.NextRound()
+}
+
+// IsBust 是否有人累計分數低於破產門檻 (提前結束)
+func (l GameLength) IsBust(scores map[int]int) bool {
+	if l.BustLimit == nil {
+		return false
+	}
+	for _, score := range scores {
+		if score < -*l.BustLimit {
+			return true
+		}
+	}
+	return false
+}
+
+// Progress 遊戲進度 (GameStatus.Progress)：風圈制為局號代碼 (例: "2-3")，固定局數制為 "第幾局/總局數" (例: "3/8")
+// handsPlayed 為已完成的局數 (不含目前這局)
+func (l GameLength) Progress(r GameRound, handsPlayed int) string {
+	if l.Mode == LengthHands {
+		return fmt.Sprintf("%d/%d", handsPlayed+1, l.Hands)
+	}
+	return r.RoundCode()
+}
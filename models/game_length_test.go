@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGameLength_EastOnly(t *testing.T) {
+	l := GameLength{Mode: LengthEast}.Normalize()
+	r := NewFirstRound()
+
+	hands := 0
+	for {
+		hands++
+		next, done := l.NextRound(r, hands, false)
+		if done {
+			break
+		}
+		r = next
+	}
+
+	if hands != 4 || r.RoundCode() != "1-4" {
+		t.Errorf("Expected east round to end after 1-4 (4 hands), got %d hands ending at %s", hands, r.RoundCode())
+	}
+}
+
+func TestGameLength_EastSouthDealerRetained(t *testing.T) {
+	l := GameLength{Mode: LengthEastSouth}
+	last := GameRound{PrevailingWind: South, HandWind: North}
+
+	if !l.IsLastRound(last, 7) {
+		t.Errorf("Expected 2-4 to be the last round")
+	}
+	if next, done := l.NextRound(last, 8, true); done || next != last {
+		t.Errorf("Dealer retention should replay the last round, got %v %v", next, done)
+	}
+	if _, done := l.NextRound(last, 9, false); !done {
+		t.Errorf("Expected game to end after 2-4")
+	}
+}
+
+func TestGameLength_FixedHands(t *testing.T) {
+	l := GameLength{Mode: LengthHands, Hands: 3}
+	r := GameRound{PrevailingWind: North, HandWind: North}
+
+	next, done := l.NextRound(r, 1, false)
+	if done || next != NewFirstRound() {
+		t.Errorf("Expected wrap to 1-1 after 4-4, got %v %v", next, done)
+	}
+	if _, done := l.NextRound(next, 3, true); !done {
+		t.Errorf("Fixed hands should end after 3 hands even when the dealer is retained")
+	}
+	if p := l.Progress(next, 1); p != "2/3" {
+		t.Errorf("Expected progress 2/3, got %s", p)
+	}
+}
+
+func TestGameLength_IsBust(t *testing.T) {
+	limit := 1000
+	l := GameLength{Mode: LengthFull, BustLimit: &limit}
+	if l.IsBust(map[int]int{1: -1000, 2: 1000}) {
+		t.Errorf("Exactly at the limit should not bust")
+	}
+	if !l.IsBust(map[int]int{1: -1001, 2: 1001}) {
+		t.Errorf("Below the limit should bust")
+	}
+	if (GameLength{}).IsBust(map[int]int{1: -100000}) {
+		t.Errorf("Bust rule disabled by default")
+	}
+}
+
+func TestGameLength_IsBustBelowZero(t *testing.T) {
+	var l GameLength
+	if err := json.Unmarshal([]byte(`{"mode":"full","bust_limit":0}`), &l); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	l = l.Normalize()
+	if l.IsBust(map[int]int{1: 0, 2: 0}) {
+		t.Errorf("Zero points should not bust")
+	}
+	if !l.IsBust(map[int]int{1: -1, 2: 1}) {
+		t.Errorf("A limit of 0 should bust below zero")
+	}
+
+	negative := -5
+	if (GameLength{BustLimit: &negative}).Normalize().BustLimit != nil {
+		t.Errorf("Negative limit should disable the bust rule")
+	}
+}
@@ -26,6 +26,14 @@ func (w WindPosition) String() string {
 	return "未知"
 }
 
+// SeatWind 依莊家位置計算座位的門風：莊家為東，其下家為南，依此類推
+func SeatWind(seat int, dealerSeat int) WindPosition {
+	if dealerSeat < 1 || dealerSeat > 4 {
+		dealerSeat = 1
+	}
+	return WindPosition((seat-dealerSeat+4)%4 + 1)
+}
+
 // GameRound 局號狀態
 // 圈風 (PrevailingWind): 東→南→西→北 (1-4)
 // 門風 / 局 (HandWind):  東→南→西→北 (1-4)
@@ -138,24 +146,85 @@ const (
 	GameType16 GameType = 16 // 16張玩法 (含花牌，144張)
 )
 
+// HandSize 閒家的手牌張數 (副露以每組 3 張計)
+func (g GameType) HandSize() int {
+	if g == GameType13 {
+		return 13
+	}
+	return 16
+}
+
 // GameState 完整遊戲狀態（存放在 Redis 中）
 type GameState struct {
 	GameID              string              `json:"game_id"`
-	GameType            GameType            `json:"game_type"`              // 遊戲類型 (13 或 16)
+	Rules               RoomRules           `json:"rules"`                  // 房間規則 (建立時決定)
 	Stage               GameStage           `json:"stage"`                  // 目前遊戲階段
 	CurrentPlayerID     int                 `json:"current_player_id"`      // 目前輪到的玩家代號 (1-4)
 	Round               GameRound           `json:"round"`                  // 目前局號
 	DealerPlayerID      int                 `json:"dealer_player_id"`       // 莊家玩家代號 (1-4)
 	Dice                DiceResult          `json:"dice"`                   // 擲骰子結果
 	IsStarted           bool                `json:"is_started"`             // 是否已開始
+	StartedAt           int64               `json:"started_at"`             // 開始時間 (UnixNano)，同一 game ID 重新開始時區分不同的一將
 	IsFinished          bool                `json:"is_finished"`            // 一將是否結束
 	Players             map[int]Player      `json:"players"`                // 玩家列表 (SeatID 1-4 對應 -> Player)
+	OwnerID             int64               `json:"owner_id,omitempty"`     // 開房的帳號 (以登入 token 開房時記錄)，可指派外部 AI
 	LastDiscardTile     *Tile               `json:"last_discard_tile"`      // 最新打出的一張牌 (可為 null)
 	LastDiscardPlayerID int                 `json:"last_discard_player_id"` // 是誰打出最新的這張牌
 	ActionDeclarations  map[int]string      `json:"action_declarations"`    // 紀錄各家在 WAIT_ACTION 階段宣吿的動作 ("pass", "pong", "kong", "hu")
 	WinnerIDs           []int               `json:"winner_ids"`             // 遊戲結束時贏家的 ID 列表 (支援一砲多響)
 	IsAfterKong         bool                `json:"is_after_kong"`          // 是否剛槓牌 (用於計算槓上開花)
 	ScoreResults        map[int]ScoreResult `json:"score_results"`          // 紀錄每位贏家的台數與牌型結算
+	LastDrawTile        *Tile               `json:"last_draw_tile"`         // 目前玩家最後摸進的牌 (自摸時作為胡牌張)
+	Scores              map[int]int         `json:"scores"`                 // 各家累計分數 (SeatID 1-4 對應 -> 分數)
+	RoundTransfers      []PointTransfer     `json:"round_transfers"`        // 本局結算的點數轉移紀錄
+	Stats               map[int]SeatStats   `json:"stats"`                  // 各家整將統計 (胡牌/放槍/自摸/最大牌)
+	RoundsPlayed        int                 `json:"rounds_played"`          // 已完成的局數
+	FinalResult         *FinalResult        `json:"final_result"`           // 一將結束後的最終排名 (GAME_OVER 後唯讀)
+	RoundResult         *RoundResult        `json:"round_result"`           // 本局結算結果 (ROUND_OVER 時產生)
+	DiscardCount        int                 `json:"discard_count"`          // 本局已出牌次數 (判斷第一巡用)
+	IsInterrupted       bool                `json:"is_interrupted"`         // 本局是否已有人吃/碰/槓 (第一巡被打斷)
+	IsRobbingKong       bool                `json:"is_robbing_kong"`        // 目前等待宣告的牌是否為加槓的牌 (用於計算搶槓)
+	ClaimTiles          map[int][]int       `json:"claim_tiles,omitempty"`  // 吃牌宣告者指定的兩張手牌 ID (座位 → 牌 ID)
+	DeadHands           map[int]bool        `json:"dead_hands"`             // 本局詐胡成為相公的座位 (不能再胡牌)
+	DrawReason          DrawReason          `json:"draw_reason"`            // 本局流局原因 (ROUND_OVER 且無贏家時)
+	FirstDiscards       []Tile              `json:"first_discards"`         // 未被打斷的第一巡打出的牌 (判斷四風連打用)
+	IsFrozen            bool                `json:"is_frozen"`              // 稽核發現違規而凍結 (拒絕後續變更)
+	FrozenReason        string              `json:"frozen_reason"`          // 凍結原因
+}
+
+// Progress 目前的遊戲進度 (依遊戲長度設定)
+func (s *GameState) Progress() string {
+	return s.Rules.GameLength.Normalize().Progress(s.Round, s.RoundsPlayed)
+}
+
+// TaiTable 本局使用的台數表 (找不到時使用預設表)
+func (s *GameState) TaiTable() *TaiTable {
+	if table, ok := LookupTaiTable(s.Rules.TaiTable); ok {
+		return table
+	}
+	return DefaultTaiTable()
+}
+
+// SpecialShapes 本局啟用的特殊胡牌型
+func (s *GameState) SpecialShapes() []SpecialShape {
+	return s.Rules.EnabledSpecialShapes()
+}
+
+// IsWinningHand 依本局啟用的牌型判斷手牌是否胡牌
+func (s *GameState) IsWinningHand(hand []Tile) bool {
+	return IsWinningHand(hand, s.SpecialShapes())
+}
+
+// IsFirstGoAround 是否仍在未被打斷的第一巡 (四家各出一張牌之前，且無人吃碰槓)
+// isSelfDrawn: 自摸時以「尚未出滿四張」判斷；胡他人打出的牌時，該張牌已計入出牌次數
+func (s *GameState) IsFirstGoAround(isSelfDrawn bool) bool {
+	if s.IsInterrupted {
+		return false
+	}
+	if isSelfDrawn {
+		return s.DiscardCount < 4
+	}
+	return s.DiscardCount <= 4
 }
 
 // MeldType 副露類型
@@ -0,0 +1,311 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 牌譜記法 (notation)
+//
+//	數字在前、花色字母在後，同花色可連寫：123m 萬、456p 筒、789s 條
+//	z: 1-4 東南西北、5-7 中發白；f: 1-8 梅蘭竹菊春夏秋冬
+//	[...] 明副露 (依張數與形狀判斷吃/碰/明槓)、(...) 暗槓、{...} 加槓
+//	+ 標記胡牌張，例如 "23m567p123456s99p [777z] +4m 12f"
+
+// notationSuits 花色字母對照
+var notationSuits = map[byte]TileType{
+	'm': Wan,
+	'p': Tong,
+	's': Tiao,
+	'f': Flower,
+}
+
+// HandNotation 以記法表示的一手牌
+type HandNotation struct {
+	Closed      []Tile `json:"closed"`       // 暗手牌 (不含胡牌張)
+	Melds       []Meld `json:"melds"`        // 副露
+	WinningTile *Tile  `json:"winning_tile"` // 胡牌張 (可為 nil)
+	Flowers     []Tile `json:"flowers"`      // 花牌
+}
+
+// NotationOf 單張牌的記法，例如 "5m"、"1z"
+func NotationOf(t Tile) string {
+	return fmt.Sprintf("%d%c", notationValue(t), notationSuit(t.Type))
+}
+
+func notationSuit(t TileType) byte {
+	switch t {
+	case Wan:
+		return 'm'
+	case Tong:
+		return 'p'
+	case Tiao:
+		return 's'
+	case Wind, Dragon:
+		return 'z'
+	case Flower:
+		return 'f'
+	default:
+		return '?'
+	}
+}
+
+func notationValue(t Tile) int {
+	if t.Type == Dragon {
+		return t.Value + 4
+	}
+	return t.Value
+}
+
+// notationTile 由記法的數字與花色字母建立牌
+func notationTile(value int, suit byte) (Tile, error) {
+	if suit == 'z' {
+		switch {
+		case value >= 1 && value <= 4:
+			return Tile{Type: Wind, Value: value}, nil
+		case value >= 5 && value <= 7:
+			return Tile{Type: Dragon, Value: value - 4}, nil
+		}
+		return Tile{}, fmt.Errorf("invalid honor tile %dz", value)
+	}
+
+	tileType, ok := notationSuits[suit]
+	if !ok {
+		return Tile{}, fmt.Errorf("unknown suit %q", suit)
+	}
+	max := 9
+	if tileType == Flower {
+		max = 8
+	}
+	if value < 1 || value > max {
+		return Tile{}, fmt.Errorf("invalid tile %d%c", value, suit)
+	}
+	return Tile{Type: tileType, Value: value}, nil
+}
+
+// FormatTiles 將牌轉成記法，相鄰同花色的牌合併寫出 (不重新排序)
+func FormatTiles(tiles []Tile) string {
+	var b strings.Builder
+	for i, t := range tiles {
+		b.WriteByte(byte('0' + notationValue(t)))
+		if i == len(tiles)-1 || notationSuit(tiles[i+1].Type) != notationSuit(t.Type) {
+			b.WriteByte(notationSuit(t.Type))
+		}
+	}
+	return b.String()
+}
+
+// ParseTiles 解析不含副露與胡牌標記的記法，例如 "123m456p1122z"
+func ParseTiles(s string) ([]Tile, error) {
+	var tiles []Tile
+	var pending []int
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			pending = append(pending, int(c-'0'))
+		case c == ' ':
+		default:
+			if len(pending) == 0 {
+				return nil, fmt.Errorf("suit %q without values at position %d", c, i)
+			}
+			for _, v := range pending {
+				t, err := notationTile(v, c)
+				if err != nil {
+					return nil, err
+				}
+				tiles = append(tiles, t)
+			}
+			pending = pending[:0]
+		}
+	}
+
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("values %v without suit in %q", pending, s)
+	}
+	return tiles, nil
+}
+
+// MustParseTiles 同 ParseTiles，解析失敗時 panic (供測試與固定資料使用)
+func MustParseTiles(s string) []Tile {
+	tiles, err := ParseTiles(s)
+	if err != nil {
+		panic(err)
+	}
+	return tiles
+}
+
+// ParseHand 解析完整的一手牌記法 (含副露、胡牌張與花牌)
+func ParseHand(s string) (HandNotation, error) {
+	var h HandNotation
+	var closed strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		// 暗手牌的數字須在副露或胡牌張之前以花色結尾，不可被拆成兩段 (如 12[333m]3m)
+		if strings.IndexByte("[({+", s[i]) >= 0 && hasPendingValues(closed.String()) {
+			return HandNotation{}, fmt.Errorf("closed tiles without suit before position %d", i)
+		}
+
+		switch c := s[i]; c {
+		case '[', '(', '{':
+			end := strings.IndexByte(s[i:], closingBracket(c))
+			if end < 0 {
+				return HandNotation{}, fmt.Errorf("unclosed meld at position %d", i)
+			}
+			meld, err := parseMeld(c, s[i+1:i+end])
+			if err != nil {
+				return HandNotation{}, err
+			}
+			h.Melds = append(h.Melds, meld)
+			i += end
+
+		case '+':
+			j := i + 1
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			if j >= len(s) || j != i+2 {
+				return HandNotation{}, fmt.Errorf("winning tile marker must be followed by one tile at position %d", i)
+			}
+			t, err := notationTile(int(s[i+1]-'0'), s[j])
+			if err != nil {
+				return HandNotation{}, err
+			}
+			h.WinningTile = &t
+			i = j
+
+		default:
+			closed.WriteByte(c)
+		}
+	}
+
+	tiles, err := ParseTiles(closed.String())
+	if err != nil {
+		return HandNotation{}, err
+	}
+	for _, t := range tiles {
+		if t.Type == Flower {
+			h.Flowers = append(h.Flowers, t)
+		} else {
+			h.Closed = append(h.Closed, t)
+		}
+	}
+	return h, nil
+}
+
+// MustParseHand 同 ParseHand，解析失敗時 panic (供測試與固定資料使用)
+func MustParseHand(s string) HandNotation {
+	h, err := ParseHand(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// hasPendingValues 記法結尾是否還有未接花色的數字 (忽略空白)
+func hasPendingValues(s string) bool {
+	s = strings.TrimRight(s, " ")
+	return s != "" && s[len(s)-1] >= '0' && s[len(s)-1] <= '9'
+}
+
+func closingBracket(open byte) byte {
+	switch open {
+	case '(':
+		return ')'
+	case '{':
+		return '}'
+	default:
+		return ']'
+	}
+}
+
+// parseMeld 依括號與張數判斷副露類型
+func parseMeld(open byte, body string) (Meld, error) {
+	tiles, err := ParseTiles(body)
+	if err != nil {
+		return Meld{}, err
+	}
+
+	if len(tiles) == 4 && allSameTile(tiles) {
+		switch open {
+		case '(':
+			return Meld{Type: MeldTypeHiddenKong, Tiles: tiles}, nil
+		case '{':
+			return Meld{Type: MeldTypeAddKong, Tiles: tiles}, nil
+		default:
+			return Meld{Type: MeldTypeKong, Tiles: tiles}, nil
+		}
+	}
+	if open == '[' && len(tiles) == 3 {
+		if allSameTile(tiles) {
+			return Meld{Type: MeldTypePong, Tiles: tiles}, nil
+		}
+		if isSequence(tiles) {
+			return Meld{Type: MeldTypeChow, Tiles: tiles}, nil
+		}
+	}
+	return Meld{}, fmt.Errorf("invalid meld %c%s%c", open, body, closingBracket(open))
+}
+
+func allSameTile(tiles []Tile) bool {
+	for _, t := range tiles[1:] {
+		if t.Type != tiles[0].Type || t.Value != tiles[0].Value {
+			return false
+		}
+	}
+	return true
+}
+
+func isSequence(tiles []Tile) bool {
+	low := tiles[0]
+	for _, t := range tiles[1:] {
+		if t.Type != low.Type {
+			return false
+		}
+		if t.Value < low.Value {
+			low = t
+		}
+	}
+	if low.Type > Tiao {
+		return false
+	}
+	seen := make(map[int]bool, len(tiles))
+	for _, t := range tiles {
+		if t.Value-low.Value >= len(tiles) || seen[t.Value] {
+			return false
+		}
+		seen[t.Value] = true
+	}
+	return true
+}
+
+// FormatMeld 副露的記法
+func FormatMeld(m Meld) string {
+	opening, closing := "[", "]"
+	switch m.Type {
+	case MeldTypeHiddenKong:
+		opening, closing = "(", ")"
+	case MeldTypeAddKong:
+		opening, closing = "{", "}"
+	}
+	return opening + FormatTiles(m.Tiles) + closing
+}
+
+// String 一手牌的記法：暗手牌、副露、胡牌張、花牌，以空白分隔
+func (h HandNotation) String() string {
+	parts := make([]string, 0, len(h.Melds)+3)
+	if len(h.Closed) > 0 {
+		parts = append(parts, FormatTiles(h.Closed))
+	}
+	for _, m := range h.Melds {
+		parts = append(parts, FormatMeld(m))
+	}
+	if h.WinningTile != nil {
+		parts = append(parts, "+"+NotationOf(*h.WinningTile))
+	}
+	if len(h.Flowers) > 0 {
+		parts = append(parts, FormatTiles(h.Flowers))
+	}
+	return strings.Join(parts, " ")
+}
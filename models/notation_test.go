@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestParseTiles(t *testing.T) {
+	tiles, err := ParseTiles("19m5p 9s1257z8f")
+	if err != nil {
+		t.Fatalf("ParseTiles failed: %v", err)
+	}
+
+	want := []Tile{
+		{Type: Wan, Value: 1}, {Type: Wan, Value: 9},
+		{Type: Tong, Value: 5}, {Type: Tiao, Value: 9},
+		{Type: Wind, Value: 1}, {Type: Wind, Value: 2},
+		{Type: Dragon, Value: 1}, {Type: Dragon, Value: 3},
+		{Type: Flower, Value: 8},
+	}
+	if len(tiles) != len(want) {
+		t.Fatalf("Expected %d tiles, got %v", len(want), tiles)
+	}
+	for i := range want {
+		if tiles[i] != want[i] {
+			t.Errorf("Tile %d: expected %v, got %v", i, want[i], tiles[i])
+		}
+	}
+}
+
+func TestParseTiles_Invalid(t *testing.T) {
+	for _, s := range []string{"123", "m", "0m", "8z", "9f", "12x"} {
+		if _, err := ParseTiles(s); err == nil {
+			t.Errorf("Expected %q to fail", s)
+		}
+	}
+}
+
+func TestFormatTiles_RoundTrip(t *testing.T) {
+	s := "123m456p789s1122z"
+	if got := FormatTiles(MustParseTiles(s)); got != s {
+		t.Errorf("Expected %q, got %q", s, got)
+	}
+	if got := NotationOf(Tile{Type: Dragon, Value: 2}); got != "6z" {
+		t.Errorf("Expected 發 to be 6z, got %s", got)
+	}
+}
+
+func TestParseHand(t *testing.T) {
+	h, err := ParseHand("23m99p [123s] [555p] (7777z) {1111m} +4m 12f")
+	if err != nil {
+		t.Fatalf("ParseHand failed: %v", err)
+	}
+
+	if len(h.Closed) != 4 || len(h.Flowers) != 2 {
+		t.Errorf("Expected 4 closed tiles and 2 flowers, got %v / %v", h.Closed, h.Flowers)
+	}
+	wantMelds := []MeldType{MeldTypeChow, MeldTypePong, MeldTypeHiddenKong, MeldTypeAddKong}
+	if len(h.Melds) != len(wantMelds) {
+		t.Fatalf("Expected %d melds, got %v", len(wantMelds), h.Melds)
+	}
+	for i, mt := range wantMelds {
+		if h.Melds[i].Type != mt {
+			t.Errorf("Meld %d: expected type %d, got %d", i, mt, h.Melds[i].Type)
+		}
+	}
+	if h.WinningTile == nil || *h.WinningTile != (Tile{Type: Wan, Value: 4}) {
+		t.Errorf("Expected winning tile 4m, got %v", h.WinningTile)
+	}
+
+	if got := h.String(); got != "23m99p [123s] [555p] (7777z) {1111m} +4m 12f" {
+		t.Errorf("Unexpected formatted hand: %s", got)
+	}
+}
+
+func TestParseHand_InvalidMeld(t *testing.T) {
+	for _, s := range []string{"[124m]", "(111m)", "[123z]", "[12m", "+m", "+12m", "12[333m]3m", "12 [333m] 3m", "12+3m 4m"} {
+		if _, err := ParseHand(s); err == nil {
+			t.Errorf("Expected %q to fail", s)
+		}
+	}
+}
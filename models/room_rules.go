@@ -0,0 +1,170 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MultiWinMode 多家同時宣告胡同一張牌時的處理方式
+type MultiWinMode string
+
+const (
+	MultiWinAll   MultiWinMode = "all"   // 一砲多響：所有宣告胡牌者皆胡
+	MultiWinFirst MultiWinMode = "first" // 截胡：只由放槍者下家起算最近的一家胡牌
+)
+
+// TimerRule 各階段的時限設定
+type TimerRule struct {
+	ActionSeconds int `json:"action_seconds"` // 吃碰槓胡宣告時限 (秒)，逾時視為過；0 為不限時
+	TurnSeconds   int `json:"turn_seconds"`   // 出牌時限 (秒)，逾時自動出牌；0 為不限時
+	AIThinkMillis int `json:"ai_think_ms"`    // AI 思考時間 (毫秒)，0 採預設值，負數為不等待
+}
+
+// DefaultTimerRule 預設不限時，AI 思考 1 秒
+var DefaultTimerRule = TimerRule{AIThinkMillis: 1000}
+
+// Normalize 補齊未設定或不合法的欄位
+func (t TimerRule) Normalize() TimerRule {
+	if t.ActionSeconds < 0 {
+		t.ActionSeconds = 0
+	}
+	if t.TurnSeconds < 0 {
+		t.TurnSeconds = 0
+	}
+	if t.AIThinkMillis == 0 {
+		t.AIThinkMillis = DefaultTimerRule.AIThinkMillis
+	}
+	return t
+}
+
+// ActionTimeout 宣告時限 (0 為不限時)
+func (t TimerRule) ActionTimeout() time.Duration {
+	return time.Duration(t.ActionSeconds) * time.Second
+}
+
+// TurnTimeout 出牌時限 (0 為不限時)
+func (t TimerRule) TurnTimeout() time.Duration {
+	return time.Duration(t.TurnSeconds) * time.Second
+}
+
+// AIThinkTime AI 思考時間 (負數設定視為不等待)
+func (t TimerRule) AIThinkTime() time.Duration {
+	if t.AIThinkMillis < 0 {
+		return 0
+	}
+	return time.Duration(t.AIThinkMillis) * time.Millisecond
+}
+
+// RoomRules 房間規則，建立房間時決定並隨遊戲狀態保存
+type RoomRules struct {
+	GameType      GameType           `json:"game_type"`                // 手牌張數 (13 或 16)
+	Flowers       bool               `json:"flowers"`                  // 是否加入花牌
+	Dice          int                `json:"dice"`                     // 擲骰數量 (2 或 3)
+	PointRule     PointRule          `json:"point_rule"`               // 底/台 計分設定
+	WinRule       WinRule            `json:"win_rule"`                 // 起胡台數與詐胡處罰設定
+	GameLength    GameLength         `json:"game_length"`              // 遊戲長度與提前結束規則
+	Timers        TimerRule          `json:"timers"`                   // 宣告、出牌與 AI 思考時間
+	MultiWin      MultiWinMode       `json:"multi_win"`                // 一砲多響或截胡
+	TaiTable      string             `json:"tai_table"`                // 台數表名稱 (空字串為預設表)
+	SpecialShapes []string           `json:"special_shapes"`           // 啟用的特殊胡牌型 (nil 時採遊戲類型預設)
+	AbortiveDraws AbortiveDrawRule   `json:"abortive_draws"`           // 中途流局規則開關
+	Audit         AuditRule          `json:"audit"`                    // 牌桌守恆稽核設定
+	AIStrategy    AIStrategy         `json:"ai_strategy"`              // AI 策略 (beginner、basic 或 efficiency)
+	BotStrategies map[int]AIStrategy `json:"bot_strategies,omitempty"` // 各 AI 座位 (1-4) 的策略，未指定的座位採 AIStrategy
+}
+
+// DefaultRoomRules 依遊戲類型產生預設規則
+// 16 張：含花牌、擲三顆骰；13 張：不含花牌、擲兩顆骰
+func DefaultRoomRules(gameType GameType) RoomRules {
+	if gameType != GameType13 && gameType != GameType16 {
+		gameType = GameType16
+	}
+	rules := RoomRules{
+		GameType:   gameType,
+		Flowers:    gameType == GameType16,
+		Dice:       2,
+		PointRule:  DefaultPointRule,
+		WinRule:    DefaultWinRule,
+		GameLength: DefaultGameLength,
+		Timers:     DefaultTimerRule,
+		MultiWin:   MultiWinAll,
+		AIStrategy: AIStrategyBasic,
+	}
+	if gameType == GameType16 {
+		rules.Dice = 3
+	}
+	return rules
+}
+
+// ParseRoomRules 解析 JSON 規則，未指定的欄位採該遊戲類型的預設值
+func ParseRoomRules(data []byte) (RoomRules, error) {
+	var head struct {
+		GameType GameType `json:"game_type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return RoomRules{}, fmt.Errorf("invalid room rules: %w", err)
+	}
+
+	rules := DefaultRoomRules(head.GameType)
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return RoomRules{}, fmt.Errorf("invalid room rules: %w", err)
+	}
+	return rules.Normalize(), nil
+}
+
+// Normalize 補齊未設定或不合法的欄位
+func (r RoomRules) Normalize() RoomRules {
+	if r.GameType != GameType13 && r.GameType != GameType16 {
+		r.GameType = GameType16
+	}
+	if r.Dice != 2 && r.Dice != 3 {
+		r.Dice = DefaultRoomRules(r.GameType).Dice
+	}
+	if r.PointRule.Base <= 0 || r.PointRule.PerTai < 0 {
+		r.PointRule = DefaultPointRule
+	}
+	if r.MultiWin != MultiWinAll && r.MultiWin != MultiWinFirst {
+		r.MultiWin = MultiWinAll
+	}
+	if !r.AIStrategy.Valid() {
+		r.AIStrategy = AIStrategyBasic
+	}
+	if r.BotStrategies != nil {
+		strategies := make(map[int]AIStrategy, len(r.BotStrategies))
+		for seat, s := range r.BotStrategies {
+			if seat >= 1 && seat <= 4 && s.Valid() {
+				strategies[seat] = s
+			}
+		}
+		r.BotStrategies = strategies
+	}
+	r.WinRule = r.WinRule.Normalize()
+	r.GameLength = r.GameLength.Normalize()
+	r.Timers = r.Timers.Normalize()
+	return r
+}
+
+// BotStrategy 座位的 AI 策略：有個別指定時採用，否則為房間的 AIStrategy
+func (r RoomRules) BotStrategy(seat int) AIStrategy {
+	if s, ok := r.BotStrategies[seat]; ok {
+		return s
+	}
+	return r.AIStrategy
+}
+
+// Validate 檢查引用的台數表是否存在
+func (r RoomRules) Validate() error {
+	if _, ok := LookupTaiTable(r.TaiTable); !ok {
+		return fmt.Errorf("unknown tai table: %s", r.TaiTable)
+	}
+	return nil
+}
+
+// EnabledSpecialShapes 啟用的特殊胡牌型
+func (r RoomRules) EnabledSpecialShapes() []SpecialShape {
+	if r.SpecialShapes == nil {
+		return LookupSpecialShapes(DefaultSpecialShapeIDs(r.GameType))
+	}
+	return LookupSpecialShapes(r.SpecialShapes)
+}
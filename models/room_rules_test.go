@@ -0,0 +1,129 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDefaultRoomRules(t *testing.T) {
+	r16 := DefaultRoomRules(GameType16)
+	if !r16.Flowers || r16.Dice != 3 || r16.MultiWin != MultiWinAll {
+		t.Errorf("Unexpected 16-tile defaults: %+v", r16)
+	}
+
+	r13 := DefaultRoomRules(GameType13)
+	if r13.Flowers || r13.Dice != 2 {
+		t.Errorf("Unexpected 13-tile defaults: %+v", r13)
+	}
+
+	if got := DefaultRoomRules(GameType(15)).GameType; got != GameType16 {
+		t.Errorf("Expected invalid game type to fall back to 16, got %d", got)
+	}
+}
+
+func TestParseRoomRules_OverridesDefaults(t *testing.T) {
+	rules, err := ParseRoomRules([]byte(`{
+		"game_type": 13,
+		"flowers": true,
+		"point_rule": {"base": 300, "per_tai": 100},
+		"win_rule": {"min_tai": 2},
+		"timers": {"action_seconds": 10, "ai_think_ms": -1},
+		"multi_win": "first"
+	}`))
+	if err != nil {
+		t.Fatalf("ParseRoomRules failed: %v", err)
+	}
+
+	if rules.GameType != GameType13 || !rules.Flowers || rules.Dice != 2 {
+		t.Errorf("Unexpected tile settings: %+v", rules)
+	}
+	if rules.PointRule != (PointRule{Base: 300, PerTai: 100}) {
+		t.Errorf("Unexpected point rule: %+v", rules.PointRule)
+	}
+	if rules.WinRule.MinTai != 2 || rules.WinRule.Penalty != FalseWinPayAll {
+		t.Errorf("Expected min tai 2 with default penalty, got %+v", rules.WinRule)
+	}
+	if rules.Timers.ActionTimeout() != 10*time.Second || rules.Timers.AIThinkTime() != 0 {
+		t.Errorf("Unexpected timers: %+v", rules.Timers)
+	}
+	if rules.MultiWin != MultiWinFirst {
+		t.Errorf("Expected multi_win first, got %s", rules.MultiWin)
+	}
+	if rules.GameLength.Mode != LengthFull {
+		t.Errorf("Expected default game length, got %+v", rules.GameLength)
+	}
+}
+
+func TestParseRoomRules_Invalid(t *testing.T) {
+	if _, err := ParseRoomRules([]byte(`{"dice": "three"}`)); err == nil {
+		t.Errorf("Expected invalid JSON to fail")
+	}
+}
+
+func TestRoomRules_Normalize(t *testing.T) {
+	r := RoomRules{GameType: GameType13, Dice: 5, MultiWin: "nobody"}.Normalize()
+
+	if r.Dice != 2 {
+		t.Errorf("Expected invalid dice to fall back to 2 for 13-tile, got %d", r.Dice)
+	}
+	if r.PointRule != DefaultPointRule || r.MultiWin != MultiWinAll {
+		t.Errorf("Unexpected normalized rules: %+v", r)
+	}
+	if r.Timers.AIThinkTime() != time.Second {
+		t.Errorf("Expected default AI think time, got %v", r.Timers.AIThinkTime())
+	}
+}
+
+func TestRoomRules_SpecialShapesAndValidate(t *testing.T) {
+	if n := len(DefaultRoomRules(GameType13).EnabledSpecialShapes()); n != 2 {
+		t.Errorf("Expected 2 default 13-tile shapes, got %d", n)
+	}
+	if n := len(RoomRules{GameType: GameType13, SpecialShapes: []string{}}.EnabledSpecialShapes()); n != 0 {
+		t.Errorf("Expected empty list to disable special shapes, got %d", n)
+	}
+
+	if err := DefaultRoomRules(GameType16).Validate(); err != nil {
+		t.Errorf("Default rules should validate: %v", err)
+	}
+	if err := (RoomRules{TaiTable: "missing"}).Validate(); err == nil {
+		t.Errorf("Expected unknown tai table to fail validation")
+	}
+}
+
+func TestGameState_RulesRoundTrip(t *testing.T) {
+	state := GameState{GameID: "g1", Rules: DefaultRoomRules(GameType13)}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var loaded GameState
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if loaded.Rules.GameType != GameType13 || loaded.Rules.Flowers || loaded.Rules.Dice != 2 {
+		t.Errorf("Rules not preserved: %+v", loaded.Rules)
+	}
+}
+
+func TestRoomRules_BotStrategies(t *testing.T) {
+	rules, err := ParseRoomRules([]byte(`{
+		"ai_strategy": "efficiency",
+		"bot_strategies": {"3": "beginner", "4": "unknown", "7": "basic"}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseRoomRules failed: %v", err)
+	}
+
+	if got := rules.BotStrategy(3); got != AIStrategyBeginner {
+		t.Errorf("Expected seat 3 to use beginner, got %s", got)
+	}
+	// 不合法的策略與座位被移除，採房間預設
+	if got := rules.BotStrategy(4); got != AIStrategyEfficiency {
+		t.Errorf("Expected seat 4 to fall back to efficiency, got %s", got)
+	}
+	if _, ok := rules.BotStrategies[7]; ok {
+		t.Error("Expected invalid seat 7 to be dropped")
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+// RoundHand 局結束時攤牌的單一座位牌面
+type RoundHand struct {
+	Seat       int    `json:"seat"`        // 座位 (1-4)
+	ClosedHand []Tile `json:"closed_hand"` // 手牌 (暗牌)
+	Melds      []Meld `json:"melds"`       // 副露
+	Flowers    []Tile `json:"flowers"`     // 花牌
+	Notation   string `json:"notation"`    // 牌譜記法 (供紀錄與重播使用)
+}
+
+// RoundResult 單局結算結果 (ROUND_OVER 時產生並廣播一次)
+type RoundResult struct {
+	WinnerIDs      []int               `json:"winner_ids"`      // 贏家 (依胡牌順位)，流局時為空
+	DiscarderID    int                 `json:"discarder_id"`    // 放槍者，自摸或流局時為 0
+	WinningTile    *Tile               `json:"winning_tile"`    // 胡牌張，流局時為 null
+	IsDraw         bool                `json:"is_draw"`         // 是否流局
+	DrawReason     DrawReason          `json:"draw_reason"`     // 流局原因 (荒莊、四槓散了、四風連打、九種九牌)
+	Hands          []RoundHand         `json:"hands"`           // 四家攤牌
+	ScoreResults   map[int]ScoreResult `json:"score_results"`   // 各贏家的牌型與台數
+	Transfers      []PointTransfer     `json:"transfers"`       // 本局點數轉移
+	Scores         map[int]int         `json:"scores"`          // 結算後的累計分數
+	NextDealerID   int                 `json:"next_dealer_id"`  // 下一局莊家
+	DealerRetained bool                `json:"dealer_retained"` // 是否連莊 (局號不前進)
+}
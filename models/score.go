@@ -1,9 +1,17 @@
 package models
 
+import "sort"
+
 // ScoreResult 儲存結算結果與所有達成的牌型名稱、總台數
 type ScoreResult struct {
-	TotalTai int
-	Patterns map[string]int // ex: {"自摸": 1, "平胡": 2}
+	TotalTai int            `json:"total_tai"`
+	Patterns map[string]int `json:"patterns"` // ex: {"自摸": 1, "平胡": 2}
+}
+
+// PatternTai 單一牌型與其台數
+type PatternTai struct {
+	Name string `json:"name"`
+	Tai  int    `json:"tai"`
 }
 
 func NewScoreResult() ScoreResult {
@@ -20,6 +28,21 @@ func (s *ScoreResult) AddPattern(name string, tai int) {
 	}
 }
 
+// SortedPatterns 依台數由高到低 (同台數依名稱) 列出所有牌型，供顯示使用
+func (s ScoreResult) SortedPatterns() []PatternTai {
+	list := make([]PatternTai, 0, len(s.Patterns))
+	for name, tai := range s.Patterns {
+		list = append(list, PatternTai{Name: name, Tai: tai})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Tai != list[j].Tai {
+			return list[i].Tai > list[j].Tai
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
 // ScoringContext 計算台數所需的完整狀態
 type ScoringContext struct {
 	ClosedHand  []Tile // 玩家手中的暗牌 (不包含剛胡的那張)
@@ -28,6 +51,28 @@ type ScoringContext struct {
 	IsSelfDrawn bool   // 是否為自摸
 	IsDealer    bool   // 是否為莊家
 	Flowers     []Tile // 抽到的花牌
+
+	PrevailingWind WindPosition // 圈風 (GameRound.PrevailingWind)
+	SeatWind       WindPosition // 贏家的門風 (依莊家位置決定)
+
+	IsLastTile        bool // 摸到海底最後一張牌自摸 (海底撈月)
+	IsLastDiscard     bool // 胡海底最後一張打出的牌 (河底撈魚)
+	IsKongReplacement bool // 槓牌後補進的牌自摸 (槓上開花)
+	IsRobbingKong     bool // 胡別人加槓的牌 (搶槓)
+	IsFirstGoAround   bool // 未被打斷的第一巡內胡牌 (天胡/地胡/人胡)
+
+	FlowerWin FlowerWinType // 花牌直接胡牌 (八仙過海/七搶一)，成立時不檢查手牌
+
+	SpecialShapes []SpecialShape // 本局啟用的特殊胡牌型 (七對子、十三么、嚦咕嚦咕等)
+	TaiTable      *TaiTable      // 本局使用的台數表 (nil 時使用預設台數表)
+}
+
+// taiTable 本次計台使用的台數表
+func (ctx ScoringContext) taiTable() *TaiTable {
+	if ctx.TaiTable != nil {
+		return ctx.TaiTable
+	}
+	return DefaultTaiTable()
 }
 
 // TileCombo 代表一組已解構的牌 (順子, 刻子, 雀頭)
@@ -37,11 +82,34 @@ const (
 	ComboPair     ComboType = 1 // 雀頭 (對子)
 	ComboSequence ComboType = 2 // 順子
 	ComboTriplet  ComboType = 3 // 刻子
+	ComboKong     ComboType = 4 // 槓子 (僅來自副露)
 )
 
 type TileCombo struct {
-	Type  ComboType
-	Tiles []Tile
+	Type      ComboType
+	Tiles     []Tile
+	IsMeld    bool // 是否為副露 (吃/碰/槓) 固定的組合
+	Concealed bool // 副露中的暗槓 (計算暗刻、門清時視為暗牌)
+}
+
+// IsTripletLike 是否為刻子或槓子 (碰碰胡、暗刻、字牌刻子等判定用)
+func (c TileCombo) IsTripletLike() bool {
+	return c.Type == ComboTriplet || c.Type == ComboKong
+}
+
+// MeldToCombo 將副露轉換為固定的 TileCombo：吃 → 順子，碰 → 明刻，槓 → 槓子
+func MeldToCombo(m Meld) TileCombo {
+	combo := TileCombo{Tiles: m.Tiles, IsMeld: true}
+	switch m.Type {
+	case MeldTypeChow:
+		combo.Type = ComboSequence
+	case MeldTypePong:
+		combo.Type = ComboTriplet
+	default:
+		combo.Type = ComboKong
+		combo.Concealed = m.Type == MeldTypeHiddenKong
+	}
+	return combo
 }
 
 // Partition 代表一種手牌的合法拆解方式 (包含各組 combo)
@@ -154,96 +222,181 @@ func FindAllPartitions(hand []Tile) []Partition {
 	return results
 }
 
+// FindAllPartitionsWithMelds 拆解暗牌區 (closed，長度 3N+2)，並將每組副露作為固定組合加入每一種拆解方案
+// 使拆解結果涵蓋整副 14 或 17 張 (槓子以 4 張計) 的胡牌
+func FindAllPartitionsWithMelds(closed []Tile, melds []Meld) []Partition {
+	partitions := FindAllPartitions(closed)
+	if len(melds) == 0 {
+		return partitions
+	}
+
+	meldCombos := make([]TileCombo, len(melds))
+	for i, m := range melds {
+		meldCombos[i] = MeldToCombo(m)
+	}
+
+	for i := range partitions {
+		partitions[i].Combos = append(partitions[i].Combos, meldCombos...)
+	}
+	return partitions
+}
+
 // CalculateScore 根據情境計算手牌能獲得的最大台數
 func CalculateScore(ctx ScoringContext) ScoreResult {
-	// 組合出完整的手牌 (包含胡的那張牌)
+	if ctx.FlowerWin != FlowerWinNone {
+		return scoreFlowerWin(ctx)
+	}
+
+	// 組合出完整的暗牌區 (包含胡的那張牌)，副露於拆解時以固定組合加入
 	fullHand := append([]Tile{}, ctx.ClosedHand...)
 	fullHand = append(fullHand, ctx.WinningTile)
 
-	partitions := FindAllPartitions(fullHand)
-	if len(partitions) == 0 {
-		return NewScoreResult() // 詐胡或未達成基本胡牌條件 (理論上不該發生，前置會有 CanHu 擋住)
+	partitions := FindAllPartitionsWithMelds(fullHand, ctx.Melds)
+	specialShapes := MatchSpecialShapes(fullHand, ctx.SpecialShapes)
+	if len(partitions) == 0 && len(specialShapes) == 0 {
+		return NewScoreResult() // 詐胡或未達成胡牌條件 (理論上不該發生，前置會有 IsWinningHand 擋住)
 	}
 
 	bestScore := ScoreResult{TotalTai: -1}
 
-	// 遍歷所有可能的拆解方式，取台數最高者
-	for _, p := range partitions {
-		score := evaluatePartition(ctx, p, fullHand)
+	// 特殊牌型與一般拆解擇優計算
+	for _, shape := range specialShapes {
+		score := evaluateSpecialShape(ctx, shape, fullHand)
 		if score.TotalTai > bestScore.TotalTai {
 			bestScore = score
 		}
 	}
+	if len(partitions) == 0 {
+		return bestScore
+	}
+
+	singleWait := isSingleWait(ctx)
+
+	// 遍歷所有可能的拆解方式與胡牌張所完成的組合，取台數最高者
+	for _, p := range partitions {
+		candidates := waitCandidates(p, ctx.WinningTile)
+		if len(candidates) == 0 {
+			candidates = []int{-1}
+		}
+		for _, waitIdx := range candidates {
+			score := evaluatePartition(ctx, p, fullHand, waitIdx, singleWait)
+			if score.TotalTai > bestScore.TotalTai {
+				bestScore = score
+			}
+		}
+	}
 
 	return bestScore
 }
 
-func evaluatePartition(ctx ScoringContext, p Partition, fullHand []Tile) ScoreResult {
-	res := NewScoreResult()
-
-	// 1. 各身分與狀態基本台
-	if ctx.IsDealer {
-		res.AddPattern("莊家", 1)
+// isSingleWait 獨聽：胡牌前只聽一種牌 (含本局啟用的特殊牌型)
+// 暗牌與副露已持有四張的牌種不可能再摸到，不算在聽牌內
+func isSingleWait(ctx ScoringContext) bool {
+	held := make([]int, 34)
+	for _, t := range ctx.ClosedHand {
+		if idx := t.ToIndex(); idx != -1 {
+			held[idx]++
+		}
 	}
-	if ctx.IsSelfDrawn {
-		res.AddPattern("自摸", 1)
+	for _, m := range ctx.Melds {
+		for _, t := range m.Tiles {
+			if idx := t.ToIndex(); idx != -1 {
+				held[idx]++
+			}
+		}
 	}
 
-	// 門清 (沒有非暗槓的吃碰槓)
-	isConcealed := true
-	for _, m := range ctx.Melds {
-		if m.Type != MeldTypeHiddenKong {
-			isConcealed = false
-			break
+	waits := 0
+	test := make([]Tile, len(ctx.ClosedHand), len(ctx.ClosedHand)+1)
+	copy(test, ctx.ClosedHand)
+	for idx := 0; idx < 34; idx++ {
+		if held[idx] >= 4 {
+			continue
+		}
+		if IsWinningHand(append(test, TileFromIndex(idx)), ctx.SpecialShapes) {
+			waits++
 		}
 	}
-	if isConcealed {
-		res.AddPattern("門清", 1)
+	return waits == 1
+}
+
+// evaluateSpecialShape 計算特殊牌型的台數
+// 特殊牌型無法拆成順刻，只計基本台、牌型台、花牌台與花色台
+func evaluateSpecialShape(ctx ScoringContext, shape SpecialShape, fullHand []Tile) ScoreResult {
+	conds := HandConditions{}
+
+	evaluateBaseTai(ctx, conds)
+	conds.Add(shape.ID, 1)
+	evaluateFlowers(ctx, conds)
+	evaluateSuits(ctx, fullHand, conds)
+
+	return ctx.taiTable().Apply(conds)
+}
+
+// evaluatePartition 計算單一拆解方式的台數
+// waitIdx 為胡牌張所完成的組合在 p.Combos 中的索引 (-1 代表無法判定)，singleWait 代表是否獨聽
+func evaluatePartition(ctx ScoringContext, p Partition, fullHand []Tile, waitIdx int, singleWait bool) ScoreResult {
+	conds := HandConditions{}
+
+	wait := WaitUnknown
+	if waitIdx >= 0 {
+		wait = waitShapeOf(p.Combos[waitIdx], ctx.WinningTile)
 	}
 
+	// 1. 各身分與狀態基本台、胡牌時機台
+	evaluateBaseTai(ctx, conds)
+
 	// 2. 統計暗刻數量 (三/四/五暗刻)
 	// 定義：暗牌區的刻子 + 暗槓。如果胡的那張牌剛好完成某個刻子，且不是自摸，該刻子算明刻。
+	// 碰出的刻子與明槓為明刻。
 	concealedTripletsCount := 0
+	allTriplets := true
 
-	// 計算從 partition 來的刻子
-	for _, combo := range p.Combos {
-		if combo.Type == ComboTriplet {
-			// 判斷這個刻子是否包含胡的那張牌，且不是自摸
-			containsWinningTile := false
-			for _, t := range combo.Tiles {
-				if t.Type == ctx.WinningTile.Type && t.Value == ctx.WinningTile.Value {
-					containsWinningTile = true
-					break
-				}
-			}
+	for i, combo := range p.Combos {
+		if combo.Type == ComboSequence {
+			allTriplets = false
+		}
+		if !combo.IsTripletLike() {
+			continue
+		}
 
-			if containsWinningTile && !ctx.IsSelfDrawn {
-				// 別人打的，算明刻
-			} else {
-				concealedTripletsCount++
+		if combo.IsMeld {
+			if combo.Concealed {
+				concealedTripletsCount++ // 暗槓
 			}
+			continue
 		}
-	}
 
-	// 加上暗槓數量
-	for _, m := range ctx.Melds {
-		if m.Type == MeldTypeHiddenKong {
+		// 判斷這個刻子是否由胡的那張牌完成 (雙碰)，且不是自摸
+		if i == waitIdx && !ctx.IsSelfDrawn {
+			// 別人打的，算明刻
+		} else {
 			concealedTripletsCount++
 		}
 	}
 
-	if concealedTripletsCount == 5 {
-		res.AddPattern("五暗刻", 8)
-	} else if concealedTripletsCount == 4 {
-		res.AddPattern("四暗刻", 4)
-	} else if concealedTripletsCount == 3 {
-		res.AddPattern("三暗刻", 2)
+	switch {
+	case concealedTripletsCount >= 5:
+		conds.Add(CondFiveConcealed, 1)
+	case concealedTripletsCount == 4:
+		conds.Add(CondFourConcealed, 1)
+	case concealedTripletsCount == 3:
+		conds.Add(CondThreeConcealed, 1)
+	}
+
+	// 碰碰胡：整副牌 (含副露) 皆為刻子/槓子 + 雀頭
+	if allTriplets {
+		conds.Add(CondAllTriplets, 1)
+	}
+
+	// 3. 獨聽 (邊張、中洞、單吊且只聽一種牌)
+	if singleWait {
+		conds.Add(CondSingleWait, 1)
 	}
 
-	// 3. 判斷平胡
-	// 條件：無字、無花、無刻子 (只有順子+雀頭)、不含任何副露(除吃以外)、非自摸
-	// 備註：嚴格的平胡甚至可能要求雀頭不能是字牌、聽牌型態為雙頭等，此處採寬鬆/基本判定
-	if !ctx.IsSelfDrawn && len(ctx.Flowers) == 0 {
+	// 判斷平胡
+	// 條件：無字 (含雀頭)、無花、無刻子 (只有順子+雀頭)、不含任何副露(除吃以外)、非自摸、兩面聽
+	if !ctx.IsSelfDrawn && len(ctx.Flowers) == 0 && wait == WaitTwoSided && !singleWait {
 		hasOnlyChow := true
 		for _, m := range ctx.Melds {
 			if m.Type != MeldTypeChow {
@@ -255,7 +408,7 @@ func evaluatePartition(ctx ScoringContext, p Partition, fullHand []Tile) ScoreRe
 		if hasOnlyChow {
 			hasTripletsOrHonors := false
 			for _, combo := range p.Combos {
-				if combo.Type == ComboTriplet {
+				if combo.IsTripletLike() {
 					hasTripletsOrHonors = true
 					break
 				}
@@ -267,12 +420,66 @@ func evaluatePartition(ctx ScoringContext, p Partition, fullHand []Tile) ScoreRe
 				}
 			}
 			if !hasTripletsOrHonors {
-				res.AddPattern("平胡", 2)
+				conds.Add(CondPingHu, 1)
 			}
 		}
 	}
 
-	// 4. 花色判斷 (清一色 8 / 混一色 4 / 字一色 16)
+	// 4. 字牌台 (三元牌、圈風、門風、大小三元、大小四喜)
+	evaluateHonors(ctx, p, conds)
+
+	// 花牌台 (正花、花槓)
+	evaluateFlowers(ctx, conds)
+
+	// 5. 花色判斷 (清一色 / 混一色 / 字一色)
+	evaluateSuits(ctx, fullHand, conds)
+
+	// 依台數表換算台數 (含牌型取代與互斥)
+	return ctx.taiTable().Apply(conds)
+}
+
+// evaluateBaseTai 偵測與拆解方式無關的基本條件 (莊家、自摸、門清、第一巡與胡牌時機)
+// 天胡/地胡/人胡成立時不另計自摸與門清，由台數表的取代規則處理
+func evaluateBaseTai(ctx ScoringContext, conds HandConditions) {
+	if ctx.IsDealer {
+		conds.Add(CondDealer, 1)
+	}
+
+	evaluateFirstGoAround(ctx, conds)
+
+	if ctx.IsSelfDrawn {
+		conds.Add(CondSelfDrawn, 1)
+	}
+
+	// 門清 (沒有非暗槓的吃碰槓)
+	isConcealed := true
+	for _, m := range ctx.Melds {
+		if m.Type != MeldTypeHiddenKong {
+			isConcealed = false
+			break
+		}
+	}
+	if isConcealed {
+		conds.Add(CondConcealed, 1)
+	}
+
+	// 胡牌時機 (海底撈月、河底撈魚、槓上開花、搶槓)
+	if ctx.IsSelfDrawn && ctx.IsLastTile {
+		conds.Add(CondLastTile, 1)
+	}
+	if !ctx.IsSelfDrawn && ctx.IsLastDiscard {
+		conds.Add(CondLastDiscard, 1)
+	}
+	if ctx.IsSelfDrawn && ctx.IsKongReplacement {
+		conds.Add(CondKongReplacement, 1)
+	}
+	if !ctx.IsSelfDrawn && ctx.IsRobbingKong {
+		conds.Add(CondRobbingKong, 1)
+	}
+}
+
+// evaluateSuits 依所有牌 (包含副露) 的花色偵測清一色、混一色、字一色
+func evaluateSuits(ctx ScoringContext, fullHand []Tile, conds HandConditions) {
 	// 統計所有牌 (包含副露) 的萬、筒、條、字牌數量
 	suitCount := make(map[TileType]int)
 
@@ -299,12 +506,85 @@ func evaluatePartition(ctx ScoringContext, p Partition, fullHand []Tile) ScoreRe
 	}
 
 	if kindsOfSuits == 0 && hasHonors {
-		res.AddPattern("字一色", 16)
+		conds.Add(CondAllHonors, 1)
 	} else if kindsOfSuits == 1 && !hasHonors {
-		res.AddPattern("清一色", 8)
+		conds.Add(CondFullFlush, 1)
 	} else if kindsOfSuits == 1 && hasHonors {
-		res.AddPattern("混一色", 4)
+		conds.Add(CondHalfFlush, 1)
+	}
+}
+
+// evaluateFirstGoAround 偵測第一巡胡牌的限定牌型
+//   - 天胡：莊家開門 (尚未出牌) 即自摸
+//   - 地胡：閒家第一次摸牌即自摸
+//   - 人胡：第一巡內胡他人打出的牌
+func evaluateFirstGoAround(ctx ScoringContext, conds HandConditions) {
+	if !ctx.IsFirstGoAround {
+		return
+	}
+
+	switch {
+	case ctx.IsSelfDrawn && ctx.IsDealer:
+		conds.Add(CondHeavenlyHand, 1)
+	case ctx.IsSelfDrawn:
+		conds.Add(CondEarthlyHand, 1)
+	default:
+		conds.Add(CondHumanHand, 1)
 	}
+}
 
-	return res
+// evaluateHonors 偵測字牌相關條件 (依拆解後的面子判斷，副露與槓子一併計入)
+//   - 三元牌：中/發/白 刻子數
+//   - 圈風、門風：與圈風/門風相同的風牌刻子
+//   - 小三元、大三元、小四喜、大四喜 (不另計的牌型由台數表的取代規則處理)
+func evaluateHonors(ctx ScoringContext, p Partition, conds HandConditions) {
+	dragonTriplets, dragonPair := 0, false
+	windTriplets, windPair := 0, false
+	hasPrevailing, hasSeat := false, false
+
+	for _, combo := range p.Combos {
+		if len(combo.Tiles) == 0 {
+			continue
+		}
+		t := combo.Tiles[0]
+		switch {
+		case t.Type == Dragon && combo.IsTripletLike():
+			dragonTriplets++
+		case t.Type == Dragon && combo.Type == ComboPair:
+			dragonPair = true
+		case t.Type == Wind && combo.IsTripletLike():
+			windTriplets++
+			if WindPosition(t.Value) == ctx.PrevailingWind {
+				hasPrevailing = true
+			}
+			if WindPosition(t.Value) == ctx.SeatWind {
+				hasSeat = true
+			}
+		case t.Type == Wind && combo.Type == ComboPair:
+			windPair = true
+		}
+	}
+
+	// 三元牌
+	conds.Add(CondDragonTriplet, dragonTriplets)
+	switch {
+	case dragonTriplets == 3:
+		conds.Add(CondBigDragons, 1)
+	case dragonTriplets == 2 && dragonPair:
+		conds.Add(CondLittleDragons, 1)
+	}
+
+	// 風牌
+	switch {
+	case windTriplets == 4:
+		conds.Add(CondBigWinds, 1)
+	case windTriplets == 3 && windPair:
+		conds.Add(CondLittleWinds, 1)
+	}
+	if hasPrevailing {
+		conds.Add(CondPrevailingWind, 1)
+	}
+	if hasSeat {
+		conds.Add(CondSeatWind, 1)
+	}
 }
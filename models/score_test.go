@@ -4,22 +4,19 @@ import (
 	"testing"
 )
 
-func TestCalculateScore_PingHu(t *testing.T) {
-	// 平胡測試: 2萬3萬4萬, 5筒6筒7筒, 1條2條3條, 4條5條6條, 9筒9筒
-	ctx := ScoringContext{
-		ClosedHand: []Tile{
-			{Type: Wan, Value: 2}, {Type: Wan, Value: 3}, {Type: Wan, Value: 4},
-			{Type: Tong, Value: 5}, {Type: Tong, Value: 6}, {Type: Tong, Value: 7},
-			{Type: Tiao, Value: 1}, {Type: Tiao, Value: 2}, {Type: Tiao, Value: 3},
-			{Type: Tiao, Value: 4}, {Type: Tiao, Value: 5}, {Type: Tiao, Value: 6},
-			{Type: Tong, Value: 9},
-		},
-		WinningTile: Tile{Type: Tong, Value: 9}, // 湊成對子
-		IsSelfDrawn: false,
-		IsDealer:    false,
-		Melds:       []Meld{}, // 門清
-		Flowers:     []Tile{},
+// scoringHand 由牌譜記法建立計分上下文 (暗手牌、副露、胡牌張與花牌)
+func scoringHand(notation string) ScoringContext {
+	h := MustParseHand(notation)
+	ctx := ScoringContext{ClosedHand: h.Closed, Melds: h.Melds, Flowers: h.Flowers}
+	if h.WinningTile != nil {
+		ctx.WinningTile = *h.WinningTile
 	}
+	return ctx
+}
+
+func TestCalculateScore_PingHu(t *testing.T) {
+	// 平胡測試: 2萬3萬 聽 1萬/4萬 (兩面), 5筒6筒7筒, 1條2條3條, 4條5條6條, 9筒9筒
+	ctx := scoringHand("23m567p123456s99p +4m") // 兩面聽、門清
 
 	res := CalculateScore(ctx)
 
@@ -32,20 +29,99 @@ func TestCalculateScore_PingHu(t *testing.T) {
 	}
 }
 
+func TestCalculateScore_SingleWaitBreaksPingHu(t *testing.T) {
+	// 單吊 9筒：獨聽 1 台，且不符合平胡 (非兩面聽)
+	ctx := scoringHand("234m567p123456s9p +9p")
+
+	res := CalculateScore(ctx)
+
+	if res.Patterns["獨聽"] != 1 {
+		t.Errorf("Expected 獨聽 1, got %v", res.Patterns)
+	}
+	if _, ok := res.Patterns["平胡"]; ok {
+		t.Errorf("Single wait should not score 平胡, got %v", res.Patterns)
+	}
+}
+
+func TestCalculateScore_SingleWaitIgnoresFullyHeldTiles(t *testing.T) {
+	// 2345555m 看似聽 2萬/5萬，但 5萬 四張都在手上，實際只聽 2萬
+	res := CalculateScore(scoringHand("2345555m567p123s +2m"))
+	if res.Patterns["獨聽"] != 1 {
+		t.Errorf("Expected 獨聽 1 with all 5m held, got %v", res.Patterns)
+	}
+
+	// 第四張 5萬 在碰出的副露中
+	res = CalculateScore(scoringHand("2345m567p123s [555m] +2m"))
+	if res.Patterns["獨聽"] != 1 {
+		t.Errorf("Expected 獨聽 1 with 5m held in a meld, got %v", res.Patterns)
+	}
+
+	// 只持有三張 5萬 時仍聽 2萬/5萬
+	res = CalculateScore(scoringHand("234555m567p123s99s +2m"))
+	if _, ok := res.Patterns["獨聽"]; ok {
+		t.Errorf("Two waits should not score 獨聽, got %v", res.Patterns)
+	}
+}
+
+func TestIsSingleWait_CountsSpecialShapes(t *testing.T) {
+	// 1122m5566p99s11z3z 一般拆解不聽牌，啟用七對子時只聽 3z
+	ctx := scoringHand("1122m5566p99s11z3z +3z")
+	if isSingleWait(ctx) {
+		t.Errorf("Expected no wait without special shapes")
+	}
+	ctx.SpecialShapes = LookupSpecialShapes([]string{ShapeSevenPairs})
+	if !isSingleWait(ctx) {
+		t.Errorf("Expected a single wait on 3z with seven pairs enabled")
+	}
+}
+
+func TestCalculateScore_PingHuRejectsHonorPairAndFlowers(t *testing.T) {
+	res := CalculateScore(scoringHand("23m567p123456s33z +1m"))
+	if _, ok := res.Patterns["平胡"]; ok {
+		t.Errorf("Honor pair should not score 平胡, got %v", res.Patterns)
+	}
+
+	res = CalculateScore(scoringHand("23m567p123456s99p +1m 2f"))
+	if _, ok := res.Patterns["平胡"]; ok {
+		t.Errorf("Flowers should not score 平胡, got %v", res.Patterns)
+	}
+}
+
+func TestWaitShapeOf(t *testing.T) {
+	seq := func(tt TileType, low int) TileCombo {
+		return TileCombo{Type: ComboSequence, Tiles: []Tile{{Type: tt, Value: low}, {Type: tt, Value: low + 1}, {Type: tt, Value: low + 2}}}
+	}
+	cases := []struct {
+		combo TileCombo
+		tile  Tile
+		want  WaitShape
+	}{
+		{seq(Wan, 1), Tile{Type: Wan, Value: 3}, WaitEdge},
+		{seq(Wan, 7), Tile{Type: Wan, Value: 7}, WaitEdge},
+		{seq(Wan, 3), Tile{Type: Wan, Value: 4}, WaitClosed},
+		{seq(Wan, 3), Tile{Type: Wan, Value: 5}, WaitTwoSided},
+		{seq(Wan, 1), Tile{Type: Wan, Value: 1}, WaitTwoSided},
+		{TileCombo{Type: ComboPair}, Tile{Type: Wan, Value: 5}, WaitPair},
+		{TileCombo{Type: ComboTriplet}, Tile{Type: Wan, Value: 5}, WaitDualPon},
+	}
+	for _, c := range cases {
+		if got := waitShapeOf(c.combo, c.tile); got != c.want {
+			t.Errorf("waitShapeOf(%v, %v) = %v, want %v", c.combo.Tiles, c.tile, got, c.want)
+		}
+	}
+}
+
+func TestWaitingTiles(t *testing.T) {
+	// 1萬2萬 邊張 + 其餘完整：只聽 3萬
+	waits := WaitingTiles(MustParseTiles("12m567p123456s99p"))
+	if len(waits) != 1 || waits[0].Type != Wan || waits[0].Value != 3 {
+		t.Errorf("Expected only 3萬, got %v", waits)
+	}
+}
+
 func TestCalculateScore_AllHonors(t *testing.T) {
 	// 字一色: 東東東 南南南 西西西 北北北 中中
-	ctx := ScoringContext{
-		ClosedHand: []Tile{
-			{Type: Wind, Value: 1}, {Type: Wind, Value: 1}, {Type: Wind, Value: 1},
-			{Type: Wind, Value: 2}, {Type: Wind, Value: 2}, {Type: Wind, Value: 2},
-			{Type: Wind, Value: 3}, {Type: Wind, Value: 3}, {Type: Wind, Value: 3},
-			{Type: Wind, Value: 4}, {Type: Wind, Value: 4}, {Type: Wind, Value: 4},
-			{Type: Dragon, Value: 1},
-		},
-		WinningTile: Tile{Type: Dragon, Value: 1},
-		IsSelfDrawn: false,
-		Melds:       []Meld{},
-	}
+	ctx := scoringHand("1112223334445z +5z")
 
 	res := CalculateScore(ctx)
 
@@ -60,17 +136,8 @@ func TestCalculateScore_AllHonors(t *testing.T) {
 
 func TestCalculateScore_FullFlush(t *testing.T) {
 	// 清一色: 全萬
-	ctx := ScoringContext{
-		ClosedHand: []Tile{
-			{Type: Wan, Value: 1}, {Type: Wan, Value: 1}, {Type: Wan, Value: 1},
-			{Type: Wan, Value: 2}, {Type: Wan, Value: 3}, {Type: Wan, Value: 4},
-			{Type: Wan, Value: 5}, {Type: Wan, Value: 6}, {Type: Wan, Value: 7},
-			{Type: Wan, Value: 8}, {Type: Wan, Value: 8}, {Type: Wan, Value: 8},
-			{Type: Wan, Value: 9},
-		},
-		WinningTile: Tile{Type: Wan, Value: 9},
-		IsSelfDrawn: true,
-	}
+	ctx := scoringHand("1112345678889m +9m")
+	ctx.IsSelfDrawn = true
 
 	res := CalculateScore(ctx)
 	if res.Patterns["清一色"] != 8 {
@@ -80,3 +147,128 @@ func TestCalculateScore_FullFlush(t *testing.T) {
 		t.Errorf("Expected Self-Drawn 1, got %v", res.Patterns)
 	}
 }
+
+func TestScoreResult_SortedPatterns(t *testing.T) {
+	res := NewScoreResult()
+	res.AddPattern("門清", 1)
+	res.AddPattern("清一色", 8)
+	res.AddPattern("自摸", 1)
+
+	list := res.SortedPatterns()
+	if len(list) != 3 || list[0].Name != "清一色" || list[1].Name != "自摸" && list[1].Name != "門清" {
+		t.Errorf("Expected patterns sorted by tai descending, got %v", list)
+	}
+}
+
+func TestCalculateScore_MeldsInPartition(t *testing.T) {
+	// 16 張：碰 5筒、碰 7條 + 暗牌 2萬2萬2萬 3條3條3條 東東東 9萬，胡 9萬 (放槍)
+	ctx := scoringHand("222m333s111z9m [555p] [777s] +9m")
+
+	res := CalculateScore(ctx)
+
+	if res.Patterns["碰碰胡"] != 4 {
+		t.Errorf("Expected All Triplets 4 with pong melds, got %v", res.Patterns)
+	}
+	// 碰出的刻子為明刻，只有暗牌區的 3 組算暗刻
+	if res.Patterns["三暗刻"] != 2 || res.Patterns["四暗刻"] != 0 {
+		t.Errorf("Expected Three Concealed Triplets only, got %v", res.Patterns)
+	}
+	if _, ok := res.Patterns["門清"]; ok {
+		t.Errorf("Open hand should not be concealed, got %v", res.Patterns)
+	}
+}
+
+func TestCalculateScore_HiddenKongCountsConcealed(t *testing.T) {
+	// 暗槓 8萬 + 暗牌 1筒1筒1筒 4條4條4條 2萬3萬4萬 5條，自摸 5條
+	ctx := scoringHand("111p444s234m5s (8888m) +5s")
+	ctx.IsSelfDrawn = true
+
+	res := CalculateScore(ctx)
+
+	if res.Patterns["三暗刻"] != 2 {
+		t.Errorf("Expected hidden kong to count as a concealed triplet, got %v", res.Patterns)
+	}
+	if res.Patterns["門清"] != 1 {
+		t.Errorf("Hidden kong should keep the hand concealed, got %v", res.Patterns)
+	}
+}
+
+func TestCalculateScore_HonorTai(t *testing.T) {
+	// 中中中 發發發 東東東 2萬3萬4萬 白，胡 白 (放槍)；圈風東、門風東
+	ctx := scoringHand("555666z111z234m7z +7z")
+	ctx.PrevailingWind = East
+	ctx.SeatWind = East
+
+	res := CalculateScore(ctx)
+
+	if res.Patterns["小三元"] != 4 {
+		t.Errorf("Expected Small Three Dragons 4, got %v", res.Patterns)
+	}
+	if _, ok := res.Patterns["三元牌"]; ok {
+		t.Errorf("Small Three Dragons should exclude single dragon tai, got %v", res.Patterns)
+	}
+	if res.Patterns["圈風"] != 1 || res.Patterns["門風"] != 1 {
+		t.Errorf("Expected prevailing and seat wind tai, got %v", res.Patterns)
+	}
+}
+
+func TestCalculateScore_BigThreeDragons(t *testing.T) {
+	// 中中中 發發發 白白白 1條2條3條 9筒，胡 9筒
+	ctx := scoringHand("555666777z123s9p +9p")
+	ctx.PrevailingWind = South
+	ctx.SeatWind = West
+
+	res := CalculateScore(ctx)
+
+	if res.Patterns["大三元"] != 8 {
+		t.Errorf("Expected Big Three Dragons 8, got %v", res.Patterns)
+	}
+	if _, ok := res.Patterns["三元牌"]; ok {
+		t.Errorf("Big Three Dragons should exclude single dragon tai, got %v", res.Patterns)
+	}
+}
+
+func TestSeatWind(t *testing.T) {
+	// 莊家為 3 號：3 東、4 南、1 西、2 北
+	expected := map[int]WindPosition{3: East, 4: South, 1: West, 2: North}
+	for seat, wind := range expected {
+		if got := SeatWind(seat, 3); got != wind {
+			t.Errorf("Seat %d with dealer 3: expected %s, got %s", seat, wind, got)
+		}
+	}
+}
+
+func TestCalculateScore_WinTiming(t *testing.T) {
+	closed := MustParseTiles("123m456p789s222z9p")
+	winning := MustParseTiles("9p")[0]
+
+	// 海底撈月 + 槓上開花 (自摸)
+	res := CalculateScore(ScoringContext{ClosedHand: closed, WinningTile: winning, IsSelfDrawn: true, IsLastTile: true, IsKongReplacement: true})
+	if res.Patterns["海底撈月"] != 1 || res.Patterns["槓上開花"] != 1 || res.Patterns["自摸"] != 1 {
+		t.Errorf("Expected last tile and kong replacement tai with self-drawn, got %v", res.Patterns)
+	}
+
+	// 河底撈魚 + 搶槓 (放槍)；自摸限定的台不應出現
+	res = CalculateScore(ScoringContext{ClosedHand: closed, WinningTile: winning, IsLastDiscard: true, IsRobbingKong: true, IsLastTile: true})
+	if res.Patterns["河底撈魚"] != 1 || res.Patterns["搶槓"] != 1 {
+		t.Errorf("Expected last discard and robbing kong tai, got %v", res.Patterns)
+	}
+	if _, ok := res.Patterns["海底撈月"]; ok {
+		t.Errorf("Last tile tai requires self-drawn, got %v", res.Patterns)
+	}
+
+	// 天胡：莊家第一巡自摸，不另計自摸與門清
+	res = CalculateScore(ScoringContext{ClosedHand: closed, WinningTile: winning, IsSelfDrawn: true, IsDealer: true, IsFirstGoAround: true})
+	if res.Patterns["天胡"] != 24 {
+		t.Errorf("Expected Heavenly Hand 24, got %v", res.Patterns)
+	}
+	if _, ok := res.Patterns["自摸"]; ok {
+		t.Errorf("Heavenly Hand should exclude self-drawn, got %v", res.Patterns)
+	}
+
+	// 人胡：第一巡胡他人打出的牌
+	res = CalculateScore(ScoringContext{ClosedHand: closed, WinningTile: winning, IsFirstGoAround: true})
+	if res.Patterns["人胡"] != 16 {
+		t.Errorf("Expected Human Hand 16, got %v", res.Patterns)
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+// PointRule 底/台 計分設定
+// 每筆支付點數 = 底 + 台數 × 每台點數
+type PointRule struct {
+	Base   int `json:"base"`    // 底
+	PerTai int `json:"per_tai"` // 每台點數
+}
+
+// DefaultPointRule 預設 100 底 20 台
+var DefaultPointRule = PointRule{Base: 100, PerTai: 20}
+
+// DealerBonusTai 莊家閒家互付時，閒家胡牌由莊家多付的台數 (莊家胡牌時已計入 ScoreResult 的「莊家」台)
+const DealerBonusTai = 1
+
+// Points 將台數換算為點數
+func (r PointRule) Points(tai int) int {
+	return r.Base + tai*r.PerTai
+}
+
+// PointTransfer 一筆點數轉移 (From 付給 To)
+type PointTransfer struct {
+	From   int `json:"from"`   // 付款玩家代號 (1-4)
+	To     int `json:"to"`     // 收款玩家代號 (1-4)
+	Tai    int `json:"tai"`    // 此筆計算用的台數 (含莊家加台)
+	Points int `json:"points"` // 點數
+}
+
+// WinSettlement 單一贏家的結算條件
+type WinSettlement struct {
+	WinnerID    int // 胡牌玩家 (1-4)
+	DiscarderID int // 放槍玩家 (1-4)，自摸時為 0
+	DealerID    int // 莊家玩家 (1-4)
+	TotalTai    int // 贏家的總台數 (CalculateScore 結果)
+}
+
+// SettleWin 計算單一贏家的點數轉移
+//   - 放槍：由放槍者一人支付
+//   - 自摸：其餘三家各自支付
+//   - 閒家胡牌而付款者為莊家時，莊家需多付 DealerBonusTai 台
+//
+// 一砲多響時每位贏家各自呼叫一次，分開結算
+func SettleWin(rule PointRule, w WinSettlement) []PointTransfer {
+	var payers []int
+	if w.DiscarderID != 0 && w.DiscarderID != w.WinnerID {
+		payers = []int{w.DiscarderID}
+	} else {
+		for p := 1; p <= 4; p++ {
+			if p != w.WinnerID {
+				payers = append(payers, p)
+			}
+		}
+	}
+
+	transfers := make([]PointTransfer, 0, len(payers))
+	for _, payer := range payers {
+		tai := w.TotalTai
+		if w.WinnerID != w.DealerID && payer == w.DealerID {
+			tai += DealerBonusTai
+		}
+		transfers = append(transfers, PointTransfer{
+			From:   payer,
+			To:     w.WinnerID,
+			Tai:    tai,
+			Points: rule.Points(tai),
+		})
+	}
+	return transfers
+}
+
+// ApplyTransfers 將點數轉移套用到累計分數上
+func ApplyTransfers(scores map[int]int, transfers []PointTransfer) {
+	for _, t := range transfers {
+		scores[t.From] -= t.Points
+		scores[t.To] += t.Points
+	}
+}
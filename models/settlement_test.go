@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSettleWin_Discard(t *testing.T) {
+	// 閒家 2 胡莊家 1 放的槍，3 台：莊家多付 1 台
+	rule := PointRule{Base: 100, PerTai: 20}
+	transfers := SettleWin(rule, WinSettlement{WinnerID: 2, DiscarderID: 1, DealerID: 1, TotalTai: 3})
+
+	if len(transfers) != 1 {
+		t.Fatalf("Expected 1 transfer, got %v", transfers)
+	}
+	if transfers[0].From != 1 || transfers[0].To != 2 || transfers[0].Tai != 4 || transfers[0].Points != 180 {
+		t.Errorf("Expected dealer pays 4 tai (180), got %+v", transfers[0])
+	}
+}
+
+func TestSettleWin_SelfDrawn(t *testing.T) {
+	// 閒家 3 自摸 2 台，莊家為 1：三家各付，莊家多付 1 台
+	rule := PointRule{Base: 100, PerTai: 20}
+	transfers := SettleWin(rule, WinSettlement{WinnerID: 3, DiscarderID: 0, DealerID: 1, TotalTai: 2})
+
+	if len(transfers) != 3 {
+		t.Fatalf("Expected 3 transfers, got %v", transfers)
+	}
+
+	scores := map[int]int{1: 0, 2: 0, 3: 0, 4: 0}
+	ApplyTransfers(scores, transfers)
+
+	if scores[1] != -160 || scores[2] != -140 || scores[4] != -140 || scores[3] != 440 {
+		t.Errorf("Unexpected scores after self-drawn settlement: %v", scores)
+	}
+}
+
+func TestSettleWin_DealerSelfDrawn(t *testing.T) {
+	// 莊家自摸：莊家台已計入 TotalTai，三家付相同點數
+	rule := PointRule{Base: 50, PerTai: 10}
+	transfers := SettleWin(rule, WinSettlement{WinnerID: 1, DiscarderID: 0, DealerID: 1, TotalTai: 3})
+
+	for _, tr := range transfers {
+		if tr.To != 1 || tr.Tai != 3 || tr.Points != 80 {
+			t.Errorf("Expected each payer to pay 3 tai (80) to the dealer, got %+v", tr)
+		}
+	}
+}
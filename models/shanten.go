@@ -0,0 +1,402 @@
+package models
+
+import (
+	"sort"
+	"sync"
+)
+
+// 向聽數 (shanten)：距離聽牌還差幾步
+//
+//	-1 已胡牌、0 聽牌、1 一向聽 ...
+//
+// 暗牌區 n 張 (3N+1 或 3N+2) 需要 N 組面子 + 1 雀頭，副露不影響 N (槓子亦以一組計)
+// 基本牌型以「面子、搭子、雀頭」分解計算：2N - 2*面子 - 搭子 - 雀頭 (面子 + 搭子 ≤ N)
+// 各花色互不相干，分別窮舉後再合併，字牌不能組順子
+
+// shantenMaxSets 分解時面子數的上限 (16 張玩法最多 5 組，多留一格)
+const shantenMaxSets = 6
+
+// tileCountTable 各牌種 (索引 0-33) 的張數
+type tileCountTable [34]int
+
+// countTable 統計各牌種張數 (排除花牌)，回傳計數與總張數
+func countTable(hand []Tile) (tileCountTable, int) {
+	var counts tileCountTable
+	total := 0
+	for _, t := range hand {
+		if idx := t.ToIndex(); idx != -1 {
+			counts[idx]++
+			total++
+		}
+	}
+	return counts, total
+}
+
+// Shanten 計算暗牌區的向聽數
+// melds: 已有的副露 (有副露時不考慮七對子等需門清的特殊牌型)；shapes: 本局啟用的特殊牌型
+func Shanten(closed []Tile, melds []Meld, shapes []SpecialShape) int {
+	counts, total := countTable(closed)
+	return shantenOf(&counts, total, len(melds) == 0, shapes)
+}
+
+// shantenOf 取基本牌型與各特殊牌型中最小的向聽數
+func shantenOf(counts *tileCountTable, total int, concealed bool, shapes []SpecialShape) int {
+	sets := total / 3
+	best := standardShanten(counts, sets)
+	if !concealed {
+		return best
+	}
+
+	for _, shape := range shapes {
+		s := specialShanten(counts, total, shape)
+		if s < best {
+			best = s
+		}
+	}
+	return best
+}
+
+// setsTable 分解結果：[是否有雀頭][面子數] = 最多的搭子數 (-1 為無此分解)
+type setsTable [2][shantenMaxSets + 1]int8
+
+func newSetsTable() setsTable {
+	var st setsTable
+	for p := range st {
+		for m := range st[p] {
+			st[p][m] = -1
+		}
+	}
+	return st
+}
+
+// standardShanten 基本胡牌型 (N 組面子 + 1 雀頭) 的向聽數
+func standardShanten(counts *tileCountTable, sets int) int {
+	// 依序合併萬、筒、條、字四個區塊的分解結果
+	combined := newSetsTable()
+	combined[0][0] = 0
+	for _, block := range [][2]int{{0, 9}, {9, 18}, {18, 27}, {27, 34}} {
+		var c blockCounts
+		copy(c[:], counts[block[0]:block[1]])
+		combined = mergeSetsTables(combined, decompose(c, block[0] >= 27))
+	}
+
+	best := 2*sets + 1
+	for p := 0; p <= 1; p++ {
+		for m := 0; m <= sets && m <= shantenMaxSets; m++ {
+			t := int(combined[p][m])
+			if t < 0 {
+				continue
+			}
+			if t > sets-m {
+				t = sets - m
+			}
+			if s := 2*sets - 2*m - t - p; s < best {
+				best = s
+			}
+		}
+	}
+	return best
+}
+
+// mergeSetsTables 合併兩個區塊的分解結果 (雀頭最多一個)
+func mergeSetsTables(a, b setsTable) setsTable {
+	merged := newSetsTable()
+	for pa := 0; pa <= 1; pa++ {
+		for ma, ta := range a[pa] {
+			if ta < 0 {
+				continue
+			}
+			for pb := 0; pa+pb <= 1; pb++ {
+				for mb, tb := range b[pb] {
+					if tb < 0 || ma+mb > shantenMaxSets {
+						continue
+					}
+					if t := ta + tb; t > merged[pa+pb][ma+mb] {
+						merged[pa+pb][ma+mb] = t
+					}
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// blockCounts 單一花色 (1-9) 或字牌 (東南西北中發白，只用前 7 格) 的張數
+type blockCounts [9]int
+
+// key 以 5 進位編碼張數 (每種 0-4 張)，字牌另加旗標
+func (c *blockCounts) key(honors bool) uint32 {
+	var k uint32
+	for _, n := range c {
+		k = k*5 + uint32(n)
+	}
+	if honors {
+		k |= 1 << 31
+	}
+	return k
+}
+
+// 區塊分解結果的快取：同一張數組合的分解只算一次 (超過上限後不再新增)
+const decomposeCacheLimit = 1 << 18
+
+var (
+	decomposeCacheMu sync.RWMutex
+	decomposeCache   = make(map[uint32]setsTable)
+)
+
+// decompose 窮舉區塊內所有面子、搭子、雀頭的分解，取各 (雀頭, 面子數) 下最多的搭子數
+// 以目前張數為狀態做記憶化：由第一個有牌的位置開始，取出一組組合或把該位置全當孤張
+func decompose(c blockCounts, honors bool) setsTable {
+	key := c.key(honors)
+	decomposeCacheMu.RLock()
+	cached, ok := decomposeCache[key]
+	decomposeCacheMu.RUnlock()
+	if ok {
+		return cached
+	}
+
+	pos := 0
+	for pos < len(c) && c[pos] == 0 {
+		pos++
+	}
+	result := newSetsTable()
+	if pos == len(c) {
+		result[0][0] = 0
+		return result
+	}
+
+	for _, g := range blockGroups {
+		if g.sequence && honors {
+			continue
+		}
+		next, ok := c.without(pos, g.offsets)
+		if !ok {
+			continue
+		}
+		sub := decompose(next, honors)
+		for p := 0; p+g.pair <= 1; p++ {
+			for m := 0; m+g.sets <= shantenMaxSets; m++ {
+				if t := sub[p][m]; t >= 0 && t+g.partials > result[p+g.pair][m+g.sets] {
+					result[p+g.pair][m+g.sets] = t + g.partials
+				}
+			}
+		}
+	}
+
+	// 該位置剩下的牌都當孤張
+	next := c
+	next[pos] = 0
+	rest := decompose(next, honors)
+	for p := range rest {
+		for m, t := range rest[p] {
+			if t > result[p][m] {
+				result[p][m] = t
+			}
+		}
+	}
+
+	decomposeCacheMu.Lock()
+	if len(decomposeCache) < decomposeCacheLimit {
+		decomposeCache[key] = result
+	}
+	decomposeCacheMu.Unlock()
+	return result
+}
+
+// without 從 pos 取出一組組合 (offsets 為相對位置)，不足時回傳 false
+func (c blockCounts) without(pos int, offsets []int) (blockCounts, bool) {
+	for _, off := range offsets {
+		if pos+off >= len(c) || c[pos+off] == 0 {
+			return c, false
+		}
+		c[pos+off]--
+	}
+	return c, true
+}
+
+// blockGroup 從某位置開始能取出的組合 (面子、雀頭、搭子)
+type blockGroup struct {
+	offsets  []int // 使用的牌 (相對於起始位置)
+	sequence bool  // 是否需要連續數牌 (字牌不能組)
+	sets     int
+	partials int8
+	pair     int
+}
+
+var blockGroups = []blockGroup{
+	{offsets: []int{0, 0, 0}, sets: 1},                  // 刻子
+	{offsets: []int{0, 1, 2}, sequence: true, sets: 1},  // 順子
+	{offsets: []int{0, 0}, pair: 1},                     // 雀頭
+	{offsets: []int{0, 0}, partials: 1},                 // 對子搭子 (等碰)
+	{offsets: []int{0, 1}, sequence: true, partials: 1}, // 兩面/邊張搭子
+	{offsets: []int{0, 2}, sequence: true, partials: 1}, // 嵌張搭子
+}
+
+// specialShanten 特殊牌型的向聽數；無法計算 (張數不符或未知牌型) 時回傳極大值
+// 內建牌型以「已湊齊的有效張數」計算：向聽數 = 胡牌張數 - 1 - 有效張數
+// 自訂牌型只能判斷是否已胡牌
+func specialShanten(counts *tileCountTable, total int, shape SpecialShape) int {
+	const unreachable = 99
+	sets := total / 3
+
+	switch shape.ID {
+	case ShapeSevenPairs:
+		if sets != 4 {
+			return unreachable
+		}
+		pairs, singles := pairKinds(counts)
+		return 13 - pairsUseful(pairs, singles, 7)
+	case ShapeThirteenOrphans:
+		if sets != 4 {
+			return unreachable
+		}
+		kinds, pair := 0, 0
+		for _, idx := range thirteenOrphanIndexes {
+			if counts[idx] > 0 {
+				kinds++
+			}
+			if counts[idx] >= 2 {
+				pair = 1
+			}
+		}
+		return 13 - kinds - pair
+	case ShapeLiguLigu:
+		if sets != 5 {
+			return unreachable
+		}
+		// 選一種牌做刻子，其餘取最好的 7 個對子
+		pairs, singles := pairKinds(counts)
+		best := unreachable
+		for _, c := range counts {
+			if c == 0 {
+				continue
+			}
+			p, s := pairs, singles
+			if c >= 2 {
+				p--
+			} else {
+				s--
+			}
+			if sh := 16 - min(c, 3) - pairsUseful(p, s, 7); sh < best {
+				best = sh
+			}
+		}
+		return best
+	}
+
+	if total%3 == 2 && shape.Match != nil && shape.Match(tilesFromCounts(counts)) {
+		return -1
+	}
+	return unreachable
+}
+
+// pairKinds 至少兩張的牌種數與只有一張的牌種數
+func pairKinds(counts *tileCountTable) (pairs, singles int) {
+	for _, c := range counts {
+		switch {
+		case c >= 2:
+			pairs++
+		case c == 1:
+			singles++
+		}
+	}
+	return pairs, singles
+}
+
+// pairsUseful 取最多 n 種牌各算 2 張的有效張數 (對子優先，不足再算單張)
+func pairsUseful(pairs, singles, n int) int {
+	if pairs >= n {
+		return 2 * n
+	}
+	return 2*pairs + min(singles, n-pairs)
+}
+
+// tilesFromCounts 依張數表還原手牌 (ID 為 0)
+func tilesFromCounts(counts *tileCountTable) []Tile {
+	var tiles []Tile
+	for idx, c := range counts {
+		for i := 0; i < c; i++ {
+			tiles = append(tiles, TileFromIndex(idx))
+		}
+	}
+	return tiles
+}
+
+// DiscardAnalysis 打出某張牌後的牌效
+type DiscardAnalysis struct {
+	Discard Tile   `json:"discard"` // 打出的牌 (手牌中的實際那張)
+	Shanten int    `json:"shanten"` // 打出後的向聽數
+	Tiles   []Tile `json:"tiles"`   // 有效牌 (摸到能減少向聽數的牌種，ID 為代表牌)
+	Ukeire  int    `json:"ukeire"`  // 有效牌尚未現身的總張數
+}
+
+// Ukeire 暗牌區 (3N+1 張) 的有效牌與其尚未現身的總張數
+// visible: 場上已現身的牌 (河牌、副露等，不含自己的暗牌)
+func Ukeire(closed []Tile, melds []Meld, shapes []SpecialShape, visible []Tile) ([]Tile, int) {
+	counts, total := countTable(closed)
+	seen, _ := countTable(visible)
+	shanten := shantenOf(&counts, total, len(melds) == 0, shapes)
+	return ukeireOf(&counts, &seen, total, shanten, len(melds) == 0, shapes)
+}
+
+// ukeireOf 逐一嘗試 34 種牌，能讓向聽數減少者即為有效牌
+func ukeireOf(counts, seen *tileCountTable, total, shanten int, concealed bool, shapes []SpecialShape) ([]Tile, int) {
+	var tiles []Tile
+	ukeire := 0
+	for idx := range counts {
+		remaining := 4 - counts[idx] - seen[idx]
+		if counts[idx] >= 4 {
+			continue
+		}
+		counts[idx]++
+		improved := shantenOf(counts, total+1, concealed, shapes) < shanten
+		counts[idx]--
+		if !improved {
+			continue
+		}
+
+		t := TileFromIndex(idx)
+		t.ID = KindTileID(t)
+		tiles = append(tiles, t)
+		if remaining > 0 {
+			ukeire += remaining
+		}
+	}
+	return tiles, ukeire
+}
+
+// AnalyzeDiscards 出牌前手牌 (3N+2 張暗牌) 每種可打的牌打出後的向聽數與有效牌
+// 同一牌種只列一次；依向聽數由小到大、有效牌張數由多到少排序
+func AnalyzeDiscards(hand []Tile, melds []Meld, shapes []SpecialShape, visible []Tile) []DiscardAnalysis {
+	counts, total := countTable(hand)
+	seen, _ := countTable(visible)
+	concealed := len(melds) == 0
+
+	var results []DiscardAnalysis
+	tried := make(map[int]bool)
+	for _, discard := range hand {
+		idx := discard.ToIndex()
+		if idx < 0 || tried[idx] {
+			continue
+		}
+		tried[idx] = true
+
+		// 打出的牌也會現身在河裡
+		counts[idx]--
+		seen[idx]++
+		shanten := shantenOf(&counts, total-1, concealed, shapes)
+		tiles, ukeire := ukeireOf(&counts, &seen, total-1, shanten, concealed, shapes)
+		counts[idx]++
+		seen[idx]--
+
+		results = append(results, DiscardAnalysis{Discard: discard, Shanten: shanten, Tiles: tiles, Ukeire: ukeire})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Shanten != results[j].Shanten {
+			return results[i].Shanten < results[j].Shanten
+		}
+		return results[i].Ukeire > results[j].Ukeire
+	})
+	return results
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestShanten_Standard(t *testing.T) {
+	cases := []struct {
+		hand string
+		want int
+	}{
+		{"123m456p789s11122z", -1},      // 已胡牌
+		{"123m456p789s1122z", 0},        // 雙碰聽牌
+		{"23m567p123456s99p", 0},        // 兩面聽牌
+		{"123m456p789s1z2z3z4z", 2},     // 三組面子 + 四張孤張字牌
+		{"147m258p369s1234z", 8},        // 全孤張
+		{"123m456m789m123p11s56z", 1},   // 16 張：四組面子 + 雀頭 + 兩張孤張
+		{"123m456m789m123p456p11s", -1}, // 16 張胡牌 (17 張)
+		{"123m456m789m123p456p1s", 0},   // 16 張單吊
+	}
+	for _, c := range cases {
+		if got := Shanten(MustParseTiles(c.hand), nil, nil); got != c.want {
+			t.Errorf("Shanten(%s) = %d, want %d", c.hand, got, c.want)
+		}
+	}
+}
+
+func TestShanten_SpecialShapes(t *testing.T) {
+	shapes13 := LookupSpecialShapes(DefaultSpecialShapeIDs(GameType13))
+	shapes16 := LookupSpecialShapes(DefaultSpecialShapeIDs(GameType16))
+
+	cases := []struct {
+		hand   string
+		shapes []SpecialShape
+		want   int
+	}{
+		{"147m258p369s1234z", shapes13, 6},    // 七對子 6 向聽 (十三么 7 向聽)
+		{"1122m3344p5566s7z", shapes13, 0},    // 七對子聽牌
+		{"19m19p19s1234567z", shapes13, 0},    // 十三么十三面聽
+		{"19m19p19s12345677z", shapes13, -1},  // 十三么胡牌
+		{"1122m3344p5566s1777z", shapes16, 0}, // 嚦咕嚦咕聽牌
+		{"1122m3344p5566s1777z", nil, 3},      // 不啟用時只算基本牌型
+	}
+	for _, c := range cases {
+		if got := Shanten(MustParseTiles(c.hand), nil, c.shapes); got != c.want {
+			t.Errorf("Shanten(%s) = %d, want %d", c.hand, got, c.want)
+		}
+	}
+}
+
+func TestShanten_MeldsDisableSpecialShapes(t *testing.T) {
+	// 16 張玩法已有一組副露，暗牌 13 張剛好湊成七對子聽牌的樣子也不算
+	shapes := LookupSpecialShapes([]string{ShapeSevenPairs})
+	closed := MustParseTiles("1122m3344p5566s7z")
+	melds := []Meld{{Type: MeldTypePong, Tiles: MustParseTiles("999s")}}
+
+	if got := Shanten(closed, nil, shapes); got != 0 {
+		t.Errorf("Expected seven pairs tenpai without melds, got %d", got)
+	}
+	if got := Shanten(closed, melds, shapes); got == 0 {
+		t.Errorf("Seven pairs should not apply with melds, got %d", got)
+	}
+}
+
+func TestUkeire(t *testing.T) {
+	// 兩面 1萬/4萬，場上已現身兩張 1萬
+	tiles, ukeire := Ukeire(MustParseTiles("23m567p123456s99p"), nil, nil, MustParseTiles("11m"))
+	if len(tiles) != 2 || ukeire != 6 {
+		t.Errorf("Expected 2 kinds and 6 tiles, got %v (%d)", tiles, ukeire)
+	}
+	for _, tile := range tiles {
+		if tile.ID != KindTileID(tile) {
+			t.Errorf("Expected representative tile id, got %d", tile.ID)
+		}
+	}
+}
+
+func TestAnalyzeDiscards(t *testing.T) {
+	results := AnalyzeDiscards(MustParseTiles("23m567p123456s99p5z"), nil, nil, nil)
+	if len(results) == 0 {
+		t.Fatal("Expected discard analysis")
+	}
+	best := results[0]
+	if NotationOf(best.Discard) != "5z" || best.Shanten != 0 || best.Ukeire != 8 {
+		t.Errorf("Expected discarding 5z for 8 outs at tenpai, got %+v", best)
+	}
+	for _, r := range results[1:] {
+		if r.Shanten < best.Shanten {
+			t.Errorf("Results not sorted by shanten: %+v", results)
+		}
+	}
+}
+
+var (
+	benchHand13 = MustParseTiles("1245m3368p2479s13z6z")
+	benchHand16 = MustParseTiles("11235m24668p34579s2z")
+)
+
+func BenchmarkShanten13(b *testing.B) {
+	shapes := LookupSpecialShapes(DefaultSpecialShapeIDs(GameType13))
+	for i := 0; i < b.N; i++ {
+		Shanten(benchHand13, nil, shapes)
+	}
+}
+
+func BenchmarkShanten16(b *testing.B) {
+	shapes := LookupSpecialShapes(DefaultSpecialShapeIDs(GameType16))
+	for i := 0; i < b.N; i++ {
+		Shanten(benchHand16, nil, shapes)
+	}
+}
+
+func BenchmarkShanten16SingleSuit(b *testing.B) {
+	hand := MustParseTiles("11122233445566789m")[:16]
+	for i := 0; i < b.N; i++ {
+		Shanten(hand, nil, nil)
+	}
+}
+
+func BenchmarkAnalyzeDiscards16(b *testing.B) {
+	shapes := LookupSpecialShapes(DefaultSpecialShapeIDs(GameType16))
+	hand := append(MustParseTiles("5s"), benchHand16...)
+	for i := 0; i < b.N; i++ {
+		AnalyzeDiscards(hand, nil, shapes, nil)
+	}
+}
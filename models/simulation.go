@@ -0,0 +1,123 @@
+package models
+
+import "sort"
+
+// StrategyStats 自我對戰中單一 AI 策略的累計統計，以「座位 × 局」為一手
+type StrategyStats struct {
+	Strategy AIStrategy     `json:"strategy"`
+	Hands    int            `json:"hands"`     // 參與的手數
+	Wins     int            `json:"wins"`      // 胡牌次數 (一砲多響各自計算)
+	SelfWins int            `json:"self_wins"` // 其中自摸次數
+	DealIns  int            `json:"deal_ins"`  // 放槍次數
+	Draws    int            `json:"draws"`     // 流局手數
+	TotalTai int            `json:"total_tai"` // 胡牌台數合計
+	Patterns map[string]int `json:"patterns"`  // 胡牌時各牌型出現次數
+}
+
+// PatternFrequency 牌型出現次數與佔胡牌次數的比例
+type PatternFrequency struct {
+	Name  string  `json:"name"`
+	Count int     `json:"count"`
+	Rate  float64 `json:"rate"`
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// WinRate 胡牌率 (胡牌次數 / 手數)
+func (s StrategyStats) WinRate() float64 { return ratio(s.Wins, s.Hands) }
+
+// DealInRate 放槍率 (放槍次數 / 手數)
+func (s StrategyStats) DealInRate() float64 { return ratio(s.DealIns, s.Hands) }
+
+// DrawRate 流局率 (流局手數 / 手數)
+func (s StrategyStats) DrawRate() float64 { return ratio(s.Draws, s.Hands) }
+
+// AverageTai 平均胡牌台數
+func (s StrategyStats) AverageTai() float64 { return ratio(s.TotalTai, s.Wins) }
+
+// PatternFrequencies 依出現次數由多到少 (同次數依名稱) 列出牌型頻率
+func (s StrategyStats) PatternFrequencies() []PatternFrequency {
+	list := make([]PatternFrequency, 0, len(s.Patterns))
+	for name, count := range s.Patterns {
+		list = append(list, PatternFrequency{Name: name, Count: count, Rate: ratio(count, s.Wins)})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// SimulationReport 自我對戰模擬的彙整結果
+type SimulationReport struct {
+	Seed       uint64          `json:"seed"`
+	Games      int             `json:"games"`      // 打完的將數
+	Rounds     int             `json:"rounds"`     // 打完的局數
+	Draws      int             `json:"draws"`      // 流局局數
+	Strategies []StrategyStats `json:"strategies"` // 依 AIStrategies 順序排列
+}
+
+// strategy 取得策略的統計，尚未出現時新增並維持排列順序
+func (r *SimulationReport) strategy(s AIStrategy) *StrategyStats {
+	for i := range r.Strategies {
+		if r.Strategies[i].Strategy == s {
+			return &r.Strategies[i]
+		}
+	}
+	r.Strategies = append(r.Strategies, StrategyStats{Strategy: s, Patterns: make(map[string]int)})
+	sort.SliceStable(r.Strategies, func(i, j int) bool {
+		return strategyOrder(r.Strategies[i].Strategy) < strategyOrder(r.Strategies[j].Strategy)
+	})
+	return r.strategy(s)
+}
+
+// strategyOrder 內建策略依難度排序，其他策略排在最後
+func strategyOrder(s AIStrategy) int {
+	for i, known := range AIStrategies {
+		if known == s {
+			return i
+		}
+	}
+	return len(AIStrategies)
+}
+
+// RecordRound 將一局結算記錄到各座位使用的策略 (seats: 座位 1-4 → 策略)
+func (r *SimulationReport) RecordRound(result RoundResult, seats map[int]AIStrategy) {
+	r.Rounds++
+	if result.IsDraw {
+		r.Draws++
+	}
+
+	winners := make(map[int]bool, len(result.WinnerIDs))
+	for _, id := range result.WinnerIDs {
+		winners[id] = true
+	}
+
+	for seat := 1; seat <= 4; seat++ {
+		s := r.strategy(seats[seat])
+		s.Hands++
+		switch {
+		case result.IsDraw:
+			s.Draws++
+		case winners[seat]:
+			score := result.ScoreResults[seat]
+			s.Wins++
+			if result.DiscarderID == 0 {
+				s.SelfWins++
+			}
+			s.TotalTai += score.TotalTai
+			for name := range score.Patterns {
+				s.Patterns[name]++
+			}
+		case seat == result.DiscarderID:
+			s.DealIns++
+		}
+	}
+}
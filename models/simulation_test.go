@@ -0,0 +1,72 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSimulationReport_RecordRound(t *testing.T) {
+	seats := map[int]AIStrategy{1: AIStrategyEfficiency, 2: AIStrategyBasic, 3: AIStrategyBasic, 4: AIStrategyBeginner}
+	report := &SimulationReport{}
+
+	// 座位 1 胡座位 2 放的槍
+	report.RecordRound(RoundResult{
+		WinnerIDs:   []int{1},
+		DiscarderID: 2,
+		ScoreResults: map[int]ScoreResult{
+			1: {TotalTai: 4, Patterns: map[string]int{"門清": 1, "三元牌": 1, "獨聽": 1, "莊家": 1}},
+		},
+	}, seats)
+	// 座位 3 自摸
+	report.RecordRound(RoundResult{
+		WinnerIDs: []int{3},
+		ScoreResults: map[int]ScoreResult{
+			3: {TotalTai: 2, Patterns: map[string]int{"門清": 1, "自摸": 1}},
+		},
+	}, seats)
+	// 流局
+	report.RecordRound(RoundResult{IsDraw: true, DrawReason: DrawExhaustive}, seats)
+
+	if report.Rounds != 3 || report.Draws != 1 {
+		t.Fatalf("Expected 3 rounds with 1 draw, got %d rounds %d draws", report.Rounds, report.Draws)
+	}
+
+	order := []AIStrategy{AIStrategyBeginner, AIStrategyBasic, AIStrategyEfficiency}
+	if len(report.Strategies) != len(order) {
+		t.Fatalf("Expected %d strategies, got %+v", len(order), report.Strategies)
+	}
+	for i, s := range order {
+		if report.Strategies[i].Strategy != s {
+			t.Errorf("Expected strategy %d to be %s, got %s", i, s, report.Strategies[i].Strategy)
+		}
+	}
+
+	beginner, basic, efficiency := report.Strategies[0], report.Strategies[1], report.Strategies[2]
+	if efficiency.Hands != 3 || efficiency.Wins != 1 || efficiency.WinRate() != 1.0/3 || efficiency.AverageTai() != 4 {
+		t.Errorf("Unexpected efficiency stats: %+v", efficiency)
+	}
+	// basic 佔兩個座位：6 手中胡 1 次 (自摸)、放槍 1 次、流局 2 手
+	if basic.Hands != 6 || basic.Wins != 1 || basic.SelfWins != 1 || basic.DealIns != 1 || basic.Draws != 2 {
+		t.Errorf("Unexpected basic stats: %+v", basic)
+	}
+	if basic.DealInRate() != 1.0/6 || basic.DrawRate() != 1.0/3 {
+		t.Errorf("Expected basic deal-in rate 1/6 and draw rate 1/3, got %v %v", basic.DealInRate(), basic.DrawRate())
+	}
+	if beginner.Wins != 0 || beginner.AverageTai() != 0 || len(beginner.PatternFrequencies()) != 0 {
+		t.Errorf("Expected beginner without wins, got %+v", beginner)
+	}
+}
+
+func TestStrategyStats_PatternFrequencies(t *testing.T) {
+	s := StrategyStats{Wins: 4, Patterns: map[string]int{"自摸": 1, "門清": 3, "獨聽": 1}}
+
+	freqs := s.PatternFrequencies()
+	want := []PatternFrequency{{"門清", 3, 0.75}, {"獨聽", 1, 0.25}, {"自摸", 1, 0.25}}
+	if len(freqs) != len(want) {
+		t.Fatalf("Expected %d patterns, got %+v", len(want), freqs)
+	}
+	for i := range want {
+		if freqs[i] != want[i] {
+			t.Errorf("Pattern %d: expected %+v, got %+v", i, want[i], freqs[i])
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package models
+
+// SpecialShape 特殊胡牌型 (不符合「N 組順刻 + 1 雀頭」的胡牌型態)
+// 可透過 RegisterSpecialShape 擴充，並依遊戲類型與規則決定是否啟用
+type SpecialShape struct {
+	ID    string            // 規則設定中使用的識別字 (例: "seven_pairs")
+	Name  string            // 牌型名稱 (計台時顯示)
+	Tai   int               // 牌型本身的台數
+	Match func([]Tile) bool // 判斷暗牌區 (含胡牌張、不含花牌) 是否符合此牌型
+}
+
+// 內建特殊牌型 ID
+const (
+	ShapeSevenPairs      = "seven_pairs"      // 七對子 (13 張)
+	ShapeThirteenOrphans = "thirteen_orphans" // 十三么 (13 張)
+	ShapeLiguLigu        = "ligu_ligu"        // 嚦咕嚦咕 (16 張：七對 + 一刻)
+)
+
+var specialShapeRegistry = map[string]SpecialShape{}
+
+func init() {
+	RegisterSpecialShape(SpecialShape{ID: ShapeSevenPairs, Name: "七對子", Tai: 4, Match: isSevenPairs})
+	RegisterSpecialShape(SpecialShape{ID: ShapeThirteenOrphans, Name: "十三么", Tai: 16, Match: isThirteenOrphans})
+	RegisterSpecialShape(SpecialShape{ID: ShapeLiguLigu, Name: "嚦咕嚦咕", Tai: 8, Match: isLiguLigu})
+}
+
+// RegisterSpecialShape 註冊 (或覆寫) 一種特殊牌型
+func RegisterSpecialShape(shape SpecialShape) {
+	specialShapeRegistry[shape.ID] = shape
+}
+
+// DefaultSpecialShapeIDs 各遊戲類型預設啟用的特殊牌型
+func DefaultSpecialShapeIDs(gameType GameType) []string {
+	if gameType == GameType13 {
+		return []string{ShapeSevenPairs, ShapeThirteenOrphans}
+	}
+	return []string{ShapeLiguLigu}
+}
+
+// LookupSpecialShapes 依 ID 取出已註冊的特殊牌型 (未註冊的 ID 略過)
+func LookupSpecialShapes(ids []string) []SpecialShape {
+	shapes := make([]SpecialShape, 0, len(ids))
+	for _, id := range ids {
+		if shape, ok := specialShapeRegistry[id]; ok {
+			shapes = append(shapes, shape)
+		}
+	}
+	return shapes
+}
+
+// IsWinningHand 判斷手牌是否胡牌：基本胡牌型或任一啟用的特殊牌型
+func IsWinningHand(hand []Tile, shapes []SpecialShape) bool {
+	if CanHu(hand) {
+		return true
+	}
+	return len(MatchSpecialShapes(hand, shapes)) > 0
+}
+
+// MatchSpecialShapes 回傳手牌符合的所有特殊牌型
+func MatchSpecialShapes(hand []Tile, shapes []SpecialShape) []SpecialShape {
+	var matched []SpecialShape
+	for _, shape := range shapes {
+		if shape.Match != nil && shape.Match(hand) {
+			matched = append(matched, shape)
+		}
+	}
+	return matched
+}
+
+// tileCounts 統計各牌種數量 (排除花牌)，回傳計數與總張數
+func tileCounts(hand []Tile) ([]int, int) {
+	counts := make([]int, 34)
+	total := 0
+	for _, t := range hand {
+		if idx := t.ToIndex(); idx != -1 {
+			counts[idx]++
+			total++
+		}
+	}
+	return counts, total
+}
+
+// isSevenPairs 七對子：14 張組成 7 個不同的對子
+func isSevenPairs(hand []Tile) bool {
+	counts, total := tileCounts(hand)
+	if total != 14 {
+		return false
+	}
+	for _, c := range counts {
+		if c != 0 && c != 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// isLiguLigu 嚦咕嚦咕：17 張組成 7 個不同的對子 + 1 個刻子
+func isLiguLigu(hand []Tile) bool {
+	counts, total := tileCounts(hand)
+	if total != 17 {
+		return false
+	}
+	triplets := 0
+	for _, c := range counts {
+		switch c {
+		case 0, 2:
+		case 3:
+			triplets++
+		default:
+			return false
+		}
+	}
+	return triplets == 1
+}
+
+// thirteenOrphanIndexes 么九牌與字牌的索引 (1/9 萬筒條、東南西北、中發白)
+var thirteenOrphanIndexes = []int{0, 8, 9, 17, 18, 26, 27, 28, 29, 30, 31, 32, 33}
+
+// isThirteenOrphans 十三么：13 種么九字牌各一張，其中一種成對
+func isThirteenOrphans(hand []Tile) bool {
+	counts, total := tileCounts(hand)
+	if total != 14 {
+		return false
+	}
+	pairs := 0
+	for _, idx := range thirteenOrphanIndexes {
+		switch counts[idx] {
+		case 1:
+		case 2:
+			pairs++
+		default:
+			return false
+		}
+	}
+	return pairs == 1
+}
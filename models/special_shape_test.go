@@ -0,0 +1,90 @@
+package models
+
+import (
+	"testing"
+)
+
+func pairsOf(tiles ...Tile) []Tile {
+	var hand []Tile
+	for _, t := range tiles {
+		hand = append(hand, t, t)
+	}
+	return hand
+}
+
+func TestSpecialShape_SevenPairs(t *testing.T) {
+	hand := pairsOf(
+		Tile{Type: Wan, Value: 1}, Tile{Type: Wan, Value: 5}, Tile{Type: Tong, Value: 2},
+		Tile{Type: Tong, Value: 8}, Tile{Type: Tiao, Value: 3}, Tile{Type: Wind, Value: 1},
+		Tile{Type: Dragon, Value: 2},
+	)
+
+	if CanHu(hand) {
+		t.Fatalf("Seven pairs should not be a standard winning hand")
+	}
+	if !IsWinningHand(hand, LookupSpecialShapes(DefaultSpecialShapeIDs(GameType13))) {
+		t.Errorf("Seven pairs should win in a 13-tile game")
+	}
+	if IsWinningHand(hand, LookupSpecialShapes(DefaultSpecialShapeIDs(GameType16))) {
+		t.Errorf("Seven pairs should not win in a 16-tile game")
+	}
+	if IsWinningHand(hand, LookupSpecialShapes([]string{})) {
+		t.Errorf("Seven pairs should not win when special shapes are disabled")
+	}
+}
+
+func TestSpecialShape_ThirteenOrphans(t *testing.T) {
+	var hand []Tile
+	for _, idx := range thirteenOrphanIndexes {
+		hand = append(hand, TileFromIndex(idx))
+	}
+	hand = append(hand, Tile{Type: Dragon, Value: 1})
+
+	if !isThirteenOrphans(hand) {
+		t.Errorf("Expected thirteen orphans, got %v", hand)
+	}
+
+	hand[len(hand)-1] = Tile{Type: Wan, Value: 5}
+	if isThirteenOrphans(hand) {
+		t.Errorf("Non-terminal tile should break thirteen orphans")
+	}
+}
+
+func TestSpecialShape_LiguLigu(t *testing.T) {
+	hand := pairsOf(
+		Tile{Type: Wan, Value: 1}, Tile{Type: Wan, Value: 5}, Tile{Type: Tong, Value: 2},
+		Tile{Type: Tong, Value: 8}, Tile{Type: Tiao, Value: 3}, Tile{Type: Wind, Value: 1},
+		Tile{Type: Dragon, Value: 2},
+	)
+	hand = append(hand, Tile{Type: Tiao, Value: 9}, Tile{Type: Tiao, Value: 9}, Tile{Type: Tiao, Value: 9})
+
+	if !isLiguLigu(hand) {
+		t.Errorf("Expected 嚦咕嚦咕, got %v", hand)
+	}
+	if isSevenPairs(hand) {
+		t.Errorf("17 tiles should not be seven pairs")
+	}
+}
+
+func TestCalculateScore_SpecialShape(t *testing.T) {
+	hand := pairsOf(
+		Tile{Type: Wan, Value: 1}, Tile{Type: Wan, Value: 5}, Tile{Type: Tong, Value: 2},
+		Tile{Type: Tong, Value: 8}, Tile{Type: Tiao, Value: 3}, Tile{Type: Wind, Value: 1},
+		Tile{Type: Dragon, Value: 2},
+	)
+	winning := hand[len(hand)-1]
+
+	ctx := ScoringContext{
+		ClosedHand:    hand[:len(hand)-1],
+		WinningTile:   winning,
+		IsSelfDrawn:   true,
+		SpecialShapes: LookupSpecialShapes([]string{ShapeSevenPairs}),
+	}
+
+	res := CalculateScore(ctx)
+
+	// 七對子 (4), 自摸 (1), 門清 (1) => 6台
+	if res.Patterns["七對子"] != 4 || res.TotalTai != 6 {
+		t.Errorf("Expected 七對子 4 with total 6, got %d %v", res.TotalTai, res.Patterns)
+	}
+}
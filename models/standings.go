@@ -0,0 +1,97 @@
+package models
+
+import "sort"
+
+// SeatStats 單一座位整將 (一將) 的累計統計
+type SeatStats struct {
+	Wins            int            `json:"wins"`             // 胡牌次數
+	DealIns         int            `json:"deal_ins"`         // 放槍次數
+	SelfDrawns      int            `json:"self_drawns"`      // 自摸次數
+	FalseWins       int            `json:"false_wins"`       // 詐胡次數
+	BiggestTai      int            `json:"biggest_tai"`      // 單局最大台數
+	BiggestPatterns map[string]int `json:"biggest_patterns"` // 單局最大台數的牌型
+}
+
+// RecordWin 將一次胡牌記錄到贏家與放槍者的統計中
+// discarderID 為放槍者，自摸時傳入 0
+func RecordWin(stats map[int]SeatStats, winnerID int, discarderID int, result ScoreResult) {
+	w := stats[winnerID]
+	w.Wins++
+	if discarderID == 0 || discarderID == winnerID {
+		w.SelfDrawns++
+	}
+	if result.TotalTai > w.BiggestTai || w.BiggestPatterns == nil {
+		w.BiggestTai = result.TotalTai
+		w.BiggestPatterns = result.Patterns
+	}
+	stats[winnerID] = w
+}
+
+// RecordDealIn 記錄一次放槍 (一砲多響只算一次)
+func RecordDealIn(stats map[int]SeatStats, discarderID int) {
+	d := stats[discarderID]
+	d.DealIns++
+	stats[discarderID] = d
+}
+
+// RecordFalseWin 記錄一次詐胡
+func RecordFalseWin(stats map[int]SeatStats, offenderID int) {
+	o := stats[offenderID]
+	o.FalseWins++
+	stats[offenderID] = o
+}
+
+// Standing 終局單一座位的排名資料
+type Standing struct {
+	Seat      int       `json:"seat"`       // 座位 (1-4)
+	Name      string    `json:"name"`       // 玩家名稱
+	Placement int       `json:"placement"`  // 名次 (1-4，同分同名次)
+	NetPoints int       `json:"net_points"` // 整將淨得分
+	Stats     SeatStats `json:"stats"`      // 整將統計
+}
+
+// GameEndReason 遊戲結束原因
+type GameEndReason string
+
+const (
+	GameEndCompleted GameEndReason = "completed" // 打完預定的局數
+	GameEndBust      GameEndReason = "bust"      // 有人分數低於破產門檻，提前結束
+)
+
+// FinalResult 一將結束後的最終結果 (供終局畫面與對戰紀錄使用)
+type FinalResult struct {
+	GameID       string        `json:"game_id"`
+	GameType     GameType      `json:"game_type"`
+	GameLength   GameLength    `json:"game_length"`   // 遊戲長度設定
+	EndReason    GameEndReason `json:"end_reason"`    // 結束原因
+	RoundsPlayed int           `json:"rounds_played"` // 已進行的局數
+	FinishedAt   int64         `json:"finished_at"`   // 結束時間戳
+	Standings    []Standing    `json:"standings"`     // 依名次排序
+}
+
+// BuildStandings 依累計分數由高到低排出名次，同分者同名次 (以座位順序列出)
+func BuildStandings(state *GameState) []Standing {
+	standings := make([]Standing, 0, 4)
+	for seat := 1; seat <= 4; seat++ {
+		standings = append(standings, Standing{
+			Seat:      seat,
+			Name:      state.Players[seat].Name,
+			NetPoints: state.Scores[seat],
+			Stats:     state.Stats[seat],
+		})
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].NetPoints > standings[j].NetPoints
+	})
+
+	for i := range standings {
+		if i > 0 && standings[i].NetPoints == standings[i-1].NetPoints {
+			standings[i].Placement = standings[i-1].Placement
+		} else {
+			standings[i].Placement = i + 1
+		}
+	}
+
+	return standings
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestBuildStandings(t *testing.T) {
+	state := &GameState{
+		Players: map[int]Player{
+			1: {ID: 1, Name: "A"}, 2: {ID: 2, Name: "B"}, 3: {ID: 3, Name: "C"}, 4: {ID: 4, Name: "D"},
+		},
+		Scores: map[int]int{1: -300, 2: 500, 3: -100, 4: -100},
+		Stats:  make(map[int]SeatStats),
+	}
+
+	RecordWin(state.Stats, 2, 1, ScoreResult{TotalTai: 5, Patterns: map[string]int{"清一色": 8}})
+	RecordDealIn(state.Stats, 1)
+
+	standings := BuildStandings(state)
+
+	if standings[0].Seat != 2 || standings[0].Placement != 1 {
+		t.Errorf("Expected seat 2 in first place, got %+v", standings[0])
+	}
+	if standings[0].Stats.Wins != 1 || standings[0].Stats.BiggestTai != 5 {
+		t.Errorf("Expected winner stats to be recorded, got %+v", standings[0].Stats)
+	}
+	// 座位 3、4 同分，應同為第 2 名
+	if standings[1].Placement != 2 || standings[2].Placement != 2 {
+		t.Errorf("Expected tied seats to share 2nd place, got %+v", standings)
+	}
+	if standings[3].Seat != 1 || standings[3].Placement != 4 || standings[3].Stats.DealIns != 1 {
+		t.Errorf("Expected seat 1 last with 1 deal-in, got %+v", standings[3])
+	}
+}
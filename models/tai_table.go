@@ -0,0 +1,306 @@
+package models
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"webmajiang/config"
+)
+
+// 計台條件 ID：由計台程式偵測，台數表 (TaiTable) 依條件決定牌型名稱與台數
+const (
+	CondDealer          = "dealer"           // 莊家
+	CondSelfDrawn       = "self_drawn"       // 自摸
+	CondConcealed       = "concealed"        // 門清 (無吃碰明槓)
+	CondHeavenlyHand    = "heavenly_hand"    // 天胡
+	CondEarthlyHand     = "earthly_hand"     // 地胡
+	CondHumanHand       = "human_hand"       // 人胡
+	CondLastTile        = "last_tile"        // 海底撈月
+	CondLastDiscard     = "last_discard"     // 河底撈魚
+	CondKongReplacement = "kong_replacement" // 槓上開花
+	CondRobbingKong     = "robbing_kong"     // 搶槓
+	CondThreeConcealed  = "three_concealed"  // 三暗刻
+	CondFourConcealed   = "four_concealed"   // 四暗刻
+	CondFiveConcealed   = "five_concealed"   // 五暗刻
+	CondAllTriplets     = "all_triplets"     // 碰碰胡
+	CondSingleWait      = "single_wait"      // 獨聽
+	CondPingHu          = "ping_hu"          // 平胡
+	CondDragonTriplet   = "dragon_triplet"   // 三元牌刻子 (依刻子數計)
+	CondLittleDragons   = "little_three_dragons"
+	CondBigDragons      = "big_three_dragons"
+	CondPrevailingWind  = "prevailing_wind" // 圈風刻
+	CondSeatWind        = "seat_wind"       // 門風刻
+	CondLittleWinds     = "little_four_winds"
+	CondBigWinds        = "big_four_winds"
+	CondSeatFlower      = "seat_flower"     // 正花 (依張數計)
+	CondFlowerKong      = "flower_kong"     // 花槓 (依組數計)
+	CondAllHonors       = "all_honors"      // 字一色
+	CondFullFlush       = "full_flush"      // 清一色
+	CondHalfFlush       = "half_flush"      // 混一色
+	CondEightImmortals  = "eight_immortals" // 八仙過海
+	CondSevenRobOne     = "seven_rob_one"   // 七搶一
+)
+
+// DefaultTaiTableName 預設台數表名稱
+const DefaultTaiTableName = "standard"
+
+// HandConditions 一手牌偵測到的計台條件 (條件 ID -> 成立次數)
+type HandConditions map[string]int
+
+// Add 記錄條件成立 count 次 (count <= 0 時忽略)
+func (c HandConditions) Add(id string, count int) {
+	if count > 0 {
+		c[id] += count
+	}
+}
+
+// TaiRule 台數表中的一個牌型
+type TaiRule struct {
+	ID        string   `yaml:"id" json:"id"`                                   // 牌型 ID
+	Name      string   `yaml:"name" json:"name"`                               // 顯示名稱
+	Tai       int      `yaml:"tai" json:"tai"`                                 // 台數 (條件成立多次時乘以次數，0 代表不計)
+	Condition string   `yaml:"condition" json:"condition"`                     // 使用的計台條件 ID (省略時同 ID)
+	Overrides []string `yaml:"overrides,omitempty" json:"overrides,omitempty"` // 成立時取代 (不另計) 的牌型 ID
+	Excludes  []string `yaml:"excludes,omitempty" json:"excludes,omitempty"`   // 互斥的牌型 ID，同時成立時只取台數較高者
+}
+
+// TaiTable 具名的台數表
+type TaiTable struct {
+	Name  string    `json:"name"`
+	Rules []TaiRule `json:"rules"`
+}
+
+// builtinTaiTables 內建的台數表 (編譯時內嵌的 config/tai_rules.yaml)
+var builtinTaiTables = mustParseTaiTables(config.TaiRules)
+
+var taiTables = builtinTaiTables
+
+// LookupTaiTable 依名稱取得台數表，空字串代表預設台數表
+func LookupTaiTable(name string) (*TaiTable, bool) {
+	if name == "" {
+		name = DefaultTaiTableName
+	}
+	table, ok := taiTables[name]
+	return table, ok
+}
+
+// DefaultTaiTable 目前的預設台數表
+func DefaultTaiTable() *TaiTable {
+	table, _ := LookupTaiTable(DefaultTaiTableName)
+	return table
+}
+
+// TaiTableNames 已載入的台數表名稱
+func TaiTableNames() []string {
+	names := make([]string, 0, len(taiTables))
+	for name := range taiTables {
+		names = append(names, name)
+	}
+	return names
+}
+
+// taiTableFile 台數表設定檔格式 (config/tai_rules.yaml)
+type taiTableFile struct {
+	Tables map[string]struct {
+		Extends string    `yaml:"extends"` // 以另一張表為基礎，同 ID 的牌型覆寫，其餘附加
+		Rules   []TaiRule `yaml:"rules"`
+	} `yaml:"tables"`
+}
+
+// LoadTaiTables 從 YAML 檔載入台數表並註冊 (同名者覆寫內建表)
+func LoadTaiTables(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read tai rules: %w", err)
+	}
+
+	tables, err := parseTaiTables(data, builtinTaiTables)
+	if err != nil {
+		return err
+	}
+	taiTables = tables
+	return nil
+}
+
+// mustParseTaiTables 解析內建台數表，格式錯誤時 panic
+func mustParseTaiTables(data []byte) map[string]*TaiTable {
+	tables, err := parseTaiTables(data, nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid builtin tai rules: %v", err))
+	}
+	if _, ok := tables[DefaultTaiTableName]; !ok {
+		panic(fmt.Sprintf("builtin tai rules must define the %s table", DefaultTaiTableName))
+	}
+	return tables
+}
+
+// parseTaiTables 解析台數表設定，base 中的表可被 extends 引用，同名者被覆寫
+func parseTaiTables(data []byte, base map[string]*TaiTable) (map[string]*TaiTable, error) {
+	var file taiTableFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tai rules: %w", err)
+	}
+
+	loaded := make(map[string]*TaiTable, len(base)+len(file.Tables))
+	for name, table := range base {
+		loaded[name] = table
+	}
+	var resolve func(name string, visiting map[string]bool) (*TaiTable, error)
+	resolve = func(name string, visiting map[string]bool) (*TaiTable, error) {
+		def, ok := file.Tables[name]
+		if !ok {
+			if table, ok := base[name]; ok {
+				return table, nil
+			}
+			return nil, fmt.Errorf("unknown tai table: %s", name)
+		}
+		if visiting[name] {
+			return nil, fmt.Errorf("tai table %s extends itself", name)
+		}
+		visiting[name] = true
+
+		var rules []TaiRule
+		if def.Extends != "" {
+			parent, err := resolve(def.Extends, visiting)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, parent.Rules...)
+		}
+		for _, r := range def.Rules {
+			rules = mergeTaiRule(rules, r)
+		}
+
+		table := &TaiTable{Name: name, Rules: rules}
+		if err := table.Validate(); err != nil {
+			return nil, err
+		}
+		delete(visiting, name)
+		return table, nil
+	}
+
+	for name := range file.Tables {
+		table, err := resolve(name, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		loaded[name] = table
+	}
+	return loaded, nil
+}
+
+// mergeTaiRule 將牌型加入規則列表，同 ID 者覆寫
+func mergeTaiRule(rules []TaiRule, r TaiRule) []TaiRule {
+	for i := range rules {
+		if rules[i].ID == r.ID {
+			rules[i] = r
+			return rules
+		}
+	}
+	return append(rules, r)
+}
+
+// Validate 檢查牌型 ID 不重複，且取代/互斥的牌型都存在
+func (t *TaiTable) Validate() error {
+	ids := make(map[string]bool, len(t.Rules))
+	for _, r := range t.Rules {
+		if r.ID == "" {
+			return fmt.Errorf("tai table %s: rule without id", t.Name)
+		}
+		if ids[r.ID] {
+			return fmt.Errorf("tai table %s: duplicate rule %s", t.Name, r.ID)
+		}
+		ids[r.ID] = true
+	}
+	for _, r := range t.Rules {
+		for _, ref := range append(append([]string{}, r.Overrides...), r.Excludes...) {
+			if !ids[ref] {
+				return fmt.Errorf("tai table %s: rule %s refers to unknown rule %s", t.Name, r.ID, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// condition 牌型使用的計台條件 (省略時同 ID)
+func (r TaiRule) condition() string {
+	if r.Condition != "" {
+		return r.Condition
+	}
+	return r.ID
+}
+
+// Apply 依台數表將偵測到的條件換算為牌型與台數
+// 先移除被取代 (Overrides) 的牌型，再處理互斥 (Excludes) 的牌型 (保留台數較高者)
+func (t *TaiTable) Apply(conds HandConditions) ScoreResult {
+	type hit struct {
+		rule TaiRule
+		tai  int
+	}
+
+	var hits []hit
+	present := make(map[string]bool)
+	for _, r := range t.Rules {
+		if n := conds[r.condition()]; n > 0 && r.Tai > 0 {
+			hits = append(hits, hit{rule: r, tai: r.Tai * n})
+			present[r.ID] = true
+		}
+	}
+
+	// 台數表未定義的特殊牌型，採牌型本身的台數
+	for id := range conds {
+		shape, ok := specialShapeRegistry[id]
+		if !ok || t.hasCondition(id) {
+			continue
+		}
+		hits = append(hits, hit{rule: TaiRule{ID: shape.ID, Name: shape.Name, Tai: shape.Tai}, tai: shape.Tai})
+		present[shape.ID] = true
+	}
+
+	removed := make(map[string]bool)
+	for _, h := range hits {
+		for _, id := range h.rule.Overrides {
+			removed[id] = true
+		}
+	}
+
+	taiOf := make(map[string]int, len(hits))
+	for _, h := range hits {
+		taiOf[h.rule.ID] = h.tai
+	}
+	for _, h := range hits {
+		if removed[h.rule.ID] {
+			continue
+		}
+		for _, id := range h.rule.Excludes {
+			if !present[id] || removed[id] {
+				continue
+			}
+			if taiOf[id] > h.tai {
+				removed[h.rule.ID] = true
+			} else {
+				removed[id] = true
+			}
+		}
+	}
+
+	res := NewScoreResult()
+	for _, h := range hits {
+		if !removed[h.rule.ID] {
+			res.AddPattern(h.rule.Name, h.tai)
+		}
+	}
+	return res
+}
+
+// hasCondition 台數表中是否有牌型使用此條件
+func (t *TaiTable) hasCondition(id string) bool {
+	for _, r := range t.Rules {
+		if r.condition() == id {
+			return true
+		}
+	}
+	return false
+}
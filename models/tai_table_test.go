@@ -0,0 +1,78 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func loadTestTaiTables(t *testing.T) {
+	t.Helper()
+	saved := taiTables
+	t.Cleanup(func() { taiTables = saved })
+
+	if err := LoadTaiTables("../config/tai_rules.yaml"); err != nil {
+		t.Fatalf("Failed to load tai rules: %v", err)
+	}
+}
+
+func TestTaiTable_StrictAllHonorsOverridesAllTriplets(t *testing.T) {
+	loadTestTaiTables(t)
+
+	// 字一色 (碰碰胡型)
+	hand := []Tile{
+		{Type: Wind, Value: 1}, {Type: Wind, Value: 1}, {Type: Wind, Value: 1},
+		{Type: Wind, Value: 2}, {Type: Wind, Value: 2}, {Type: Wind, Value: 2},
+		{Type: Dragon, Value: 1}, {Type: Dragon, Value: 1}, {Type: Dragon, Value: 1},
+		{Type: Dragon, Value: 2}, {Type: Dragon, Value: 2}, {Type: Dragon, Value: 2},
+		{Type: Wind, Value: 4},
+	}
+	ctx := ScoringContext{
+		ClosedHand:  hand,
+		WinningTile: Tile{Type: Wind, Value: 4},
+		Melds:       []Meld{{Type: MeldTypePong, Tiles: []Tile{{Type: Dragon, Value: 3}, {Type: Dragon, Value: 3}, {Type: Dragon, Value: 3}}}},
+	}
+
+	standard := CalculateScore(ctx)
+	if standard.Patterns["碰碰胡"] != 4 || standard.Patterns["字一色"] != 16 {
+		t.Errorf("Standard table should stack 字一色 and 碰碰胡, got %v", standard.Patterns)
+	}
+
+	strict, ok := LookupTaiTable("strict")
+	if !ok {
+		t.Fatalf("Expected strict table to be loaded")
+	}
+	ctx.TaiTable = strict
+	res := CalculateScore(ctx)
+	if _, ok := res.Patterns["碰碰胡"]; ok {
+		t.Errorf("Strict table: 字一色 should supersede 碰碰胡, got %v", res.Patterns)
+	}
+	if res.TotalTai != standard.TotalTai-4 {
+		t.Errorf("Expected strict total %d, got %d", standard.TotalTai-4, res.TotalTai)
+	}
+}
+
+func TestTaiTable_ApplyPrecedence(t *testing.T) {
+	table := &TaiTable{Name: "test", Rules: []TaiRule{
+		{ID: "a", Name: "A", Tai: 1},
+		{ID: "b", Name: "B", Tai: 3, Overrides: []string{"a"}},
+		{ID: "c", Name: "C", Tai: 2, Excludes: []string{"d"}},
+		{ID: "d", Name: "D", Tai: 5},
+		{ID: "e", Name: "E", Tai: 2, Condition: CondSeatFlower},
+	}}
+	if err := table.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+
+	res := table.Apply(HandConditions{"a": 1, "b": 1, "c": 1, "d": 1, CondSeatFlower: 2})
+	want := map[string]int{"B": 3, "D": 5, "E": 4}
+	if !reflect.DeepEqual(res.Patterns, want) || res.TotalTai != 12 {
+		t.Errorf("Expected %v (12), got %v (%d)", want, res.Patterns, res.TotalTai)
+	}
+}
+
+func TestTaiTable_ValidateUnknownReference(t *testing.T) {
+	table := &TaiTable{Name: "bad", Rules: []TaiRule{{ID: "a", Name: "A", Tai: 1, Overrides: []string{"missing"}}}}
+	if err := table.Validate(); err == nil {
+		t.Errorf("Expected error for unknown override reference")
+	}
+}
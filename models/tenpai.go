@@ -0,0 +1,84 @@
+package models
+
+// WaitHint 聽牌提示中的一種胡牌張
+type WaitHint struct {
+	Tile      Tile `json:"tile"`      // 胡牌張 (代表牌，ID 為登錄表中該牌種的第一張)
+	Remaining int  `json:"remaining"` // 尚未現身的張數 (扣除自己手牌、河牌與所有副露)
+	Tai       int  `json:"tai"`       // 以放槍計算的預估台數
+}
+
+// DiscardHint 打出某張牌後的聽牌提示
+type DiscardHint struct {
+	Discard Tile       `json:"discard"` // 打出的牌 (手牌中的實際那張)
+	Waits   []WaitHint `json:"waits"`   // 打出後能胡的牌 (依牌種排序)
+}
+
+// TenpaiHints 計算出牌前手牌 (3N+2 張暗牌) 每種可打的牌打出後的聽牌
+// visible: 場上已現身的牌 (四家河牌與副露)；base: 計分上下文 (副露、花牌、風位、特殊牌型、台數表)
+// 只回傳打出後有聽牌的候選，同一牌種只列一次
+func TenpaiHints(hand []Tile, visible []Tile, base ScoringContext) []DiscardHint {
+	var seenCounts [34]int
+	for _, t := range visible {
+		if idx := t.ToIndex(); idx >= 0 {
+			seenCounts[idx]++
+		}
+	}
+
+	var hints []DiscardHint
+	tried := make(map[int]bool)
+	for i, discard := range hand {
+		idx := discard.ToIndex()
+		if idx < 0 || tried[idx] {
+			continue
+		}
+		tried[idx] = true
+
+		closed := make([]Tile, 0, len(hand))
+		closed = append(closed, hand[:i]...)
+		closed = append(closed, hand[i+1:]...)
+
+		waits := waitHints(closed, discard, seenCounts, base)
+		if len(waits) > 0 {
+			hints = append(hints, DiscardHint{Discard: discard, Waits: waits})
+		}
+	}
+	return hints
+}
+
+// waitHints 暗牌 (3N+1 張) 的所有胡牌張，含剩餘張數與預估台數
+func waitHints(closed []Tile, discard Tile, seenCounts [34]int, base ScoringContext) []WaitHint {
+	var ownCounts [34]int
+	for _, t := range closed {
+		if idx := t.ToIndex(); idx >= 0 {
+			ownCounts[idx]++
+		}
+	}
+
+	var waits []WaitHint
+	test := make([]Tile, len(closed), len(closed)+1)
+	copy(test, closed)
+
+	for idx := 0; idx < 34; idx++ {
+		t := TileFromIndex(idx)
+		if !IsWinningHand(append(test, t), base.SpecialShapes) {
+			continue
+		}
+
+		remaining := 4 - ownCounts[idx] - seenCounts[idx]
+		if discard.ToIndex() == idx {
+			remaining-- // 打出的牌也會現身在河裡
+		}
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		ctx := base
+		ctx.ClosedHand = closed
+		ctx.WinningTile = t
+		ctx.IsSelfDrawn = false
+
+		t.ID = KindTileID(t)
+		waits = append(waits, WaitHint{Tile: t, Remaining: remaining, Tai: CalculateScore(ctx).TotalTai})
+	}
+	return waits
+}
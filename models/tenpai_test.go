@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+)
+
+func findDiscardHint(hints []DiscardHint, notation string) *DiscardHint {
+	for i := range hints {
+		if NotationOf(hints[i].Discard) == notation {
+			return &hints[i]
+		}
+	}
+	return nil
+}
+
+func TestTenpaiHints_TwoSidedWait(t *testing.T) {
+	// 打出 中 後聽 1萬/4萬 (兩面)，場上已現身兩張 1萬
+	hand := MustParseTiles("23m567p123456s99p5z")
+	visible := MustParseTiles("11m")
+
+	hints := TenpaiHints(hand, visible, ScoringContext{})
+	hint := findDiscardHint(hints, "5z")
+	if hint == nil {
+		t.Fatalf("Expected a hint for discarding 5z, got %v", hints)
+	}
+	if len(hint.Waits) != 2 {
+		t.Fatalf("Expected 2 waits, got %v", hint.Waits)
+	}
+
+	want := map[string]int{"1m": 2, "4m": 4}
+	for _, w := range hint.Waits {
+		n := NotationOf(w.Tile)
+		if remaining, ok := want[n]; !ok || w.Remaining != remaining {
+			t.Errorf("Unexpected wait %s remaining %d", n, w.Remaining)
+		}
+		if w.Tile.ID != KindTileID(w.Tile) {
+			t.Errorf("Expected representative tile id for %s, got %d", n, w.Tile.ID)
+		}
+		// 平胡 (2) + 門清 (1)
+		if w.Tai != 3 {
+			t.Errorf("Expected 3 tai for %s, got %d", n, w.Tai)
+		}
+	}
+}
+
+func TestTenpaiHints_DiscardCountsAsSeen(t *testing.T) {
+	// 打出 9筒 後單吊 9筒：自己手上一張、河裡一張，只剩兩張
+	hand := MustParseTiles("123m567p123456s99p")
+
+	hint := findDiscardHint(TenpaiHints(hand, nil, ScoringContext{}), "9p")
+	if hint == nil {
+		t.Fatal("Expected a hint for discarding 9p")
+	}
+	for _, w := range hint.Waits {
+		if NotationOf(w.Tile) == "9p" && w.Remaining != 2 {
+			t.Errorf("Expected 2 remaining 9p, got %d", w.Remaining)
+		}
+	}
+}
+
+func TestTenpaiHints_NoWaits(t *testing.T) {
+	hand := MustParseTiles("147m258p369s1234z")
+	if hints := TenpaiHints(hand, nil, ScoringContext{}); len(hints) != 0 {
+		t.Errorf("Expected no hints, got %v", hints)
+	}
+}
@@ -0,0 +1,116 @@
+package models
+
+import "fmt"
+
+// 牌 ID 登錄表：全系統唯一的 ID ↔ (類型, 數值, 第幾張) 對照
+//
+//	萬 1-36、筒 37-72、條 73-108、風 109-124、元 125-136、花 137-144
+//	同一種牌的 4 張依序為第 0-3 張，例如 ID 1-4 為 1萬 的第 0-3 張
+const (
+	MinTileID       = 1   // 最小的牌 ID
+	MaxTileID       = 144 // 最大的牌 ID (含花牌)
+	MaxNonFlowerID  = 136 // 不含花牌時的最大 ID
+	copiesPerTile   = 4   // 萬筒條風元每種 4 張
+	flowerTileCount = 8   // 花牌各 1 張
+)
+
+// TileDef 登錄表中的一張牌
+type TileDef struct {
+	ID       int      `json:"id"`
+	Type     TileType `json:"type"`
+	Value    int      `json:"value"`
+	Copy     int      `json:"copy"`     // 同種牌的第幾張 (0-3，花牌為 0)
+	Notation string   `json:"notation"` // 牌譜記法，例如 "5m"
+	Name     string   `json:"name"`     // 中文名稱，例如 "5萬"
+}
+
+// Tile 轉為遊戲中使用的 Tile
+func (d TileDef) Tile() Tile {
+	return Tile{ID: d.ID, Type: d.Type, Value: d.Value}
+}
+
+// tileRegistry 依 ID 排列的登錄表 (索引 0 為 ID 1)
+var tileRegistry = buildTileRegistry()
+
+func buildTileRegistry() []TileDef {
+	defs := make([]TileDef, 0, MaxTileID)
+	add := func(tileType TileType, maxValue, copies int) {
+		for value := 1; value <= maxValue; value++ {
+			for c := 0; c < copies; c++ {
+				t := Tile{ID: len(defs) + 1, Type: tileType, Value: value}
+				defs = append(defs, TileDef{
+					ID:       t.ID,
+					Type:     tileType,
+					Value:    value,
+					Copy:     c,
+					Notation: NotationOf(t),
+					Name:     t.String(),
+				})
+			}
+		}
+	}
+
+	add(Wan, 9, copiesPerTile)
+	add(Tong, 9, copiesPerTile)
+	add(Tiao, 9, copiesPerTile)
+	add(Wind, 4, copiesPerTile)
+	add(Dragon, 3, copiesPerTile)
+	add(Flower, flowerTileCount, 1)
+	return defs
+}
+
+// TileRegistry 完整的登錄表 (ID 1-144，回傳複本)
+func TileRegistry() []TileDef {
+	return append([]TileDef(nil), tileRegistry...)
+}
+
+// IsValidTileID 是否為登錄表中的牌 ID
+func IsValidTileID(id int) bool {
+	return id >= MinTileID && id <= MaxTileID
+}
+
+// LookupTileDef 依 ID 查詢登錄表
+func LookupTileDef(id int) (TileDef, bool) {
+	if !IsValidTileID(id) {
+		return TileDef{}, false
+	}
+	return tileRegistry[id-1], true
+}
+
+// TileByID 依 ID 取得完整的牌，ID 不在登錄表中時回傳錯誤
+func TileByID(id int) (Tile, error) {
+	def, ok := LookupTileDef(id)
+	if !ok {
+		return Tile{}, fmt.Errorf("unknown tile id: %d", id)
+	}
+	return def.Tile(), nil
+}
+
+// RegistryTiles 依 ID 順序產生一副牌 (withFlowers 為 false 時不含花牌，共 136 張)
+func RegistryTiles(withFlowers bool) []Tile {
+	last := MaxNonFlowerID
+	if withFlowers {
+		last = MaxTileID
+	}
+	tiles := make([]Tile, 0, last)
+	for _, def := range tileRegistry[:last] {
+		tiles = append(tiles, def.Tile())
+	}
+	return tiles
+}
+
+// MatchesRegistry 牌的類型與數值是否與登錄表中該 ID 一致
+func (t Tile) MatchesRegistry() bool {
+	def, ok := LookupTileDef(t.ID)
+	return ok && def.Type == t.Type && def.Value == t.Value
+}
+
+// KindTileID 牌種在登錄表中的第一個 ID (代表牌，例如提示中的胡牌張)，非法牌種回傳 0
+func KindTileID(t Tile) int {
+	for _, def := range tileRegistry {
+		if def.Type == t.Type && def.Value == t.Value {
+			return def.ID
+		}
+	}
+	return 0
+}
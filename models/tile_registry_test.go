@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestTileRegistry_Layout(t *testing.T) {
+	defs := TileRegistry()
+	if len(defs) != MaxTileID {
+		t.Fatalf("Expected %d tiles, got %d", MaxTileID, len(defs))
+	}
+
+	cases := map[int]TileDef{
+		1:   {ID: 1, Type: Wan, Value: 1, Copy: 0, Notation: "1m", Name: "1萬"},
+		4:   {ID: 4, Type: Wan, Value: 1, Copy: 3, Notation: "1m", Name: "1萬"},
+		37:  {ID: 37, Type: Tong, Value: 1, Copy: 0, Notation: "1p", Name: "1筒"},
+		108: {ID: 108, Type: Tiao, Value: 9, Copy: 3, Notation: "9s", Name: "9條"},
+		109: {ID: 109, Type: Wind, Value: 1, Copy: 0, Notation: "1z", Name: "東"},
+		136: {ID: 136, Type: Dragon, Value: 3, Copy: 3, Notation: "7z", Name: "白"},
+		144: {ID: 144, Type: Flower, Value: 8, Copy: 0, Notation: "8f", Name: "冬"},
+	}
+	for id, want := range cases {
+		if got, ok := LookupTileDef(id); !ok || got != want {
+			t.Errorf("Tile %d: expected %+v, got %+v", id, want, got)
+		}
+	}
+}
+
+func TestTileByID_RejectsUnknown(t *testing.T) {
+	for _, id := range []int{0, -1, 145} {
+		if _, err := TileByID(id); err == nil {
+			t.Errorf("Expected tile id %d to be rejected", id)
+		}
+	}
+	if tile, err := TileByID(73); err != nil || tile != (Tile{ID: 73, Type: Tiao, Value: 1}) {
+		t.Errorf("Unexpected tile 73: %v %v", tile, err)
+	}
+}
+
+func TestRegistryTiles(t *testing.T) {
+	if n := len(RegistryTiles(false)); n != 136 {
+		t.Errorf("Expected 136 tiles without flowers, got %d", n)
+	}
+
+	tiles := RegistryTiles(true)
+	seen := make(map[int]bool)
+	for _, tile := range tiles {
+		if !tile.MatchesRegistry() || seen[tile.ID] {
+			t.Errorf("Tile %v does not match the registry or is duplicated", tile)
+		}
+		seen[tile.ID] = true
+	}
+
+	all := GenerateAllTiles()
+	for _, tile := range tiles {
+		if all[tile.ID] != tile {
+			t.Errorf("GenerateAllTiles disagrees with registry for id %d: %v", tile.ID, all[tile.ID])
+		}
+	}
+}
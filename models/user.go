@@ -26,6 +26,35 @@ type User struct {
 	IsVerified   bool   `json:"is_verified"`
 }
 
+// Role 帳號角色，空字串為一般玩家
+type Role string
+
+const (
+	RoleCoach Role = "coach" // 教練：可查看任一座位的分析資料
+	RoleAdmin Role = "admin" // 管理員：可使用除錯與稽核路由、管理任何房間
+)
+
+// userRoles email → 角色 (config.yaml 的 roles 區塊)
+var userRoles = map[string]Role{}
+
+// InitRoles 設定帳號角色 (email → 角色)，登入時寫入 JWT
+func InitRoles(roles map[string]Role) error {
+	m := make(map[string]Role, len(roles))
+	for email, role := range roles {
+		if role != RoleCoach && role != RoleAdmin {
+			return fmt.Errorf("invalid role %q for %s", role, email)
+		}
+		m[email] = role
+	}
+	userRoles = m
+	return nil
+}
+
+// RoleOf 取得帳號的角色 (未設定時為一般玩家)
+func RoleOf(email string) Role {
+	return userRoles[email]
+}
+
 // CreateUser 建立新使用者，回傳新建的使用者
 func CreateUser(ctx context.Context, username, email, passwordHash string) (*User, error) {
 	// 檢查信箱是否已存在
@@ -85,3 +85,21 @@ func TestUserFlowAndOnline(t *testing.T) {
 	// Cleanup
 	service.RedisClient.FlushDB(ctx)
 }
+
+func TestInitRoles(t *testing.T) {
+	defer InitRoles(nil)
+
+	if err := InitRoles(map[string]Role{"boss@example.com": RoleAdmin, "coach@example.com": RoleCoach}); err != nil {
+		t.Fatalf("Failed to init roles: %v", err)
+	}
+	if RoleOf("boss@example.com") != RoleAdmin || RoleOf("coach@example.com") != RoleCoach {
+		t.Errorf("Unexpected roles: %q %q", RoleOf("boss@example.com"), RoleOf("coach@example.com"))
+	}
+	if RoleOf("player@example.com") != "" {
+		t.Errorf("Expected no role for unlisted account, got %q", RoleOf("player@example.com"))
+	}
+
+	if err := InitRoles(map[string]Role{"x@example.com": "root"}); err == nil {
+		t.Errorf("Expected unknown role to be rejected")
+	}
+}
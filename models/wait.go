@@ -0,0 +1,106 @@
+package models
+
+// WaitShape 聽牌型態 (胡牌張完成的是哪一種搭子)
+type WaitShape int
+
+const (
+	WaitUnknown  WaitShape = 0 // 無法判定
+	WaitTwoSided WaitShape = 1 // 兩面聽 (例: 23 聽 14)
+	WaitEdge     WaitShape = 2 // 邊張 (例: 12 聽 3、89 聽 7)
+	WaitClosed   WaitShape = 3 // 中洞/嵌張 (例: 24 聽 3)
+	WaitPair     WaitShape = 4 // 單吊 (胡牌張做雀頭)
+	WaitDualPon  WaitShape = 5 // 雙碰 (胡牌張湊成刻子)
+)
+
+// String 回傳聽牌型態名稱
+func (w WaitShape) String() string {
+	switch w {
+	case WaitTwoSided:
+		return "兩面"
+	case WaitEdge:
+		return "邊張"
+	case WaitClosed:
+		return "中洞"
+	case WaitPair:
+		return "單吊"
+	case WaitDualPon:
+		return "雙碰"
+	default:
+		return "未知"
+	}
+}
+
+// WaitingTiles 回傳暗牌區 (3N+1 張) 所有能胡的牌種 (每種一張，依索引排序)
+func WaitingTiles(closed []Tile) []Tile {
+	var waits []Tile
+	test := make([]Tile, len(closed), len(closed)+1)
+	copy(test, closed)
+
+	for idx := 0; idx < 34; idx++ {
+		t := TileFromIndex(idx)
+		if CanHu(append(test, t)) {
+			waits = append(waits, t)
+		}
+	}
+	return waits
+}
+
+// TileFromIndex 由 0-33 的索引還原為牌 (ID 為 0)，為 ToIndex 的反函式
+func TileFromIndex(idx int) Tile {
+	switch {
+	case idx < 9:
+		return Tile{Type: Wan, Value: idx + 1}
+	case idx < 18:
+		return Tile{Type: Tong, Value: idx - 9 + 1}
+	case idx < 27:
+		return Tile{Type: Tiao, Value: idx - 18 + 1}
+	case idx < 31:
+		return Tile{Type: Wind, Value: idx - 27 + 1}
+	default:
+		return Tile{Type: Dragon, Value: idx - 31 + 1}
+	}
+}
+
+// waitShapeOf 判斷胡牌張在該組合中構成的聽牌型態
+func waitShapeOf(combo TileCombo, winningTile Tile) WaitShape {
+	switch combo.Type {
+	case ComboPair:
+		return WaitPair
+	case ComboTriplet:
+		return WaitDualPon
+	case ComboSequence:
+		low := combo.Tiles[0].Value
+		switch winningTile.Value {
+		case low + 1:
+			return WaitClosed
+		case low + 2:
+			if low == 1 {
+				return WaitEdge // 12 聽 3
+			}
+			return WaitTwoSided
+		case low:
+			if low == 7 {
+				return WaitEdge // 89 聽 7
+			}
+			return WaitTwoSided
+		}
+	}
+	return WaitUnknown
+}
+
+// waitCandidates 回傳拆解中可能由胡牌張完成的組合索引 (副露不算)
+func waitCandidates(p Partition, winningTile Tile) []int {
+	var idxs []int
+	for i, combo := range p.Combos {
+		if combo.IsMeld {
+			continue
+		}
+		for _, t := range combo.Tiles {
+			if t.Type == winningTile.Type && t.Value == winningTile.Value {
+				idxs = append(idxs, i)
+				break
+			}
+		}
+	}
+	return idxs
+}
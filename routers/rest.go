@@ -4,6 +4,8 @@ import (
 	"github.com/maoxiaoyue/hypgo/pkg/router"
 
 	"webmajiang/controllers"
+	"webmajiang/middlewares"
+	"webmajiang/models"
 )
 
 // setupRestRoutes 註冊所有 REST API 路由
@@ -26,7 +28,14 @@ func setupBaseRoutes(r *router.Router) {
 
 // setupGameRoutes 註冊遊戲相關路由
 func setupGameRoutes(r *router.Router) {
-	r.POST("/api/game/start", controllers.StartGameHandler)
+	r.POST("/api/game/start", middlewares.AuthOptional(), controllers.StartGameHandler)
+	r.GET("/api/game/:id/result", controllers.GetGameResultHandler)
+	r.GET("/api/game/:id/hands", middlewares.AuthRequired(), middlewares.RoleRequired(models.RoleAdmin), controllers.GetGameHandsHandler)
+	r.GET("/api/game/:id/audit", controllers.GetGameAuditHandler)
+	r.GET("/api/game/:id/audit/snapshot", middlewares.AuthRequired(), middlewares.RoleRequired(models.RoleAdmin), controllers.GetGameAuditSnapshotHandler)
+	r.GET("/api/game/:id/danger/:seat", middlewares.AuthRequired(), controllers.GetGameDangerHandler)
+	r.POST("/api/game/:id/bots/:seat", middlewares.AuthRequired(), controllers.AttachBotHandler)
+	r.GET("/api/tiles", controllers.GetTileRegistryHandler)
 }
 
 // setupAuthRoutes 註冊認證相關路由
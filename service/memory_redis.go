@@ -0,0 +1,556 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/redis/go-redis/v9/maintnotifications"
+)
+
+// MemoryStore 記憶體中的 Redis 相容儲存，供模擬對局與測試使用
+// 以 RESP 協定實作遊戲用到的指令，go-redis 透過 net.Pipe 直接連線，不需要外部 Redis
+// 不處理過期時間 (EX/PX/EXPIRE 只會被接受)
+type MemoryStore struct {
+	mu   sync.Mutex
+	data map[string]any // string、[]string (list)、map[string]string (hash)、map[string]struct{} (set)
+}
+
+// NewMemoryStore 建立空的記憶體儲存
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string]any)}
+}
+
+// InitMemoryRedis 以記憶體儲存取代 Redis 連線
+func InitMemoryRedis() (*MemoryStore, error) {
+	store := NewMemoryStore()
+	RedisClient = redis.NewClient(&redis.Options{
+		Addr:     "memory",
+		Protocol: 2,
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go store.serve(server)
+			return client, nil
+		},
+		DisableIdentity:          true,
+		MaintNotificationsConfig: &maintnotifications.Config{Mode: maintnotifications.ModeDisabled},
+	})
+
+	if err := RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("memory store connection failed: %w", err)
+	}
+	return store, nil
+}
+
+// errWrongType 對不同型別的 key 操作
+var errWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+// serve 處理一條連線上的指令直到連線關閉
+func (s *MemoryStore) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	var queued [][]string // MULTI 之後排隊的指令
+	inMulti := false
+
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		switch name := strings.ToUpper(args[0]); {
+		case name == "MULTI":
+			inMulti, queued = true, nil
+			writeReply(w, "OK")
+		case name == "EXEC" && inMulti:
+			inMulti = false
+			replies := make([]any, 0, len(queued))
+			for _, cmd := range queued {
+				replies = append(replies, s.exec(cmd))
+			}
+			writeReply(w, replies)
+		case name == "DISCARD" && inMulti:
+			inMulti, queued = false, nil
+			writeReply(w, "OK")
+		case inMulti:
+			queued = append(queued, args)
+			writeReply(w, "QUEUED")
+		default:
+			writeReply(w, s.exec(args))
+		}
+
+		// 管線中的指令一起回覆
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readCommand 讀取一個 RESP 陣列指令
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		return strings.Fields(line), nil // inline 指令
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid multibulk length: %q", line)
+	}
+
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		header, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(header) == 0 || header[0] != '$' {
+			return nil, fmt.Errorf("invalid bulk header: %q", header)
+		}
+		size, err := strconv.Atoi(header[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk length: %q", header)
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// bulk 字串回覆 (與狀態回覆 "OK" 區分)
+type bulk string
+
+// writeReply 依 Go 型別寫出 RESP 回覆：string 為狀態、bulk 為字串、nil 為空值
+func writeReply(w *bufio.Writer, reply any) {
+	switch v := reply.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case string:
+		w.WriteString("+" + v + "\r\n")
+	case bulk:
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case int:
+		fmt.Fprintf(w, ":%d\r\n", v)
+	case error:
+		msg := v.Error()
+		if !strings.HasPrefix(msg, "WRONGTYPE") {
+			msg = "ERR " + msg
+		}
+		w.WriteString("-" + msg + "\r\n")
+	case []bulk:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	case []any:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	}
+}
+
+// exec 執行單一指令並回傳回覆
+func (s *MemoryStore) exec(args []string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.ToUpper(args[0])
+	argc := len(args) - 1
+	need := func(n int) error {
+		if argc < n {
+			return fmt.Errorf("wrong number of arguments for '%s' command", strings.ToLower(name))
+		}
+		return nil
+	}
+
+	switch name {
+	case "PING":
+		return "PONG"
+	case "SELECT", "CLIENT":
+		return "OK"
+	case "FLUSHDB", "FLUSHALL":
+		s.data = make(map[string]any)
+		return "OK"
+
+	// === string ===
+	case "GET":
+		if err := need(1); err != nil {
+			return err
+		}
+		v, ok := s.data[args[1]]
+		if !ok {
+			return nil
+		}
+		str, ok := v.(string)
+		if !ok {
+			return errWrongType
+		}
+		return bulk(str)
+	case "SET":
+		if err := need(2); err != nil {
+			return err
+		}
+		_, exists := s.data[args[1]]
+		for _, opt := range args[3:] {
+			switch strings.ToUpper(opt) {
+			case "NX":
+				if exists {
+					return nil
+				}
+			case "XX":
+				if !exists {
+					return nil
+				}
+			}
+		}
+		s.data[args[1]] = args[2]
+		return "OK"
+	case "SETNX":
+		if err := need(2); err != nil {
+			return err
+		}
+		if _, exists := s.data[args[1]]; exists {
+			return 0
+		}
+		s.data[args[1]] = args[2]
+		return 1
+	case "INCR":
+		if err := need(1); err != nil {
+			return err
+		}
+		n := 0
+		if v, ok := s.data[args[1]]; ok {
+			str, ok := v.(string)
+			if !ok {
+				return errWrongType
+			}
+			var err error
+			if n, err = strconv.Atoi(str); err != nil {
+				return errors.New("value is not an integer or out of range")
+			}
+		}
+		n++
+		s.data[args[1]] = strconv.Itoa(n)
+		return n
+
+	// === key ===
+	case "DEL":
+		removed := 0
+		for _, key := range args[1:] {
+			if _, ok := s.data[key]; ok {
+				delete(s.data, key)
+				removed++
+			}
+		}
+		return removed
+	case "EXISTS":
+		found := 0
+		for _, key := range args[1:] {
+			if _, ok := s.data[key]; ok {
+				found++
+			}
+		}
+		return found
+	case "EXPIRE", "PEXPIRE":
+		if err := need(2); err != nil {
+			return err
+		}
+		if _, ok := s.data[args[1]]; ok {
+			return 1
+		}
+		return 0
+	case "EXPIREMEMBER":
+		return 1
+
+	// === list ===
+	case "LPUSH", "RPUSH":
+		if err := need(2); err != nil {
+			return err
+		}
+		list, err := s.list(args[1])
+		if err != nil {
+			return err
+		}
+		for _, v := range args[2:] {
+			if name == "LPUSH" {
+				list = append([]string{v}, list...)
+			} else {
+				list = append(list, v)
+			}
+		}
+		s.data[args[1]] = list
+		return len(list)
+	case "LPOP", "RPOP":
+		if err := need(1); err != nil {
+			return err
+		}
+		list, err := s.list(args[1])
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return nil
+		}
+		var v string
+		if name == "LPOP" {
+			v, list = list[0], list[1:]
+		} else {
+			v, list = list[len(list)-1], list[:len(list)-1]
+		}
+		s.storeList(args[1], list)
+		return bulk(v)
+	case "LLEN":
+		if err := need(1); err != nil {
+			return err
+		}
+		list, err := s.list(args[1])
+		if err != nil {
+			return err
+		}
+		return len(list)
+	case "LRANGE":
+		if err := need(3); err != nil {
+			return err
+		}
+		list, err := s.list(args[1])
+		if err != nil {
+			return err
+		}
+		start, err1 := strconv.Atoi(args[2])
+		stop, err2 := strconv.Atoi(args[3])
+		if err1 != nil || err2 != nil {
+			return errors.New("value is not an integer or out of range")
+		}
+		start, stop = listIndex(start, len(list)), listIndex(stop, len(list))
+		start = max(start, 0)
+		stop = min(stop, len(list)-1)
+		items := []bulk{}
+		for i := start; i <= stop; i++ {
+			items = append(items, bulk(list[i]))
+		}
+		return items
+	case "LINDEX":
+		if err := need(2); err != nil {
+			return err
+		}
+		list, err := s.list(args[1])
+		if err != nil {
+			return err
+		}
+		i, err := strconv.Atoi(args[2])
+		if err != nil {
+			return errors.New("value is not an integer or out of range")
+		}
+		if i = listIndex(i, len(list)); i < 0 || i >= len(list) {
+			return nil
+		}
+		return bulk(list[i])
+	case "LSET":
+		if err := need(3); err != nil {
+			return err
+		}
+		list, err := s.list(args[1])
+		if err != nil {
+			return err
+		}
+		i, err := strconv.Atoi(args[2])
+		if err != nil {
+			return errors.New("value is not an integer or out of range")
+		}
+		if i = listIndex(i, len(list)); i < 0 || i >= len(list) {
+			return errors.New("index out of range")
+		}
+		list[i] = args[3]
+		return "OK"
+	case "LREM":
+		if err := need(3); err != nil {
+			return err
+		}
+		list, err := s.list(args[1])
+		if err != nil {
+			return err
+		}
+		count, err := strconv.Atoi(args[2])
+		if err != nil {
+			return errors.New("value is not an integer or out of range")
+		}
+		removed := 0
+		kept := make([]string, 0, len(list))
+		if count >= 0 {
+			for _, v := range list {
+				if v == args[3] && (count == 0 || removed < count) {
+					removed++
+					continue
+				}
+				kept = append(kept, v)
+			}
+		} else {
+			for i := len(list) - 1; i >= 0; i-- {
+				if list[i] == args[3] && removed < -count {
+					removed++
+					continue
+				}
+				kept = append([]string{list[i]}, kept...)
+			}
+		}
+		s.storeList(args[1], kept)
+		return removed
+
+	// === hash ===
+	case "HSET":
+		if err := need(3); err != nil || argc%2 != 1 {
+			return fmt.Errorf("wrong number of arguments for 'hset' command")
+		}
+		h, err := s.hash(args[1], true)
+		if err != nil {
+			return err
+		}
+		added := 0
+		for i := 2; i+1 < len(args); i += 2 {
+			if _, ok := h[args[i]]; !ok {
+				added++
+			}
+			h[args[i]] = args[i+1]
+		}
+		return added
+	case "HGET", "HEXISTS":
+		if err := need(2); err != nil {
+			return err
+		}
+		h, err := s.hash(args[1], false)
+		if err != nil {
+			return err
+		}
+		v, ok := h[args[2]]
+		if name == "HEXISTS" {
+			if ok {
+				return 1
+			}
+			return 0
+		}
+		if !ok {
+			return nil
+		}
+		return bulk(v)
+
+	// === set ===
+	case "SADD":
+		if err := need(2); err != nil {
+			return err
+		}
+		set, err := s.set(args[1], true)
+		if err != nil {
+			return err
+		}
+		added := 0
+		for _, m := range args[2:] {
+			if _, ok := set[m]; !ok {
+				set[m] = struct{}{}
+				added++
+			}
+		}
+		return added
+	case "SISMEMBER":
+		if err := need(2); err != nil {
+			return err
+		}
+		set, err := s.set(args[1], false)
+		if err != nil {
+			return err
+		}
+		if _, ok := set[args[2]]; ok {
+			return 1
+		}
+		return 0
+	}
+	return fmt.Errorf("unknown command '%s'", args[0])
+}
+
+// listIndex 負數索引由尾端起算
+func listIndex(i, n int) int {
+	if i < 0 {
+		return n + i
+	}
+	return i
+}
+
+func (s *MemoryStore) list(key string) ([]string, error) {
+	v, ok := s.data[key]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := v.([]string)
+	if !ok {
+		return nil, errWrongType
+	}
+	return list, nil
+}
+
+// storeList 清單為空時刪除 key (與 Redis 相同)
+func (s *MemoryStore) storeList(key string, list []string) {
+	if len(list) == 0 {
+		delete(s.data, key)
+		return
+	}
+	s.data[key] = list
+}
+
+func (s *MemoryStore) hash(key string, create bool) (map[string]string, error) {
+	v, ok := s.data[key]
+	if !ok {
+		h := make(map[string]string)
+		if create {
+			s.data[key] = h
+		}
+		return h, nil
+	}
+	h, ok := v.(map[string]string)
+	if !ok {
+		return nil, errWrongType
+	}
+	return h, nil
+}
+
+func (s *MemoryStore) set(key string, create bool) (map[string]struct{}, error) {
+	v, ok := s.data[key]
+	if !ok {
+		set := make(map[string]struct{})
+		if create {
+			s.data[key] = set
+		}
+		return set, nil
+	}
+	set, ok := v.(map[string]struct{})
+	if !ok {
+		return nil, errWrongType
+	}
+	return set, nil
+}
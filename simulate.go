@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"webmajiang/controllers"
+	"webmajiang/models"
+	"webmajiang/service"
+	"webmajiang/utils"
+)
+
+// runSimulate webmajiang simulate：四家 AI 在記憶體中自我對戰，輸出各策略的統計
+func runSimulate(args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	games := fs.Int("games", 100, "number of full games to play")
+	seed := fs.Uint64("seed", 1, "seed for shuffling and dice (same seed and rules give the same result)")
+	rulesArg := fs.String("rules", "", "room rules as JSON, or @path to a JSON file (default: 16-tile rules)")
+	strategies := fs.String("strategies", "", "comma-separated bot strategies for seats 1-4, e.g. efficiency,basic,basic,beginner")
+	taiRules := fs.String("tai-rules", "config/tai_rules.yaml", "tai table file")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	verbose := fs.Bool("v", false, "print game logs")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *games <= 0 {
+		return fmt.Errorf("-games must be positive")
+	}
+
+	rules, err := parseSimulationRules(*rulesArg)
+	if err != nil {
+		return err
+	}
+	if *strategies != "" {
+		names := strings.Split(*strategies, ",")
+		if len(names) != 4 {
+			return fmt.Errorf("-strategies needs 4 entries, got %d", len(names))
+		}
+		rules.BotStrategies = make(map[int]models.AIStrategy, 4)
+		for i, name := range names {
+			s := models.AIStrategy(strings.TrimSpace(name))
+			if !s.Valid() {
+				return fmt.Errorf("seat %d: unknown strategy %q", i+1, name)
+			}
+			rules.BotStrategies[i+1] = s
+		}
+	}
+
+	if err := models.LoadTaiTables(*taiRules); err != nil {
+		return err
+	}
+	if _, err := service.InitMemoryRedis(); err != nil {
+		return err
+	}
+	defer service.CloseRedis()
+	utils.SetQuiet(!*verbose)
+
+	report, err := controllers.Simulate(context.Background(), controllers.SimulationConfig{
+		Games: *games,
+		Seed:  *seed,
+		Rules: rules,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printSimulationReport(os.Stdout, report)
+	return nil
+}
+
+// parseSimulationRules 解析 -rules (JSON 字串或 @檔案)，未指定時採 16 張預設規則
+func parseSimulationRules(arg string) (models.RoomRules, error) {
+	if arg == "" {
+		return models.DefaultRoomRules(models.GameType16), nil
+	}
+	data := []byte(arg)
+	if path, ok := strings.CutPrefix(arg, "@"); ok {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return models.RoomRules{}, fmt.Errorf("failed to read rules: %w", err)
+		}
+	}
+	return models.ParseRoomRules(data)
+}
+
+// printSimulationReport 以表格輸出各策略統計與牌型頻率
+func printSimulationReport(w io.Writer, r *models.SimulationReport) {
+	fmt.Fprintf(w, "seed %d: %d games, %d rounds, %d draws\n\n", r.Seed, r.Games, r.Rounds, r.Draws)
+	fmt.Fprintf(w, "%-12s %7s %8s %8s %8s %8s\n", "strategy", "hands", "win", "deal-in", "draw", "avg tai")
+	for _, s := range r.Strategies {
+		fmt.Fprintf(w, "%-12s %7d %7.1f%% %7.1f%% %7.1f%% %8.2f\n",
+			s.Strategy, s.Hands, s.WinRate()*100, s.DealInRate()*100, s.DrawRate()*100, s.AverageTai())
+	}
+
+	for _, s := range r.Strategies {
+		fmt.Fprintf(w, "\n%s patterns (%d wins, %d self-drawn)\n", s.Strategy, s.Wins, s.SelfWins)
+		for _, p := range s.PatternFrequencies() {
+			fmt.Fprintf(w, "  %6d %6.1f%%  %s\n", p.Count, p.Rate*100, p.Name)
+		}
+	}
+}
@@ -23,16 +23,18 @@ func InitJWT(secret string) {
 type Claims struct {
 	UserID   int64  `json:"user_id"`
 	Username string `json:"username"`
+	Role     string `json:"role,omitempty"` // 帳號角色 (admin、coach)，一般玩家為空
 	jwt.RegisteredClaims
 }
 
 // GenerateJWT generates a JWT token for the user.
-func GenerateJWT(id int64, username string, duration time.Duration) (string, error) {
+func GenerateJWT(id int64, username, role string, duration time.Duration) (string, error) {
 	expirationTime := time.Now().Add(duration)
 
 	claims := &Claims{
 		UserID:   id,
 		Username: username,
+		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
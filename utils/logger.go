@@ -10,6 +10,12 @@ import (
 
 var logger *log.Logger
 var currentDate string
+var quiet bool
+
+// SetQuiet 關閉 Info 訊息 (模擬對局等大量對局時使用)，Error 仍會輸出
+func SetQuiet(q bool) {
+	quiet = q
+}
 
 func init() {
 	setupLogger()
@@ -42,6 +48,9 @@ func checkDateRotation() {
 
 // Info logs generic info messages
 func Info(format string, v ...interface{}) {
+	if quiet {
+		return
+	}
 	checkDateRotation()
 	msg := fmt.Sprintf(format, v...)
 	if logger != nil {